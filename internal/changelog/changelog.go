@@ -0,0 +1,200 @@
+// Package changelog tracks what changed since the last run: new releases of
+// the app, updated data packs and character files that were migrated, so a
+// "what's new" overlay can summarize them.
+package changelog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"sheet/internal/ui/glyphs"
+)
+
+// Version returns the running app's module version from its build info,
+// e.g. "v0.5.0", or "" for a development build.
+func Version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "(devel)" {
+		return ""
+	}
+	return info.Main.Version
+}
+
+// Release is the notes for one version.
+type Release struct {
+	Version string
+	Notes   []string
+}
+
+// Releases lists the notable changes per version, newest first. Add an
+// entry when tagging a release.
+var Releases []Release
+
+// State is what was recorded at the end of the last run.
+type State struct {
+	Version string    `json:"version"`
+	RanAt   time.Time `json:"ran_at"`
+}
+
+// LoadState reads the last-run state. A missing file yields a zero State,
+// as on first launch.
+func LoadState(path string) (State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("failed to read last-run state: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return State{}, fmt.Errorf("failed to parse last-run state: %w", err)
+	}
+	return s, nil
+}
+
+// SaveState records this run so the next one only reports newer changes.
+func SaveState(path string, now time.Time) error {
+	data, err := json.MarshalIndent(State{Version: Version(), RanAt: now}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal last-run state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write last-run state: %w", err)
+	}
+	return nil
+}
+
+// Migration records a character file that was upgraded on load.
+type Migration struct {
+	Character string
+	Summary   string
+}
+
+// Notice is what the "what's new" overlay shows.
+type Notice struct {
+	Releases []Release
+	// DataFiles are data files modified since the last run.
+	DataFiles  []string
+	Migrations []Migration
+}
+
+// Empty reports whether there is nothing to show.
+func (n Notice) Empty() bool {
+	return len(n.Releases) == 0 && len(n.DataFiles) == 0 && len(n.Migrations) == 0
+}
+
+// Check compares the last run against the running version and the data
+// directories. Nothing is reported on first launch, where the tour takes
+// over instead. Release notes are skipped when either run was a
+// development build.
+func Check(last State, dataDirs []string) (Notice, error) {
+	var n Notice
+	if last.RanAt.IsZero() {
+		return n, nil
+	}
+	if current := Version(); current != "" && last.Version != "" {
+		n.Releases = releasesBetween(last.Version, current)
+	}
+	for _, dir := range dataDirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return Notice{}, fmt.Errorf("failed to read data directory: %w", err)
+		}
+		for _, e := range entries {
+			if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				return Notice{}, err
+			}
+			if info.ModTime().After(last.RanAt) {
+				n.DataFiles = append(n.DataFiles, filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+	return n, nil
+}
+
+// releasesBetween returns the releases after from up to and including to.
+func releasesBetween(from, to string) []Release {
+	var out []Release
+	for _, r := range Releases {
+		if compareVersions(r.Version, from) > 0 && compareVersions(r.Version, to) <= 0 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// View renders the notice as overlay text.
+func (n Notice) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString("What's new\n")
+	for _, r := range n.Releases {
+		fmt.Fprintf(&b, "\n%s\n", r.Version)
+		for _, note := range r.Notes {
+			fmt.Fprintf(&b, "  %s %s\n", g.Bullet, note)
+		}
+	}
+	if len(n.DataFiles) > 0 {
+		b.WriteString("\nUpdated data\n")
+		for _, f := range n.DataFiles {
+			fmt.Fprintf(&b, "  %s %s\n", g.Bullet, filepath.Base(f))
+		}
+	}
+	if len(n.Migrations) > 0 {
+		b.WriteString("\nCharacters upgraded\n")
+		for _, m := range n.Migrations {
+			fmt.Fprintf(&b, "  %s %s: %s\n", g.Bullet, m.Character, m.Summary)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// compareVersions compares dotted numeric versions, returning -1, 0 or 1.
+// A leading "v" and any pre-release or build suffix ("-rc.1", "+dirty")
+// are ignored, and missing components count as zero.
+func compareVersions(a, b string) int {
+	pa := strings.Split(versionCore(a), ".")
+	pb := strings.Split(versionCore(b), ".")
+	for i := range max(len(pa), len(pb)) {
+		var x, y int
+		if i < len(pa) {
+			x, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			y, _ = strconv.Atoi(pb[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionCore(v string) string {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	return v
+}
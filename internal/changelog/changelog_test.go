@@ -0,0 +1,81 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.4.0", "0.4.0", 0},
+		{"v0.4.0", "0.4.0", 0},
+		{"0.4", "0.4.0", 0},
+		{"0.10.0", "0.9.0", 1},
+		{"1.0.0", "1.0.1", -1},
+		{"v1.2.0-rc.1", "1.2.0", 0},
+		{"v1.2.0+dirty", "1.1.9", 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestReleasesBetween(t *testing.T) {
+	old := Releases
+	t.Cleanup(func() { Releases = old })
+	Releases = []Release{{Version: "v0.3.0"}, {Version: "v0.2.0"}, {Version: "v0.1.0"}}
+	tests := []struct {
+		from, to string
+		want     []string
+	}{
+		{"v0.1.0", "v0.3.0", []string{"v0.3.0", "v0.2.0"}},
+		{"v0.2.0", "v0.2.0", nil},
+		{"v0.1.0", "v0.2.0", []string{"v0.2.0"}},
+	}
+	for _, tt := range tests {
+		got := releasesBetween(tt.from, tt.to)
+		var versions []string
+		for _, r := range got {
+			versions = append(versions, r.Version)
+		}
+		if !slices.Equal(versions, tt.want) {
+			t.Errorf("releasesBetween(%q, %q) = %v, want %v", tt.from, tt.to, versions, tt.want)
+		}
+	}
+}
+
+func TestCheckDataFiles(t *testing.T) {
+	dir := t.TempDir()
+	last := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, mod := range map[string]time.Time{
+		"spells.json": last.Add(time.Hour),
+		"feats.json":  last.Add(-time.Hour),
+		"notes.txt":   last.Add(time.Hour),
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("[]"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n, err := Check(State{RanAt: last}, []string{dir, filepath.Join(dir, "missing")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(n.DataFiles) != 1 || filepath.Base(n.DataFiles[0]) != "spells.json" {
+		t.Errorf("DataFiles = %v, want [spells.json]", n.DataFiles)
+	}
+	if first, _ := Check(State{}, []string{dir}); !first.Empty() {
+		t.Errorf("first launch notice = %+v, want empty", first)
+	}
+}
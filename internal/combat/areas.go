@@ -0,0 +1,38 @@
+package combat
+
+import (
+	"fmt"
+
+	"sheet/internal/data"
+)
+
+// Standing returns the monsters still above 0 HP.
+func (t *Tracker) Standing() []*Combatant {
+	var out []*Combatant
+	for _, c := range t.Combatants {
+		if !c.IsPlayer && c.HP > 0 {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// TargetHint suggests how many creatures an area catches, capped by the
+// monsters still standing when an encounter is running, e.g.
+// "20-ft-radius sphere: about 4 targets (3 monsters standing)".
+func (t *Tracker) TargetHint(a data.SpellArea) string {
+	n := a.Targets()
+	if t == nil || t.Round == 0 {
+		return fmt.Sprintf("%s: about %s", a, count(n, "target"))
+	}
+	standing := len(t.Standing())
+	return fmt.Sprintf("%s: about %s (%s standing)", a, count(min(n, standing), "target"), count(standing, "monster"))
+}
+
+// count formats n with noun, adding an "s" unless n is one.
+func count(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,169 @@
+package combat
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/rules"
+)
+
+// Timing is when during a combatant's turn an ongoing effect triggers.
+type Timing string
+
+const (
+	StartOfTurn Timing = "start"
+	EndOfTurn   Timing = "end"
+)
+
+// Save is a saving throw an ongoing effect allows.
+type Save struct {
+	Ability    rules.Ability `json:"ability"`
+	DC         int           `json:"dc"`
+	HalfOnSave bool          `json:"half_on_save,omitempty"`
+}
+
+// OngoingEffect is a hazard or lingering damage (burning, a poison cloud,
+// lair effects) that triggers on affected combatants' turns.
+type OngoingEffect struct {
+	Name string `json:"name"`
+	// Targets are the affected combatant names.
+	Targets    []string         `json:"targets"`
+	Timing     Timing           `json:"timing"`
+	Damage     string           `json:"damage,omitempty"`
+	DamageType rules.DamageType `json:"damage_type,omitempty"`
+	Save       *Save            `json:"save,omitempty"`
+	// Automatic effects are applied without prompting when they have no
+	// save; others produce a prompt for the DM.
+	Automatic bool `json:"automatic,omitempty"`
+	// Rounds is how many more rounds the effect lasts; 0 means until
+	// removed.
+	Rounds int `json:"rounds,omitempty"`
+}
+
+// Tick is an ongoing effect triggering on one combatant.
+type Tick struct {
+	Effect OngoingEffect
+	Target *Combatant
+}
+
+// Prompt describes the tick for the DM, e.g.
+// "Burning: Goblin takes 1d6 fire (DEX DC 13 save for half)".
+func (t Tick) Prompt() string {
+	s := fmt.Sprintf("%s: %s", t.Effect.Name, t.Target.Name)
+	if t.Effect.Damage != "" {
+		s += " takes " + t.Effect.Damage
+		if t.Effect.DamageType != "" {
+			s += " " + string(t.Effect.DamageType)
+		}
+	}
+	if sv := t.Effect.Save; sv != nil {
+		s += fmt.Sprintf(" (%s DC %d save", sv.Ability, sv.DC)
+		if sv.HalfOnSave {
+			s += " for half"
+		}
+		s += ")"
+	}
+	return s
+}
+
+// NeedsPrompt reports whether the tick should ask the DM before applying.
+func (t Tick) NeedsPrompt() bool {
+	return !t.Effect.Automatic || t.Effect.Save != nil
+}
+
+// AddOngoing registers an ongoing effect.
+func (t *Tracker) AddOngoing(e OngoingEffect) error {
+	if e.Damage != "" {
+		if _, err := dice.Parse(e.Damage); err != nil {
+			return err
+		}
+	}
+	t.Ongoing = append(t.Ongoing, e)
+	return nil
+}
+
+// RemoveOngoing ends the named ongoing effect.
+func (t *Tracker) RemoveOngoing(name string) {
+	t.Ongoing = slices.DeleteFunc(t.Ongoing, func(e OngoingEffect) bool {
+		return strings.EqualFold(e.Name, name)
+	})
+}
+
+// Advance ends the current turn and starts the next. It returns the new
+// active combatant and the ongoing effects that trigger: end-of-turn
+// effects on the combatant whose turn ended, then start-of-turn effects on
+// the new one. Timed effects count down when a new round begins.
+func (t *Tracker) Advance() (*Combatant, []Tick) {
+	var ticks []Tick
+	if ending := t.Active(); ending != nil {
+		ticks = append(ticks, t.ticksFor(ending, EndOfTurn)...)
+	}
+
+	round := t.Round
+	next := t.Next()
+	if next == nil {
+		return nil, ticks
+	}
+	if t.Round > round && round > 0 {
+		t.expireOngoing()
+	}
+	ticks = append(ticks, t.ticksFor(next, StartOfTurn)...)
+	return next, ticks
+}
+
+func (t *Tracker) ticksFor(c *Combatant, timing Timing) []Tick {
+	var ticks []Tick
+	for _, e := range t.Ongoing {
+		if e.Timing == timing && slices.ContainsFunc(e.Targets, func(n string) bool {
+			return strings.EqualFold(n, c.Name)
+		}) {
+			ticks = append(ticks, Tick{Effect: e, Target: c})
+		}
+	}
+	return ticks
+}
+
+func (t *Tracker) expireOngoing() {
+	kept := t.Ongoing[:0]
+	for _, e := range t.Ongoing {
+		if e.Rounds > 0 {
+			e.Rounds--
+			if e.Rounds == 0 {
+				continue
+			}
+		}
+		kept = append(kept, e)
+	}
+	t.Ongoing = kept
+}
+
+// ApplyTick rolls the tick's damage and applies it to the target, halving
+// it when the target saved against a half-on-save effect (or negating it
+// otherwise). It returns the damage dealt and the roll.
+func (t *Tracker) ApplyTick(tick Tick, r *dice.Roller, saved bool) (int, dice.Result, error) {
+	if tick.Effect.Damage == "" {
+		return 0, dice.Result{}, nil
+	}
+	expr, err := dice.Parse(tick.Effect.Damage)
+	if err != nil {
+		return 0, dice.Result{}, err
+	}
+	res := r.Roll(expr)
+	dmg := max(res.Total, 0)
+	if saved && tick.Effect.Save != nil {
+		if tick.Effect.Save.HalfOnSave {
+			dmg /= 2
+		} else {
+			dmg = 0
+		}
+	}
+	tick.Target.TakeDamage(dmg)
+	return dmg, res, nil
+}
+
+// TakeDamage reduces HP, not below zero.
+func (c *Combatant) TakeDamage(n int) {
+	c.HP = max(c.HP-n, 0)
+}
@@ -0,0 +1,140 @@
+package combat
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/dice"
+	"sheet/internal/rules"
+)
+
+// Recharge is a monster ability that becomes available again on a d6 roll,
+// such as a dragon's breath weapon (Recharge 5–6).
+type Recharge struct {
+	Name string `json:"name"`
+	// On is the lowest d6 face that recharges the ability: 5 for "5–6".
+	On        int  `json:"on"`
+	Available bool `json:"available"`
+}
+
+func (r Recharge) String() string {
+	state := "ready"
+	if !r.Available {
+		state = "spent"
+	}
+	if r.On >= 6 {
+		return fmt.Sprintf("%s (Recharge 6) %s", r.Name, state)
+	}
+	return fmt.Sprintf("%s (Recharge %d-6) %s", r.Name, r.On, state)
+}
+
+// MonsterResources are the limited defensive and offensive resources the
+// tracker keeps for a monster.
+type MonsterResources struct {
+	LegendaryResistances int        `json:"legendary_resistances,omitempty"`
+	LegendaryUsed        int        `json:"legendary_used,omitempty"`
+	Recharges            []Recharge `json:"recharges,omitempty"`
+}
+
+// LegendaryRemaining returns how many legendary resistances are left.
+func (m *MonsterResources) LegendaryRemaining() int {
+	return max(m.LegendaryResistances-m.LegendaryUsed, 0)
+}
+
+// UseLegendaryResistance spends one legendary resistance to turn a failed
+// save into a success.
+func (m *MonsterResources) UseLegendaryResistance() error {
+	if m.LegendaryRemaining() == 0 {
+		return fmt.Errorf("no legendary resistances left")
+	}
+	m.LegendaryUsed++
+	return nil
+}
+
+// Use marks a recharge ability as spent.
+func (m *MonsterResources) Use(name string) error {
+	for i := range m.Recharges {
+		r := &m.Recharges[i]
+		if strings.EqualFold(r.Name, name) {
+			if !r.Available {
+				return fmt.Errorf("%s has not recharged", r.Name)
+			}
+			r.Available = false
+			return nil
+		}
+	}
+	return fmt.Errorf("no recharge ability named %q", name)
+}
+
+// Reset restores everything, for a new day.
+func (m *MonsterResources) Reset() {
+	m.LegendaryUsed = 0
+	for i := range m.Recharges {
+		m.Recharges[i].Available = true
+	}
+}
+
+// RechargeResult is the outcome of one recharge roll.
+type RechargeResult struct {
+	Name      string
+	Roll      int
+	Recharged bool
+}
+
+func (r RechargeResult) String() string {
+	if r.Recharged {
+		return fmt.Sprintf("%s recharged (rolled %d)", r.Name, r.Roll)
+	}
+	return fmt.Sprintf("%s did not recharge (rolled %d)", r.Name, r.Roll)
+}
+
+// StartTurn runs the automatic start-of-turn bookkeeping for a combatant:
+// a d6 for each spent recharge ability. Call it with the combatant
+// returned by Advance.
+func StartTurn(c *Combatant, r *dice.Roller) []RechargeResult {
+	if c == nil || c.Monster == nil {
+		return nil
+	}
+	d6 := dice.MustParse("1d6")
+	var results []RechargeResult
+	for i := range c.Monster.Recharges {
+		rc := &c.Monster.Recharges[i]
+		if rc.Available {
+			continue
+		}
+		roll := r.Roll(d6).Total
+		rc.Available = roll >= rc.On
+		results = append(results, RechargeResult{Name: rc.Name, Roll: roll, Recharged: rc.Available})
+	}
+	return results
+}
+
+// AddMonster adds a combatant from a stat block, rolling its initiative
+// with its DEX modifier. name is the combatant's name; empty uses the
+// stat block's name, numbered by Add for each copy ("Goblin 2").
+// Legendary resistances and recharge actions are set up as resources.
+func (t *Tracker) AddMonster(m data.Monster, name string, r *dice.Roller) *Combatant {
+	if name == "" {
+		name = m.Name
+	}
+	c := &Combatant{
+		Name:       name,
+		Initiative: r.Roll(dice.D20(dice.Normal, m.Abilities.Modifier(rules.Dexterity))).Total,
+		HP:         m.HP,
+		MaxHP:      m.HP,
+		StatBlock:  m.Name,
+		XP:         m.XP(),
+	}
+	res := &MonsterResources{LegendaryResistances: m.LegendaryResistances}
+	for _, a := range m.Actions {
+		if a.Recharge > 0 {
+			res.Recharges = append(res.Recharges, Recharge{Name: a.Name, On: a.Recharge, Available: true})
+		}
+	}
+	if res.LegendaryResistances > 0 || len(res.Recharges) > 0 {
+		c.Monster = res
+	}
+	t.Add(c)
+	return c
+}
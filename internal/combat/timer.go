@@ -0,0 +1,73 @@
+// Package combat holds combat-tracker logic that is independent of the UI.
+package combat
+
+import "time"
+
+// TimerState is how far through its turn a timer is.
+type TimerState int
+
+const (
+	TimerOff TimerState = iota
+	TimerRunning
+	TimerWarning
+	TimerExpired
+)
+
+// TurnTimer is an optional per-turn countdown. A zero Limit disables it.
+// Callers pass the current time so the view's tick message drives it.
+type TurnTimer struct {
+	Limit time.Duration
+	// Warn is how much time remains when the warning state starts.
+	Warn time.Duration
+
+	started time.Time
+	running bool
+}
+
+// NewTurnTimer returns a timer of the given length in seconds that warns
+// for the last quarter of the turn, but never less than five seconds.
+func NewTurnTimer(seconds int) *TurnTimer {
+	limit := time.Duration(seconds) * time.Second
+	return &TurnTimer{
+		Limit: limit,
+		Warn:  max(limit/4, 5*time.Second),
+	}
+}
+
+// Enabled reports whether the timer has a limit.
+func (t *TurnTimer) Enabled() bool {
+	return t.Limit > 0
+}
+
+// Start restarts the countdown, typically when the turn advances.
+func (t *TurnTimer) Start(now time.Time) {
+	t.started = now
+	t.running = t.Enabled()
+}
+
+// Stop halts the countdown, e.g. when combat ends.
+func (t *TurnTimer) Stop() {
+	t.running = false
+}
+
+// Remaining returns the time left in the turn, never negative.
+func (t *TurnTimer) Remaining(now time.Time) time.Duration {
+	if !t.running {
+		return t.Limit
+	}
+	return max(t.Limit-now.Sub(t.started), 0)
+}
+
+// State returns the timer's display state at now.
+func (t *TurnTimer) State(now time.Time) TimerState {
+	if !t.running {
+		return TimerOff
+	}
+	switch left := t.Remaining(now); {
+	case left == 0:
+		return TimerExpired
+	case left <= t.Warn:
+		return TimerWarning
+	}
+	return TimerRunning
+}
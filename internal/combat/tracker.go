@@ -0,0 +1,215 @@
+package combat
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Condition is a condition on a combatant, with the spell or effect that
+// caused it so it can be removed when that ends.
+type Condition struct {
+	Name   string `json:"name"`
+	Source string `json:"source,omitempty"`
+}
+
+// Combatant is one creature in initiative order.
+type Combatant struct {
+	Name       string      `json:"name"`
+	Initiative int         `json:"initiative"`
+	HP         int         `json:"hp"`
+	MaxHP      int         `json:"max_hp"`
+	IsPlayer   bool        `json:"is_player,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Monster holds legendary resistances and recharge abilities; nil for
+	// player characters and simple monsters.
+	Monster *MonsterResources `json:"monster,omitempty"`
+	// StatBlock is the monster database entry the combatant was added
+	// from, and XP what defeating it is worth.
+	StatBlock string `json:"stat_block,omitempty"`
+	XP        int    `json:"xp,omitempty"`
+}
+
+// HasCondition reports whether the combatant has the named condition from
+// any source.
+func (c *Combatant) HasCondition(name string) bool {
+	return slices.ContainsFunc(c.Conditions, func(cond Condition) bool {
+		return strings.EqualFold(cond.Name, name)
+	})
+}
+
+// AddCondition applies a condition unless the same condition from the same
+// source is already present.
+func (c *Combatant) AddCondition(cond Condition) bool {
+	if slices.ContainsFunc(c.Conditions, func(existing Condition) bool {
+		return strings.EqualFold(existing.Name, cond.Name) && existing.Source == cond.Source
+	}) {
+		return false
+	}
+	c.Conditions = append(c.Conditions, cond)
+	return true
+}
+
+// RemoveCondition removes the named condition from every source.
+func (c *Combatant) RemoveCondition(name string) bool {
+	before := len(c.Conditions)
+	c.Conditions = slices.DeleteFunc(c.Conditions, func(cond Condition) bool {
+		return strings.EqualFold(cond.Name, name)
+	})
+	return len(c.Conditions) != before
+}
+
+// Tracker holds the initiative order and turn state of an encounter.
+type Tracker struct {
+	Combatants []*Combatant `json:"combatants"`
+	Round      int          `json:"round"`
+	// Turn is the index of the active combatant.
+	Turn int `json:"turn"`
+	// Ongoing are hazards and lingering effects that trigger on turns.
+	Ongoing []OngoingEffect `json:"ongoing,omitempty"`
+}
+
+// NewTracker returns an empty tracker at round 0 (combat not started).
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Add inserts a combatant and keeps the order sorted by initiative,
+// highest first. Ties keep insertion order. Combatants are looked up by
+// name, so a name already taken is numbered: the second "Goblin" becomes
+// "Goblin 2".
+func (t *Tracker) Add(c *Combatant) {
+	c.Name = t.uniqueName(c.Name)
+	var active *Combatant
+	if t.Round > 0 {
+		active = t.Active()
+	}
+	t.Combatants = append(t.Combatants, c)
+	sort.SliceStable(t.Combatants, func(i, j int) bool {
+		return t.Combatants[i].Initiative > t.Combatants[j].Initiative
+	})
+	if active != nil {
+		t.Turn = slices.Index(t.Combatants, active)
+	}
+}
+
+// uniqueName returns name, or name numbered from 2 up if a combatant
+// already has it.
+func (t *Tracker) uniqueName(name string) string {
+	unique := name
+	for n := 2; ; n++ {
+		if _, taken := t.Find(unique); !taken {
+			return unique
+		}
+		unique = fmt.Sprintf("%s %d", name, n)
+	}
+}
+
+// Find returns the combatant with the given name.
+func (t *Tracker) Find(name string) (*Combatant, bool) {
+	for _, c := range t.Combatants {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Start begins round 1 with the highest initiative.
+func (t *Tracker) Start() error {
+	if len(t.Combatants) == 0 {
+		return fmt.Errorf("no combatants")
+	}
+	t.Round, t.Turn = 1, 0
+	return nil
+}
+
+// Active returns the combatant whose turn it is, or nil before combat
+// starts.
+func (t *Tracker) Active() *Combatant {
+	if t.Round == 0 || len(t.Combatants) == 0 {
+		return nil
+	}
+	return t.Combatants[t.Turn]
+}
+
+// Next advances to the next combatant, starting a new round after the last.
+func (t *Tracker) Next() *Combatant {
+	if t.Round == 0 {
+		if t.Start() != nil {
+			return nil
+		}
+		return t.Active()
+	}
+	t.Turn++
+	if t.Turn >= len(t.Combatants) {
+		t.Turn = 0
+		t.Round++
+	}
+	return t.Active()
+}
+
+// ApplyCondition adds a condition from source to every named combatant, for
+// area effects like Hypnotic Pattern. It returns the names it was applied
+// to and an error listing any names that weren't found.
+func (t *Tracker) ApplyCondition(names []string, condition, source string) ([]string, error) {
+	var applied, missing []string
+	for _, name := range names {
+		c, ok := t.Find(name)
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		if c.AddCondition(Condition{Name: condition, Source: source}) {
+			applied = append(applied, c.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return applied, fmt.Errorf("no combatant named %s", strings.Join(missing, ", "))
+	}
+	return applied, nil
+}
+
+// RemoveCondition removes a condition from every named combatant.
+func (t *Tracker) RemoveCondition(names []string, condition string) []string {
+	var removed []string
+	for _, name := range names {
+		if c, ok := t.Find(name); ok && c.RemoveCondition(condition) {
+			removed = append(removed, c.Name)
+		}
+	}
+	return removed
+}
+
+// RemoveSource removes every condition caused by source from every
+// combatant, e.g. when the caster's concentration on Hypnotic Pattern
+// ends. It returns the affected combatant names.
+func (t *Tracker) RemoveSource(source string) []string {
+	var affected []string
+	for _, c := range t.Combatants {
+		before := len(c.Conditions)
+		c.Conditions = slices.DeleteFunc(c.Conditions, func(cond Condition) bool {
+			return cond.Source == source
+		})
+		if len(c.Conditions) != before {
+			affected = append(affected, c.Name)
+		}
+	}
+	return affected
+}
+
+// Sources lists the distinct condition sources active in the encounter,
+// for the bulk-removal picker.
+func (t *Tracker) Sources() []string {
+	var sources []string
+	for _, c := range t.Combatants {
+		for _, cond := range c.Conditions {
+			if cond.Source != "" && !slices.Contains(sources, cond.Source) {
+				sources = append(sources, cond.Source)
+			}
+		}
+	}
+	sort.Strings(sources)
+	return sources
+}
@@ -0,0 +1,75 @@
+package combat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddNumbersDuplicates(t *testing.T) {
+	tr := NewTracker()
+	for _, name := range []string{"Goblin", "goblin", "Goblin", "Goblin 2", "Aria"} {
+		tr.Add(&Combatant{Name: name})
+	}
+	var names []string
+	for _, c := range tr.Combatants {
+		names = append(names, c.Name)
+	}
+	want := []string{"Goblin", "goblin 2", "Goblin 3", "Goblin 2 2", "Aria"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+}
+
+func TestAddKeepsInitiativeOrder(t *testing.T) {
+	tr := NewTracker()
+	tr.Add(&Combatant{Name: "Slow", Initiative: 5})
+	tr.Add(&Combatant{Name: "Fast", Initiative: 18})
+	tr.Start()
+	tr.Next() // Slow's turn
+	tr.Add(&Combatant{Name: "Faster", Initiative: 20})
+	if got := tr.Active().Name; got != "Slow" {
+		t.Errorf("active after Add = %s, want Slow", got)
+	}
+	if got := tr.Combatants[0].Name; got != "Faster" {
+		t.Errorf("first = %s, want Faster", got)
+	}
+}
+
+func TestApplyConditionToCopies(t *testing.T) {
+	tr := NewTracker()
+	for range 3 {
+		tr.Add(&Combatant{Name: "Goblin"})
+	}
+	applied, err := tr.ApplyCondition([]string{"Goblin 2", "Goblin 3", "Ogre"}, "Charmed", "Hypnotic Pattern")
+	if err == nil {
+		t.Error("missing Ogre wasn't reported")
+	}
+	if want := []string{"Goblin 2", "Goblin 3"}; !reflect.DeepEqual(applied, want) {
+		t.Errorf("applied to %q, want %q", applied, want)
+	}
+	for i, want := range []bool{false, true, true} {
+		if got := tr.Combatants[i].HasCondition("charmed"); got != want {
+			t.Errorf("%s charmed = %v, want %v", tr.Combatants[i].Name, got, want)
+		}
+	}
+	if got := tr.RemoveSource("Hypnotic Pattern"); len(got) != 2 {
+		t.Errorf("RemoveSource affected %q", got)
+	}
+}
+
+func TestOngoingTargetsCopies(t *testing.T) {
+	tr := NewTracker()
+	tr.Add(&Combatant{Name: "Goblin", Initiative: 10})
+	tr.Add(&Combatant{Name: "Goblin", Initiative: 5})
+	if err := tr.AddOngoing(OngoingEffect{Name: "Burning", Targets: []string{"Goblin 2"}, Timing: StartOfTurn, Damage: "1d6", Automatic: true}); err != nil {
+		t.Fatal(err)
+	}
+	_, ticks := tr.Advance()
+	if len(ticks) != 0 {
+		t.Errorf("Goblin's turn ticked %d effects", len(ticks))
+	}
+	_, ticks = tr.Advance()
+	if len(ticks) != 1 || ticks[0].Target.Name != "Goblin 2" {
+		t.Errorf("Goblin 2's turn ticked %v", ticks)
+	}
+}
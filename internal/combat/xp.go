@@ -0,0 +1,85 @@
+package combat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Defeated returns the monsters reduced to 0 HP.
+func (t *Tracker) Defeated() []*Combatant {
+	var out []*Combatant
+	for _, c := range t.Combatants {
+		if !c.IsPlayer && c.HP <= 0 {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// XPAward is the experience earned from an encounter, split evenly among
+// the player characters who took part.
+type XPAward struct {
+	Monsters []string
+	Total    int
+	Players  []string
+	// Share is each player's cut, rounded down.
+	Share int
+}
+
+// EncounterXP totals the XP of the defeated monsters and splits it among
+// the player characters in the tracker.
+func (t *Tracker) EncounterXP() XPAward {
+	var a XPAward
+	for _, c := range t.Defeated() {
+		a.Monsters = append(a.Monsters, c.Name)
+		a.Total += c.XP
+	}
+	for _, c := range t.Combatants {
+		if c.IsPlayer {
+			a.Players = append(a.Players, c.Name)
+		}
+	}
+	if len(a.Players) > 0 {
+		a.Share = a.Total / len(a.Players)
+	}
+	return a
+}
+
+// Without drops players from the split, for characters who fled or joined
+// late, and recomputes each share.
+func (a XPAward) Without(names ...string) XPAward {
+	var players []string
+	for _, p := range a.Players {
+		drop := false
+		for _, n := range names {
+			drop = drop || strings.EqualFold(p, n)
+		}
+		if !drop {
+			players = append(players, p)
+		}
+	}
+	a.Players, a.Share = players, 0
+	if len(players) > 0 {
+		a.Share = a.Total / len(players)
+	}
+	return a
+}
+
+// Apply adds each player's share to their XP total, keyed by name.
+func (a XPAward) Apply(totals map[string]int) {
+	for _, p := range a.Players {
+		totals[p] += a.Share
+	}
+}
+
+// Summary describes the award for the confirmation screen.
+func (a XPAward) Summary() string {
+	if len(a.Monsters) == 0 {
+		return "No monsters were defeated."
+	}
+	s := fmt.Sprintf("Defeated %s: %d XP", strings.Join(a.Monsters, ", "), a.Total)
+	if len(a.Players) == 0 {
+		return s + " (no player characters to award)"
+	}
+	return s + fmt.Sprintf(", %d XP each to %s", a.Share, strings.Join(a.Players, ", "))
+}
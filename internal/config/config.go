@@ -0,0 +1,235 @@
+// Package config loads and saves user preferences from
+// ~/.config/sheet/config.json: global settings plus per-character
+// overrides.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sheet/internal/inventory"
+	"sheet/internal/rest"
+	"sheet/internal/rules"
+	"sheet/internal/storage"
+	"sheet/internal/templates"
+	"sheet/internal/ui/glyphs"
+)
+
+// FileName is the name of the config file inside the config directory.
+const FileName = "config.json"
+
+// Settings are the user's preferences.
+type Settings struct {
+	// DataDir is the shipped data directory; HomebrewDirs are layered on
+	// top of it in order.
+	DataDir      string   `json:"data_dir,omitempty"`
+	HomebrewDirs []string `json:"homebrew_dirs,omitempty"`
+
+	Theme           string `json:"theme"`
+	VimKeys         bool   `json:"vim_keys"`
+	AutosaveSeconds int    `json:"autosave_seconds"`
+	DiceAnimation   bool   `json:"dice_animation"`
+	// ASCII draws with ASCII symbols instead of Unicode ones; unset
+	// detects what the terminal can show. See Glyphs.
+	ASCII *bool `json:"ascii,omitempty"`
+	// BackupRetention is how many rolling backups are kept per character.
+	BackupRetention int `json:"backup_retention"`
+	// HPRounding is how fixed hit points per level are rounded.
+	HPRounding rules.HPRounding `json:"hp_rounding,omitempty"`
+	// Crit is how critical hit damage is rolled.
+	Crit rules.CritVariant `json:"crit,omitempty"`
+	// Edition is the rules edition for new characters: where their
+	// ability increases come from and which backgrounds are loaded.
+	Edition rules.Edition `json:"edition,omitempty"`
+	// AbilityScores are the point buy, array and rolling rules for new
+	// characters.
+	AbilityScores rules.AbilityRules `json:"ability_scores"`
+	// VariantEncumbrance slows characters carrying over 5 × STR pounds,
+	// and more over 10 × STR.
+	VariantEncumbrance bool `json:"variant_encumbrance,omitempty"`
+	// Gestalt has new characters advance two classes at once; see
+	// rules.Gestalt.
+	Gestalt bool `json:"gestalt,omitempty"`
+	// EpicLevels lets characters level past 20, using the levels in the
+	// data directories' epic_levels.json.
+	EpicLevels bool `json:"epic_levels,omitempty"`
+	// PartialRest is the table's house rule for interrupted short rests;
+	// empty means all or nothing.
+	PartialRest rest.PartialRest `json:"partial_rest,omitempty"`
+}
+
+// AutosaveInterval returns the autosave interval; zero disables autosave.
+func (s Settings) AutosaveInterval() time.Duration {
+	return time.Duration(max(s.AutosaveSeconds, 0)) * time.Second
+}
+
+// Glyphs returns the symbol set the views draw with.
+func (s Settings) Glyphs() glyphs.Set {
+	if s.ASCII != nil {
+		return glyphs.For(*s.ASCII)
+	}
+	return glyphs.For(glyphs.PreferASCII())
+}
+
+// DataDirs returns the data directories in increasing precedence, for
+// data.NewOverlay.
+func (s Settings) DataDirs() []string {
+	return append([]string{s.DataDir}, s.HomebrewDirs...)
+}
+
+// Overrides are per-character settings. Nil fields fall back to the global
+// settings.
+type Overrides struct {
+	Theme           *string `json:"theme,omitempty"`
+	VimKeys         *bool   `json:"vim_keys,omitempty"`
+	AutosaveSeconds *int    `json:"autosave_seconds,omitempty"`
+	DiceAnimation   *bool   `json:"dice_animation,omitempty"`
+}
+
+// Empty reports whether no setting is overridden.
+func (o Overrides) Empty() bool {
+	return o == Overrides{}
+}
+
+// Config is the whole config file.
+type Config struct {
+	Settings
+	// Characters maps character file names (without directory) to their
+	// overrides.
+	Characters map[string]Overrides `json:"characters,omitempty"`
+}
+
+// Default returns the settings used when there is no config file.
+func Default() Config {
+	return Config{
+		Settings: Settings{
+			DataDir:         "data",
+			Theme:           "dark",
+			AutosaveSeconds: 30,
+			DiceAnimation:   true,
+			BackupRetention: storage.DefaultBackupRetention,
+			HPRounding:      rules.RoundUp,
+			Crit:            rules.CritDoubleDice,
+			Edition:         rules.Edition2024,
+			AbilityScores:   rules.DefaultAbilityRules(),
+		},
+	}
+}
+
+// Dir returns the config directory, ~/.config/sheet on Linux.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+	return filepath.Join(dir, "sheet"), nil
+}
+
+// DefaultPath returns the path of the config file.
+func DefaultPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// ItemLibraryPath returns the path of the personal item library.
+func ItemLibraryPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, inventory.LibraryFile), nil
+}
+
+// TemplatesDir returns the directory character templates are saved in.
+func TemplatesDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, templates.DirName), nil
+}
+
+// Load reads the config file. A missing file yields the defaults, and
+// settings missing from the file keep their defaults.
+func Load(path string) (Config, error) {
+	c := Default()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read config: %w", err)
+	}
+	// Unmarshalling into the default costs would merge them with the
+	// file's rather than replace them.
+	c.AbilityScores.Costs = nil
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if c.AbilityScores.Costs == nil {
+		c.AbilityScores.Costs = rules.DefaultAbilityRules().Costs
+	}
+	if err := c.AbilityScores.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid ability score rules: %w", err)
+	}
+	return c, nil
+}
+
+// Save writes the config file, creating its directory.
+func (c Config) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// For returns the settings in effect for a character, with its overrides
+// applied. An empty name gives the global settings.
+func (c Config) For(character string) Settings {
+	s := c.Settings
+	o, ok := c.Characters[character]
+	if !ok {
+		return s
+	}
+	if o.Theme != nil {
+		s.Theme = *o.Theme
+	}
+	if o.VimKeys != nil {
+		s.VimKeys = *o.VimKeys
+	}
+	if o.AutosaveSeconds != nil {
+		s.AutosaveSeconds = *o.AutosaveSeconds
+	}
+	if o.DiceAnimation != nil {
+		s.DiceAnimation = *o.DiceAnimation
+	}
+	return s
+}
+
+// SetOverrides replaces a character's overrides, removing the entry when
+// nothing is overridden.
+func (c *Config) SetOverrides(character string, o Overrides) {
+	if o.Empty() {
+		delete(c.Characters, character)
+		return
+	}
+	if c.Characters == nil {
+		c.Characters = make(map[string]Overrides)
+	}
+	c.Characters[character] = o
+}
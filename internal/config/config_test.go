@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"sheet/internal/ui/glyphs"
+)
+
+func TestGlyphs(t *testing.T) {
+	on, off := true, false
+	tests := []struct {
+		name  string
+		ascii *bool
+		env   string
+		want  glyphs.Set
+	}{
+		{"on", &on, "", glyphs.ASCII},
+		{"off wins over the terminal", &off, "1", glyphs.Unicode},
+		{"unset follows the terminal", nil, "1", glyphs.ASCII},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SHEET_ASCII", tt.env)
+			if got := (Settings{ASCII: tt.ascii}).Glyphs(); got != tt.want {
+				t.Errorf("Glyphs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
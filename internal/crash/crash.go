@@ -0,0 +1,126 @@
+// Package crash turns panics into crash reports. When the program panics it
+// saves the active character to a recovery file, writes the stack trace and
+// recent events to disk, and restores the terminal before returning control.
+package crash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"sheet/internal/events"
+)
+
+// Handler describes what to do when a panic is caught. All fields other than
+// Dir are optional.
+type Handler struct {
+	// Dir is where crash reports and recovery files are written.
+	Dir string
+
+	// Save returns the serialized active character, if any. It is called
+	// after a panic, so it should not depend on UI state.
+	Save func() ([]byte, error)
+
+	// Recorder supplies the recent event log included in the report.
+	Recorder *events.Recorder
+
+	// RestoreTerminal puts the terminal back into its normal mode.
+	RestoreTerminal func()
+}
+
+// Error is returned by Run when fn panicked.
+type Error struct {
+	Value        any
+	ReportPath   string
+	RecoveryPath string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("sheet crashed: %v (report written to %s)", e.Value, e.ReportPath)
+	if e.RecoveryPath != "" {
+		msg += fmt.Sprintf(", character saved to %s", e.RecoveryPath)
+	}
+	return msg
+}
+
+// Run calls fn, converting a panic into an *Error after writing the crash
+// report.
+func (h *Handler) Run(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = h.handle(r, debug.Stack())
+		}
+	}()
+	return fn()
+}
+
+func (h *Handler) handle(value any, stack []byte) error {
+	if h.RestoreTerminal != nil {
+		h.RestoreTerminal()
+	}
+
+	crashErr := &Error{Value: value}
+	if err := os.MkdirAll(h.Dir, 0o755); err != nil {
+		return fmt.Errorf("sheet crashed: %v (failed to create crash directory: %v)", value, err)
+	}
+	stamp := time.Now().Format("20060102-150405")
+
+	var saveErr error
+	if h.Save != nil {
+		crashErr.RecoveryPath, saveErr = h.writeRecovery(stamp)
+	}
+
+	crashErr.ReportPath = filepath.Join(h.Dir, "crash-"+stamp+".log")
+	report := h.report(value, stack, saveErr)
+	if err := os.WriteFile(crashErr.ReportPath, []byte(report), 0o644); err != nil {
+		crashErr.ReportPath = "(unwritten: " + err.Error() + ")"
+	}
+
+	return crashErr
+}
+
+// writeRecovery saves the character, guarding against Save itself
+// panicking on corrupted state.
+func (h *Handler) writeRecovery(stamp string) (path string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			path, err = "", fmt.Errorf("save panicked: %v", r)
+		}
+	}()
+
+	data, err := h.Save()
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 {
+		return "", nil
+	}
+	path = filepath.Join(h.Dir, "recovery-"+stamp+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (h *Handler) report(value any, stack []byte, saveErr error) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "panic: %v\n", value)
+	fmt.Fprintf(&b, "time: %s\n", time.Now().Format(time.RFC3339))
+	if saveErr != nil {
+		fmt.Fprintf(&b, "recovery save failed: %v\n", saveErr)
+	}
+
+	b.WriteString("\nstack:\n")
+	b.Write(stack)
+
+	if h.Recorder != nil {
+		b.WriteString("\nrecent events:\n")
+		for _, r := range h.Recorder.Records() {
+			fmt.Fprintf(&b, "%s %s %+v\n", r.Time.Format(time.RFC3339), r.Event.Type(), r.Event)
+		}
+	}
+	return b.String()
+}
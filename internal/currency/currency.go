@@ -0,0 +1,70 @@
+// Package currency handles coins: totals, splitting loot between party
+// members and depositing shares.
+package currency
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Copper value of each denomination.
+const (
+	CopperPerSilver   = 10
+	CopperPerElectrum = 50
+	CopperPerGold     = 100
+	CopperPerPlatinum = 1000
+)
+
+// Coins is a purse of mixed denominations.
+type Coins struct {
+	CP int `json:"cp"`
+	SP int `json:"sp"`
+	EP int `json:"ep"`
+	GP int `json:"gp"`
+	PP int `json:"pp"`
+}
+
+// Copper returns the total value of the purse in copper pieces.
+func (c Coins) Copper() int {
+	return c.CP + c.SP*CopperPerSilver + c.EP*CopperPerElectrum +
+		c.GP*CopperPerGold + c.PP*CopperPerPlatinum
+}
+
+// Add returns the coin-by-coin sum of c and o.
+func (c Coins) Add(o Coins) Coins {
+	return Coins{
+		CP: c.CP + o.CP,
+		SP: c.SP + o.SP,
+		EP: c.EP + o.EP,
+		GP: c.GP + o.GP,
+		PP: c.PP + o.PP,
+	}
+}
+
+// FromCopper expresses an amount of copper in gold, silver and copper, the
+// denominations most tables actually carry.
+func FromCopper(cp int) Coins {
+	return Coins{
+		GP: cp / CopperPerGold,
+		SP: cp % CopperPerGold / CopperPerSilver,
+		CP: cp % CopperPerSilver,
+	}
+}
+
+// String lists the non-zero denominations from highest to lowest, e.g.
+// "12 gp, 5 sp".
+func (c Coins) String() string {
+	var parts []string
+	for _, d := range []struct {
+		n    int
+		name string
+	}{{c.PP, "pp"}, {c.GP, "gp"}, {c.EP, "ep"}, {c.SP, "sp"}, {c.CP, "cp"}} {
+		if d.n != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", d.n, d.name))
+		}
+	}
+	if len(parts) == 0 {
+		return "0 cp"
+	}
+	return strings.Join(parts, ", ")
+}
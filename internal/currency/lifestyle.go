@@ -0,0 +1,73 @@
+package currency
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lifestyle is a standard of living with a daily cost.
+type Lifestyle string
+
+const (
+	Wretched     Lifestyle = "wretched"
+	Squalid      Lifestyle = "squalid"
+	Poor         Lifestyle = "poor"
+	Modest       Lifestyle = "modest"
+	Comfortable  Lifestyle = "comfortable"
+	Wealthy      Lifestyle = "wealthy"
+	Aristocratic Lifestyle = "aristocratic"
+)
+
+// Lifestyles lists the tiers from cheapest to most expensive.
+var Lifestyles = []Lifestyle{Wretched, Squalid, Poor, Modest, Comfortable, Wealthy, Aristocratic}
+
+// dailyCost is in copper; Aristocratic uses its minimum.
+var dailyCost = map[Lifestyle]int{
+	Wretched:     0,
+	Squalid:      1 * CopperPerSilver,
+	Poor:         2 * CopperPerSilver,
+	Modest:       1 * CopperPerGold,
+	Comfortable:  2 * CopperPerGold,
+	Wealthy:      4 * CopperPerGold,
+	Aristocratic: 10 * CopperPerGold,
+}
+
+// ParseLifestyle matches a tier name in any case.
+func ParseLifestyle(s string) (Lifestyle, bool) {
+	l := Lifestyle(strings.ToLower(strings.TrimSpace(s)))
+	_, ok := dailyCost[l]
+	return l, ok
+}
+
+// Cost returns what the lifestyle costs for the given number of days.
+func (l Lifestyle) Cost(days int) Coins {
+	return FromCopper(dailyCost[l] * max(days, 0))
+}
+
+// PayLifestyle deducts the cost of living for days that have passed in
+// game. If the wallet can't cover it nothing is deducted and the error
+// says how far short the character is, so the view can warn them to drop
+// to a cheaper lifestyle.
+func (w *Wallet) PayLifestyle(l Lifestyle, days int) error {
+	if _, ok := dailyCost[l]; !ok {
+		return fmt.Errorf("unknown lifestyle %q", l)
+	}
+	cost := l.Cost(days)
+	if cost.Copper() == 0 {
+		return nil
+	}
+	if short := cost.Copper() - w.Coins.Copper(); short > 0 {
+		return fmt.Errorf("cannot afford %d days of %s lifestyle (%s): short by %s", days, l, cost, FromCopper(short))
+	}
+	return w.Spend(cost, fmt.Sprintf("%s lifestyle, %d days", l, days))
+}
+
+// AffordableDays returns how many days of the lifestyle the wallet covers,
+// or -1 for free lifestyles.
+func (w *Wallet) AffordableDays(l Lifestyle) int {
+	per := dailyCost[l]
+	if per == 0 {
+		return -1
+	}
+	return w.Coins.Copper() / per
+}
@@ -0,0 +1,59 @@
+package currency
+
+import "fmt"
+
+// LootItem is an item in a haul with its market value.
+type LootItem struct {
+	Name  string `json:"name"`
+	Value Coins  `json:"value"`
+}
+
+// Haul is everything found after an encounter or dungeon.
+type Haul struct {
+	Coins Coins      `json:"coins"`
+	Items []LootItem `json:"items,omitempty"`
+}
+
+// Value returns the total worth of the haul in copper, counting items at
+// their listed value.
+func (h Haul) Value() int {
+	total := h.Coins.Copper()
+	for _, it := range h.Items {
+		total += it.Value.Copper()
+	}
+	return total
+}
+
+// Split is the result of dividing a haul's coinage.
+type Split struct {
+	// Share is what each member receives.
+	Share Coins
+	// Remainder is the copper that doesn't divide evenly, for the party
+	// fund or whoever the table decides.
+	Remainder Coins
+}
+
+// SplitCoins divides the haul's coins evenly between members. When
+// includeItems is true the items are treated as sold at their listed value
+// and that money is split too. Shares are paid out in gold, silver and
+// copper.
+func SplitCoins(h Haul, members int, includeItems bool) (Split, error) {
+	if members < 1 {
+		return Split{}, fmt.Errorf("need at least one member to split between")
+	}
+	total := h.Coins.Copper()
+	if includeItems {
+		total = h.Value()
+	}
+	return Split{
+		Share:     FromCopper(total / members),
+		Remainder: FromCopper(total % members),
+	}, nil
+}
+
+// Deposit adds a split's share to each purse in place.
+func (s Split) Deposit(purses ...*Coins) {
+	for _, p := range purses {
+		*p = p.Add(s.Share)
+	}
+}
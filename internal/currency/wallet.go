@@ -0,0 +1,209 @@
+package currency
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Denomination is a coin type.
+type Denomination string
+
+const (
+	Copper   Denomination = "cp"
+	Silver   Denomination = "sp"
+	Electrum Denomination = "ep"
+	Gold     Denomination = "gp"
+	Platinum Denomination = "pp"
+)
+
+// Denominations lists the coin types from lowest to highest value.
+var Denominations = []Denomination{Copper, Silver, Electrum, Gold, Platinum}
+
+// ParseDenomination accepts "gp", "gold" and so on, in any case.
+func ParseDenomination(s string) (Denomination, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "cp", "copper":
+		return Copper, true
+	case "sp", "silver":
+		return Silver, true
+	case "ep", "electrum":
+		return Electrum, true
+	case "gp", "gold":
+		return Gold, true
+	case "pp", "platinum":
+		return Platinum, true
+	}
+	return "", false
+}
+
+// Value returns the copper value of one coin.
+func (d Denomination) Value() int {
+	switch d {
+	case Silver:
+		return CopperPerSilver
+	case Electrum:
+		return CopperPerElectrum
+	case Gold:
+		return CopperPerGold
+	case Platinum:
+		return CopperPerPlatinum
+	}
+	return 1
+}
+
+// count returns a pointer to the field of c holding d.
+func (c *Coins) count(d Denomination) *int {
+	switch d {
+	case Silver:
+		return &c.SP
+	case Electrum:
+		return &c.EP
+	case Gold:
+		return &c.GP
+	case Platinum:
+		return &c.PP
+	}
+	return &c.CP
+}
+
+// Of returns how many coins of d are in the purse.
+func (c Coins) Of(d Denomination) int {
+	return *c.count(d)
+}
+
+// Amount returns a purse holding n coins of d.
+func Amount(n int, d Denomination) Coins {
+	var c Coins
+	*c.count(d) = n
+	return c
+}
+
+// Pay removes cost from c, breaking larger coins and taking change in gold,
+// silver and copper as a shopkeeper would. Coins are spent lowest
+// denomination first so the purse keeps its valuable coins.
+func (c Coins) Pay(cost Coins) (Coins, error) {
+	remaining := cost.Copper()
+	if remaining < 0 {
+		return c, fmt.Errorf("cost cannot be negative")
+	}
+	if c.Copper() < remaining {
+		return c, fmt.Errorf("cannot afford %s with %s", cost, c)
+	}
+
+	for _, d := range Denominations {
+		n := c.count(d)
+		use := min(*n, remaining/d.Value())
+		*n -= use
+		remaining -= use * d.Value()
+	}
+	if remaining == 0 {
+		return c, nil
+	}
+	// Every coin left is worth more than what is still owed, so breaking
+	// the smallest one covers it.
+	for _, d := range Denominations {
+		if n := c.count(d); *n > 0 {
+			*n--
+			return c.Add(FromCopper(d.Value() - remaining)), nil
+		}
+	}
+	return c, fmt.Errorf("cannot afford %s", cost)
+}
+
+// Convert exchanges n coins of one denomination for the equivalent in
+// another. Converting down always works; converting up requires the value
+// to divide evenly.
+func (c Coins) Convert(n int, from, to Denomination) (Coins, error) {
+	if n < 1 {
+		return c, fmt.Errorf("amount must be positive")
+	}
+	if c.Of(from) < n {
+		return c, fmt.Errorf("only %d %s available", c.Of(from), from)
+	}
+	value := n * from.Value()
+	if value%to.Value() != 0 {
+		return c, fmt.Errorf("%d %s does not convert evenly to %s", n, from, to)
+	}
+	*c.count(from) -= n
+	*c.count(to) += value / to.Value()
+	return c, nil
+}
+
+// TransactionKind classifies a wallet transaction.
+type TransactionKind string
+
+const (
+	Deposit  TransactionKind = "deposit"
+	Expense  TransactionKind = "expense"
+	Exchange TransactionKind = "exchange"
+)
+
+// Transaction is one entry in a wallet's log.
+type Transaction struct {
+	Time    time.Time       `json:"time"`
+	Kind    TransactionKind `json:"kind"`
+	Amount  Coins           `json:"amount"`
+	Note    string          `json:"note,omitempty"`
+	Balance Coins           `json:"balance"`
+}
+
+func (t Transaction) String() string {
+	sign := "+"
+	if t.Kind == Expense {
+		sign = "-"
+	}
+	s := fmt.Sprintf("%s %s%s", t.Time.Format("2006-01-02 15:04"), sign, t.Amount)
+	if t.Kind == Exchange {
+		s = fmt.Sprintf("%s exchanged %s", t.Time.Format("2006-01-02 15:04"), t.Amount)
+	}
+	if t.Note != "" {
+		s += "  " + t.Note
+	}
+	return s
+}
+
+// Wallet is a purse with a log of how it changed, so players can see where
+// their money went during a session.
+type Wallet struct {
+	Coins Coins         `json:"coins"`
+	Log   []Transaction `json:"log,omitempty"`
+}
+
+func (w *Wallet) record(kind TransactionKind, amount Coins, note string) {
+	w.Log = append(w.Log, Transaction{
+		Time:    time.Now(),
+		Kind:    kind,
+		Amount:  amount,
+		Note:    note,
+		Balance: w.Coins,
+	})
+}
+
+// Deposit adds coins to the wallet.
+func (w *Wallet) Deposit(amount Coins, note string) {
+	w.Coins = w.Coins.Add(amount)
+	w.record(Deposit, amount, note)
+}
+
+// Spend pays cost from the wallet, making change as needed.
+func (w *Wallet) Spend(cost Coins, note string) error {
+	coins, err := w.Coins.Pay(cost)
+	if err != nil {
+		return err
+	}
+	w.Coins = coins
+	w.record(Expense, cost, note)
+	return nil
+}
+
+// Exchange converts n coins of one denomination into another.
+func (w *Wallet) Exchange(n int, from, to Denomination) error {
+	coins, err := w.Coins.Convert(n, from, to)
+	if err != nil {
+		return err
+	}
+	w.Coins = coins
+	w.record(Exchange, Amount(n, from), fmt.Sprintf("to %s", to))
+	return nil
+}
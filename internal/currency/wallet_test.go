@@ -0,0 +1,85 @@
+package currency
+
+import "testing"
+
+func TestPay(t *testing.T) {
+	tests := []struct {
+		name        string
+		purse, cost Coins
+		want        Coins
+	}{
+		{"exact coins", Coins{GP: 5}, Coins{GP: 3}, Coins{GP: 2}},
+		{"lowest first", Coins{CP: 50, SP: 5, GP: 1}, Coins{CP: 80}, Coins{SP: 2, GP: 1}},
+		{"change from gold", Coins{GP: 1}, Coins{CP: 7}, Coins{SP: 9, CP: 3}},
+		{"change from platinum", Coins{PP: 1}, Coins{GP: 2, SP: 5}, Coins{GP: 7, SP: 5}},
+		{"electrum", Coins{EP: 3}, Coins{SP: 12}, Coins{SP: 3}},
+		{"partly covered", Coins{CP: 5, GP: 1}, Coins{CP: 7}, Coins{SP: 9, CP: 8}},
+		{"everything", Coins{SP: 3, GP: 2}, Coins{CP: 230}, Coins{}},
+		{"nothing", Coins{GP: 2}, Coins{}, Coins{GP: 2}},
+	}
+	for _, tt := range tests {
+		got, err := tt.purse.Pay(tt.cost)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: %v pay %v = %v, want %v", tt.name, tt.purse, tt.cost, got, tt.want)
+		}
+		if got.Copper() != tt.purse.Copper()-tt.cost.Copper() {
+			t.Errorf("%s: %v left, worth %d cp; want %d cp", tt.name, got, got.Copper(), tt.purse.Copper()-tt.cost.Copper())
+		}
+	}
+}
+
+func TestPayErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		purse, cost Coins
+	}{
+		{"too expensive", Coins{GP: 1}, Coins{GP: 1, CP: 1}},
+		{"negative", Coins{GP: 1}, Coins{CP: -1}},
+	}
+	for _, tt := range tests {
+		got, err := tt.purse.Pay(tt.cost)
+		if err == nil {
+			t.Errorf("%s: %v pay %v = %v, want an error", tt.name, tt.purse, tt.cost, got)
+		}
+		if got != tt.purse {
+			t.Errorf("%s: purse changed to %v on error", tt.name, got)
+		}
+	}
+}
+
+func TestWalletSpend(t *testing.T) {
+	w := Wallet{}
+	w.Deposit(Coins{GP: 10}, "loot")
+	if err := w.Spend(Coins{GP: 3, SP: 5}, "rope"); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Coins{GP: 6, SP: 5}); w.Coins != want {
+		t.Errorf("coins = %v, want %v", w.Coins, want)
+	}
+	if err := w.Spend(Coins{PP: 1}, "too much"); err == nil {
+		t.Error("overspending succeeded")
+	}
+	if len(w.Log) != 2 {
+		t.Fatalf("log has %d entries, want 2", len(w.Log))
+	}
+	if got := w.Log[1]; got.Kind != Expense || got.Balance != w.Coins || got.Note != "rope" {
+		t.Errorf("log entry = %+v", got)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	c, err := Coins{GP: 3}.Convert(2, Gold, Silver)
+	if want := (Coins{GP: 1, SP: 20}); err != nil || c != want {
+		t.Errorf("Convert down = %v, %v; want %v", c, err, want)
+	}
+	if _, err := (Coins{SP: 15}).Convert(15, Silver, Gold); err == nil {
+		t.Error("uneven conversion succeeded")
+	}
+	if _, err := (Coins{SP: 5}).Convert(10, Silver, Copper); err == nil {
+		t.Error("converting more coins than held succeeded")
+	}
+}
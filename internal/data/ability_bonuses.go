@@ -0,0 +1,70 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+
+	"sheet/internal/rules"
+)
+
+// AbilityBonuses returns the ability score increases a new character gets
+// under the edition. picks are the player's floating increases: the
+// race's AbilityChoice in 2014, or the background's +2/+1 or +1/+1/+1 in
+// 2024.
+func AbilityBonuses(e rules.Edition, race Race, subrace string, bg Background, picks rules.Scores) (rules.Scores, error) {
+	if e == rules.Edition2014 {
+		return raceBonuses(race, subrace, picks)
+	}
+	return backgroundBonuses(bg, picks)
+}
+
+func raceBonuses(race Race, subrace string, picks rules.Scores) (rules.Scores, error) {
+	out := make(rules.Scores)
+	for a, n := range race.AbilityBonuses {
+		out[a] += n
+	}
+	if subrace != "" {
+		sub, ok := race.FindSubrace(subrace)
+		if !ok {
+			return nil, fmt.Errorf("%s has no subrace %q", race.Name, subrace)
+		}
+		for a, n := range sub.AbilityBonuses {
+			out[a] += n
+		}
+	}
+	choice := race.AbilityChoice
+	if choice == nil {
+		if len(picks) > 0 {
+			return nil, fmt.Errorf("%s has no ability choices", race.Name)
+		}
+		return out, nil
+	}
+	if len(picks) != choice.Count {
+		return nil, fmt.Errorf("%s requires choosing %d abilities, got %d", race.Name, choice.Count, len(picks))
+	}
+	for a, n := range picks {
+		if n != 1 {
+			return nil, fmt.Errorf("%s increases each chosen ability by 1, not %d", race.Name, n)
+		}
+		if len(choice.From) > 0 && !slices.Contains(choice.From, a) {
+			return nil, fmt.Errorf("%s cannot increase %s", race.Name, a)
+		}
+		out[a]++
+	}
+	return out, nil
+}
+
+func backgroundBonuses(bg Background, picks rules.Scores) (rules.Scores, error) {
+	var amounts []int
+	for a, n := range picks {
+		if !slices.Contains(bg.Abilities, a) {
+			return nil, fmt.Errorf("%s cannot increase %s", bg.Name, a)
+		}
+		amounts = append(amounts, n)
+	}
+	slices.Sort(amounts)
+	if !slices.Equal(amounts, []int{1, 2}) && !slices.Equal(amounts, []int{1, 1, 1}) {
+		return nil, fmt.Errorf("%s increases one ability by 2 and another by 1, or three by 1", bg.Name)
+	}
+	return picks, nil
+}
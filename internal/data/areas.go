@@ -0,0 +1,70 @@
+package data
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AreaShape is the shape of a spell's area of effect.
+type AreaShape string
+
+const (
+	Cone     AreaShape = "cone"
+	Cube     AreaShape = "cube"
+	Cylinder AreaShape = "cylinder"
+	Line     AreaShape = "line"
+	Sphere   AreaShape = "sphere"
+)
+
+// SpellArea is a spell's area of effect. Size is the length of a cone or
+// line, the side of a cube, or the radius of a sphere or cylinder.
+type SpellArea struct {
+	Shape AreaShape `json:"shape"`
+	Size  int       `json:"size"`
+	// Width is a line's width; zero means 5 feet.
+	Width int `json:"width,omitempty"`
+}
+
+func (a SpellArea) String() string {
+	switch a.Shape {
+	case Sphere, Cylinder:
+		return fmt.Sprintf("%d-ft-radius %s", a.Size, a.Shape)
+	case Line:
+		return fmt.Sprintf("%d x %d ft line", a.Size, cmp.Or(a.Width, 5))
+	}
+	return fmt.Sprintf("%d-ft %s", a.Size, a.Shape)
+}
+
+// Targets is the DMG's rule of thumb for how many creatures an area
+// catches when nobody is placed on a grid.
+func (a SpellArea) Targets() int {
+	div := 5
+	switch a.Shape {
+	case Cone:
+		div = 10
+	case Line:
+		div = 30
+	}
+	return max((a.Size+div-1)/div, 1)
+}
+
+// areaInRange matches the area in a range like "Self (15-foot cone)".
+var areaInRange = regexp.MustCompile(`(?i)(\d+)-foot(?:-radius)? (cone|cube|cylinder|line|sphere|radius)`)
+
+// ParseArea reads an area of effect from a spell's range text. A bare
+// radius, as in "Self (15-foot radius)", is treated as a sphere.
+func ParseArea(rng string) (SpellArea, bool) {
+	m := areaInRange.FindStringSubmatch(rng)
+	if m == nil {
+		return SpellArea{}, false
+	}
+	size, _ := strconv.Atoi(m[1])
+	shape := AreaShape(strings.ToLower(m[2]))
+	if shape == "radius" {
+		shape = Sphere
+	}
+	return SpellArea{Shape: shape, Size: size}, true
+}
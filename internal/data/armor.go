@@ -0,0 +1,65 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/inventory"
+)
+
+// ArmorFile is the data file armor and shields are loaded from.
+const ArmorFile = "armor.json"
+
+// Armor is an entry in the armor table.
+type Armor struct {
+	Name string `json:"name"`
+	// Category is "light", "medium", "heavy" or "shield".
+	Category   string `json:"category"`
+	ArmorClass int    `json:"armor_class"`
+	// Strength is the table's strength column, e.g. "Str 13".
+	Strength            string  `json:"strength,omitempty"`
+	StealthDisadvantage bool    `json:"stealth_disadvantage,omitempty"`
+	Weight              float64 `json:"weight"`
+	Cost                string  `json:"cost"`
+}
+
+// LoadArmor reads every armor entry from the data directories.
+func (o *Overlay) LoadArmor() ([]Armor, error) {
+	var armor []Armor
+	if err := o.Load(ArmorFile, &armor); err != nil {
+		return nil, err
+	}
+	return armor, nil
+}
+
+// FindArmor returns the named armor.
+func FindArmor(armor []Armor, name string) (Armor, bool) {
+	for _, a := range armor {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+	return Armor{}, false
+}
+
+// Item converts the entry to an inventory item.
+func (a Armor) Item() (inventory.Item, error) {
+	str, err := inventory.ParseStrengthRequirement(a.Strength)
+	if err != nil {
+		return inventory.Item{}, fmt.Errorf("%s: %w", a.Name, err)
+	}
+	it := inventory.Item{
+		Name:                a.Name,
+		Type:                inventory.Armor,
+		Quantity:            1,
+		Weight:              a.Weight,
+		ArmorClass:          a.ArmorClass,
+		ArmorCategory:       inventory.ArmorCategory(a.Category),
+		StrengthRequirement: str,
+	}
+	if a.Category == "shield" {
+		it.Type = inventory.Shield
+		it.ArmorCategory = ""
+	}
+	return it, nil
+}
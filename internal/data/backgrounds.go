@@ -0,0 +1,47 @@
+package data
+
+import (
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// Backgrounds are loaded from a file per edition, since the 2014 and 2024
+// versions of a background share its name.
+const (
+	BackgroundsFile     = "backgrounds.json"
+	Backgrounds2014File = "backgrounds_2014.json"
+)
+
+// Background is a background definition.
+type Background struct {
+	Name string `json:"name"`
+	// Abilities are the three abilities a 2024 background's increases
+	// are assigned among.
+	Abilities []rules.Ability `json:"abilities,omitempty"`
+	Skills    []string        `json:"skills,omitempty"`
+}
+
+// LoadBackgrounds reads the edition's backgrounds from the data
+// directories.
+func (o *Overlay) LoadBackgrounds(e rules.Edition) ([]Background, error) {
+	file := BackgroundsFile
+	if e == rules.Edition2014 {
+		file = Backgrounds2014File
+	}
+	var bgs []Background
+	if err := o.Load(file, &bgs); err != nil {
+		return nil, err
+	}
+	return bgs, nil
+}
+
+// FindBackground returns the named background.
+func FindBackground(bgs []Background, name string) (Background, bool) {
+	for _, b := range bgs {
+		if strings.EqualFold(b.Name, name) {
+			return b, true
+		}
+	}
+	return Background{}, false
+}
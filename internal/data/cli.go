@@ -0,0 +1,88 @@
+package data
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// dumpTypes maps the names accepted by "sheet data dump --type" to their
+// data files.
+var dumpTypes = map[string]string{
+	"armor":            ArmorFile,
+	"backgrounds":      BackgroundsFile,
+	"backgrounds_2014": Backgrounds2014File,
+	"epic_levels":      EpicLevelsFile,
+	"feats":            FeatsFile,
+	"invocations":      InvocationsFile,
+	"languages":        LanguagesFile,
+	"monsters":         MonstersFile,
+	"pact_boons":       PactBoonsFile,
+	"races":            RacesFile,
+	"sidekick_classes": SidekickClassesFile,
+	"spells":           SpellsFile,
+	"subclasses":       SubclassesFile,
+	// tables.File; the tables package imports this one.
+	"tables": "tables.json",
+	"tools":  ToolsFile,
+}
+
+// DumpTypes returns the names "sheet data dump --type" accepts, sorted.
+func DumpTypes() []string {
+	names := make([]string, 0, len(dumpTypes))
+	for n := range dumpTypes {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Run implements the data subcommand:
+//
+//	sheet data dump --type spells [-o file]
+//
+// It writes the merged entries of one data file, with every directory of
+// o applied in order, as a single JSON array. This is what the app
+// actually uses, so it shows which homebrew overrides took effect. The
+// dump goes to stdout unless -o names a file.
+func Run(args []string, stdout io.Writer, o *Overlay) error {
+	const usage = "usage: sheet data dump --type name [-o file]"
+	if len(args) == 0 || args[0] != "dump" {
+		return fmt.Errorf(usage)
+	}
+	fs := flag.NewFlagSet("data dump", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	typ := fs.String("type", "", "the data to dump: "+strings.Join(DumpTypes(), ", "))
+	out := fs.String("o", "", "write to this file instead of stdout")
+	if err := fs.Parse(args[1:]); err != nil {
+		return fmt.Errorf("data dump: %w", err)
+	}
+	if *typ == "" || fs.NArg() != 0 {
+		return fmt.Errorf(usage)
+	}
+	file, ok := dumpTypes[*typ]
+	if !ok {
+		return fmt.Errorf("data dump: unknown type %q (want one of %s)", *typ, strings.Join(DumpTypes(), ", "))
+	}
+	var entries []json.RawMessage
+	if err := o.Load(file, &entries); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", file, err)
+	}
+	data = append(data, '\n')
+	if *out == "" {
+		_, err := stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *out, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunDump(t *testing.T) {
+	core, homebrew := t.TempDir(), t.TempDir()
+	write := func(dir, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, SpellsFile), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(core, `[{"name": "Fireball", "level": 3}, {"name": "Shield", "level": 1}]`)
+	write(homebrew, `[{"name": "fireball", "level": 4}, {"name": "Frost Lance", "level": 2}]`)
+	o := NewOverlay(core, homebrew)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"stdout", []string{"dump", "--type", "spells"}, false},
+		{"file", []string{"dump", "--type", "spells", "-o", filepath.Join(t.TempDir(), "spells.json")}, false},
+		{"no subcommand", nil, true},
+		{"no type", []string{"dump"}, true},
+		{"unknown type", []string{"dump", "--type", "dragons"}, true},
+		{"missing file", []string{"dump", "--type", "feats"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := Run(tt.args, &stdout, o)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			out := stdout.Bytes()
+			if n := len(tt.args); tt.args[n-2] == "-o" {
+				if out, err = os.ReadFile(tt.args[n-1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var got []struct {
+				Name  string `json:"name"`
+				Level int    `json:"level"`
+			}
+			if err := json.Unmarshal(out, &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 3 || got[0].Name != "fireball" || got[0].Level != 4 {
+				t.Errorf("dump = %+v, want the homebrew Fireball first of 3", got)
+			}
+		})
+	}
+}
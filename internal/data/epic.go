@@ -0,0 +1,62 @@
+package data
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"sheet/internal/rules"
+)
+
+// EpicLevelsFile is the data file levels beyond 20 are loaded from. The
+// shipped file is empty; homebrew packs fill it in for campaigns that
+// continue past 20 under the epic levels house rule.
+const EpicLevelsFile = "epic_levels.json"
+
+// EpicLevel is a character level beyond 20, e.g. named "Level 21".
+type EpicLevel struct {
+	Name     string         `json:"name"`
+	Level    int            `json:"level"`
+	XP       int            `json:"xp"`
+	Features []ClassFeature `json:"features,omitempty"`
+}
+
+// LoadEpicLevels reads the epic levels from the data directories, in
+// level order.
+func (o *Overlay) LoadEpicLevels() ([]EpicLevel, error) {
+	var levels []EpicLevel
+	if err := o.Load(EpicLevelsFile, &levels); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(levels, func(a, b EpicLevel) int { return cmp.Compare(a.Level, b.Level) })
+	return levels, nil
+}
+
+// EpicXPTable returns the standard XP table extended with levels, which
+// must run on from 21 without gaps.
+func EpicXPTable(levels []EpicLevel) (rules.XPTable, error) {
+	thresholds := make([]int, len(levels))
+	for i, l := range levels {
+		if want := rules.MaxLevel + 1 + i; l.Level != want {
+			return nil, fmt.Errorf("%s: expected level %d", l.Name, want)
+		}
+		thresholds[i] = l.XP
+	}
+	return rules.ExtendXPTable(thresholds)
+}
+
+// EpicFeaturesAt returns the features gained on reaching an epic level.
+func EpicFeaturesAt(levels []EpicLevel, level int) []ClassFeature {
+	for _, l := range levels {
+		if l.Level != level {
+			continue
+		}
+		out := make([]ClassFeature, len(l.Features))
+		for i, f := range l.Features {
+			f.Level = level
+			out[i] = f
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/effects"
+	"sheet/internal/rules"
+)
+
+// FeatsFile is the data file feats are loaded from.
+const FeatsFile = "feats.json"
+
+// SpellChoice is a set of spells a feat lets the character pick, like
+// Magic Initiate's two cantrips and one 1st-level spell.
+type SpellChoice struct {
+	// Classes whose lists the spells come from; the player picks one.
+	Classes  []string `json:"classes"`
+	Cantrips int      `json:"cantrips,omitempty"`
+	Level1   int      `json:"level1,omitempty"`
+}
+
+// FeatEffects are the mechanical effects of a feat.
+type FeatEffects struct {
+	// AbilityIncrease is a fixed increase; AbilityChoice lets the player
+	// pick which of the listed abilities to increase by one.
+	AbilityIncrease map[rules.Ability]int `json:"ability_increase,omitempty"`
+	AbilityChoice   []rules.Ability       `json:"ability_choice,omitempty"`
+	// AbilityImprovement lets the player increase one ability by 2 or two
+	// abilities by 1, as the Ability Score Improvement does. No score can
+	// go above rules.MaxAbilityScore this way.
+	AbilityImprovement bool `json:"ability_improvement,omitempty"`
+
+	Skills      []string `json:"skills,omitempty"`
+	SkillChoice int      `json:"skill_choice,omitempty"`
+
+	Speed      int `json:"speed,omitempty"`
+	HPPerLevel int `json:"hp_per_level,omitempty"`
+
+	Spells      []string     `json:"spells,omitempty"`
+	SpellChoice *SpellChoice `json:"spell_choice,omitempty"`
+
+	// SaveProficiency grants proficiency in saves of the chosen ability.
+	SaveProficiency bool `json:"save_proficiency,omitempty"`
+
+	// RollEffects are permanent effects on the character's rolls, like
+	// Elven Accuracy's third d20.
+	RollEffects []effects.Effect `json:"roll_effects,omitempty"`
+}
+
+// ActionKind is the tab of the Actions panel an action belongs on.
+type ActionKind string
+
+const (
+	Action      ActionKind = "action"
+	BonusAction ActionKind = "bonus_action"
+	Reaction    ActionKind = "reaction"
+)
+
+// FeatAction is an action, bonus action or reaction a feat grants.
+type FeatAction struct {
+	Name        string     `json:"name"`
+	Kind        ActionKind `json:"kind"`
+	Description string     `json:"description"`
+	// Feat is the feat or pact boon granting the action; it is filled in
+	// by GrantedActions and PactActions.
+	Feat string `json:"-"`
+}
+
+// Feat is a feat definition.
+type Feat struct {
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Prerequisite string       `json:"prerequisite,omitempty"`
+	Repeatable   bool         `json:"repeatable,omitempty"`
+	Effects      FeatEffects  `json:"effects"`
+	Actions      []FeatAction `json:"actions,omitempty"`
+}
+
+// LoadFeats reads every feat from the data directories.
+func (o *Overlay) LoadFeats() ([]Feat, error) {
+	var feats []Feat
+	if err := o.Load(FeatsFile, &feats); err != nil {
+		return nil, err
+	}
+	return feats, nil
+}
+
+// FindFeat returns the named feat.
+func FindFeat(feats []Feat, name string) (Feat, bool) {
+	for _, f := range feats {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return Feat{}, false
+}
+
+// HPModifiers returns the per-level hit point bonuses of the feats a
+// character has taken, such as Tough's, for rules.MaxHP.
+func HPModifiers(feats []Feat, taken []string) []rules.HPModifier {
+	var out []rules.HPModifier
+	for _, name := range taken {
+		if f, ok := FindFeat(feats, name); ok && f.Effects.HPPerLevel != 0 {
+			out = append(out, rules.HPModifier{Source: f.Name, PerLevel: f.Effects.HPPerLevel})
+		}
+	}
+	return out
+}
+
+// RollEffects returns the permanent roll effects of the feats a character
+// has taken, to include with its active effects. Each is sourced to its
+// feat.
+func RollEffects(feats []Feat, taken []string) []effects.Effect {
+	var out []effects.Effect
+	for _, name := range taken {
+		f, ok := FindFeat(feats, name)
+		if !ok {
+			continue
+		}
+		for _, e := range f.Effects.RollEffects {
+			e.Source = f.Name
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// GrantedActions returns the actions granted by the feats a character has
+// taken, grouped by the tab they belong on.
+func GrantedActions(feats []Feat, taken []string) map[ActionKind][]FeatAction {
+	out := make(map[ActionKind][]FeatAction)
+	for _, name := range taken {
+		f, ok := FindFeat(feats, name)
+		if !ok {
+			continue
+		}
+		for _, a := range f.Actions {
+			a.Feat = f.Name
+			out[a.Kind] = append(out[a.Kind], a)
+		}
+	}
+	return out
+}
+
+// FeatChoices are the player's picks for a feat's open choices.
+type FeatChoices struct {
+	Ability rules.Ability `json:"ability,omitempty"`
+	// Improvements are the two increases of an ability improvement. Naming
+	// one ability twice increases it by 2.
+	Improvements []rules.Ability `json:"improvements,omitempty"`
+	Skills       []string        `json:"skills,omitempty"`
+	Spells       []string        `json:"spells,omitempty"`
+}
+
+// FeatTarget is what a feat's effects are applied to. The level-up model
+// implements it over the character being edited.
+type FeatTarget interface {
+	AbilityScore(a rules.Ability) int
+	IncreaseAbility(a rules.Ability, n int)
+	AddSkillProficiency(skill string)
+	AddSpeed(feet int)
+	// AddHPPerLevel raises max HP by hp for every character level,
+	// including levels already gained (Tough is retroactive).
+	AddHPPerLevel(hp int)
+	LearnSpell(name, source string)
+	GrantSaveProficiency(a rules.Ability, source string)
+}
+
+// NeedsAbility reports whether applying the feat requires an ability
+// choice.
+func (f Feat) NeedsAbility() bool {
+	return len(f.Effects.AbilityChoice) > 0 || f.Effects.SaveProficiency
+}
+
+// Validate checks the player's choices against what the feat asks for.
+func (f Feat) Validate(c FeatChoices) error {
+	e := f.Effects
+	if f.NeedsAbility() {
+		if c.Ability == "" {
+			return fmt.Errorf("%s requires choosing an ability", f.Name)
+		}
+		if len(e.AbilityChoice) > 0 && !slices.Contains(e.AbilityChoice, c.Ability) {
+			return fmt.Errorf("%s cannot increase %s", f.Name, c.Ability)
+		}
+	}
+	if e.AbilityImprovement {
+		if len(c.Improvements) != 2 {
+			return fmt.Errorf("%s requires choosing one ability twice or two abilities once, got %d", f.Name, len(c.Improvements))
+		}
+		for _, a := range c.Improvements {
+			if !slices.Contains(rules.Abilities, a) {
+				return fmt.Errorf("%s: unknown ability %q", f.Name, a)
+			}
+		}
+	}
+	if len(c.Skills) != e.SkillChoice {
+		return fmt.Errorf("%s requires choosing %d skills, got %d", f.Name, e.SkillChoice, len(c.Skills))
+	}
+	if sc := e.SpellChoice; sc != nil {
+		if want := sc.Cantrips + sc.Level1; len(c.Spells) != want {
+			return fmt.Errorf("%s requires choosing %d spells, got %d", f.Name, want, len(c.Spells))
+		}
+	}
+	return nil
+}
+
+// Apply validates the choices and applies every effect of the feat to t.
+// It returns the record to store on the character.
+func (f Feat) Apply(t FeatTarget, c FeatChoices) (rules.FeatRecord, error) {
+	if err := f.Validate(c); err != nil {
+		return rules.FeatRecord{}, err
+	}
+	e := f.Effects
+	record := rules.FeatRecord{Name: f.Name, Ability: c.Ability}
+	improve := make(map[rules.Ability]int)
+	if e.AbilityImprovement {
+		for _, a := range c.Improvements {
+			improve[a]++
+		}
+		for _, a := range rules.Abilities {
+			if n := improve[a]; n > 0 && t.AbilityScore(a)+n > rules.MaxAbilityScore {
+				return rules.FeatRecord{}, fmt.Errorf("%s can't raise %s above %d", f.Name, a, rules.MaxAbilityScore)
+			}
+		}
+	}
+
+	for a, n := range e.AbilityIncrease {
+		t.IncreaseAbility(a, n)
+	}
+	if len(e.AbilityChoice) > 0 {
+		t.IncreaseAbility(c.Ability, 1)
+	}
+	for _, a := range rules.Abilities {
+		if n := improve[a]; n > 0 {
+			t.IncreaseAbility(a, n)
+		}
+	}
+	for _, s := range append(slices.Clone(e.Skills), c.Skills...) {
+		t.AddSkillProficiency(s)
+	}
+	if e.Speed != 0 {
+		t.AddSpeed(e.Speed)
+	}
+	if e.HPPerLevel != 0 {
+		t.AddHPPerLevel(e.HPPerLevel)
+	}
+	for _, s := range append(slices.Clone(e.Spells), c.Spells...) {
+		t.LearnSpell(s, f.Name)
+	}
+	if e.SaveProficiency {
+		t.GrantSaveProficiency(c.Ability, record.Label())
+	}
+	return record, nil
+}
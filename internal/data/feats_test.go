@@ -0,0 +1,111 @@
+package data
+
+import (
+	"testing"
+
+	"sheet/internal/rules"
+)
+
+// featTarget records what a feat applies, for tests.
+type featTarget struct {
+	scores    rules.Scores
+	abilities map[rules.Ability]int
+	saves     rules.SavingThrows
+	hp        int
+}
+
+func newFeatTarget() *featTarget {
+	return &featTarget{scores: rules.Scores{}, abilities: map[rules.Ability]int{}, saves: rules.SavingThrows{}}
+}
+
+func (t *featTarget) AbilityScore(a rules.Ability) int { return t.scores[a] }
+
+func (t *featTarget) IncreaseAbility(a rules.Ability, n int) { t.abilities[a] += n }
+func (t *featTarget) AddSkillProficiency(string)             {}
+func (t *featTarget) AddSpeed(int)                           {}
+func (t *featTarget) AddHPPerLevel(hp int)                   { t.hp += hp }
+func (t *featTarget) LearnSpell(string, string)              {}
+func (t *featTarget) GrantSaveProficiency(a rules.Ability, source string) {
+	t.saves.Grant(a, source)
+}
+
+func TestFeatApply(t *testing.T) {
+	feats, err := NewOverlay("../../data").LoadFeats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		feat      string
+		scores    rules.Scores
+		choices   FeatChoices
+		wantErr   bool
+		wantUp    map[rules.Ability]int
+		wantSaves map[rules.Ability][]string
+		wantHP    int
+	}{
+		{
+			feat:      "Resilient",
+			choices:   FeatChoices{Ability: rules.Constitution},
+			wantUp:    map[rules.Ability]int{rules.Constitution: 1},
+			wantSaves: map[rules.Ability][]string{rules.Constitution: {"Resilient (CON)"}},
+		},
+		{feat: "Resilient", wantErr: true},
+		{feat: "Tough", wantHP: 2},
+		{
+			feat:    "Ability Score Improvement",
+			scores:  rules.Scores{rules.Strength: 16},
+			choices: FeatChoices{Improvements: []rules.Ability{rules.Strength, rules.Strength}},
+			wantUp:  map[rules.Ability]int{rules.Strength: 2},
+		},
+		{
+			feat:    "Ability Score Improvement",
+			scores:  rules.Scores{rules.Dexterity: 19, rules.Constitution: 13},
+			choices: FeatChoices{Improvements: []rules.Ability{rules.Dexterity, rules.Constitution}},
+			wantUp:  map[rules.Ability]int{rules.Dexterity: 1, rules.Constitution: 1},
+		},
+		{
+			feat:    "Ability Score Improvement",
+			scores:  rules.Scores{rules.Dexterity: 19},
+			choices: FeatChoices{Improvements: []rules.Ability{rules.Dexterity, rules.Dexterity}},
+			wantErr: true,
+		},
+		{
+			feat:    "Ability Score Improvement",
+			choices: FeatChoices{Improvements: []rules.Ability{rules.Wisdom}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.feat, func(t *testing.T) {
+			f, ok := FindFeat(feats, tt.feat)
+			if !ok {
+				t.Fatalf("no %s in feats.json", tt.feat)
+			}
+			target := newFeatTarget()
+			for a, n := range tt.scores {
+				target.scores[a] = n
+			}
+			_, err := f.Apply(target, tt.choices)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply error = %v, want error %v", err, tt.wantErr)
+			}
+			for a, want := range tt.wantSaves {
+				got := target.saves.Sources(a)
+				if len(got) != len(want) || got[0] != want[0] {
+					t.Errorf("%s save sources = %v, want %v", a, got, want)
+				}
+			}
+			if len(target.abilities) != len(tt.wantUp) {
+				t.Errorf("ability increases = %v, want %v", target.abilities, tt.wantUp)
+			}
+			for a, want := range tt.wantUp {
+				if got := target.abilities[a]; got != want {
+					t.Errorf("%s increased by %d, want %d", a, got, want)
+				}
+			}
+			if target.hp != tt.wantHP {
+				t.Errorf("HP per level = %d, want %d", target.hp, tt.wantHP)
+			}
+		})
+	}
+}
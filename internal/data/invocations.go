@@ -0,0 +1,110 @@
+package data
+
+import (
+	"slices"
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// InvocationsFile is the data file Eldritch Invocations are loaded from.
+const InvocationsFile = "invocations.json"
+
+// InvocationDamage is a passive damage bonus an invocation adds to a spell,
+// like Agonizing Blast adding CHA to Eldritch Blast.
+type InvocationDamage struct {
+	Spell   string        `json:"spell"`
+	Ability rules.Ability `json:"ability"`
+}
+
+// Invocation is an Eldritch Invocation.
+type Invocation struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Level is the minimum warlock level.
+	Level int `json:"level,omitempty"`
+	// Pact is the pact boon required, if any.
+	Pact string `json:"pact,omitempty"`
+	// Spell is a spell the warlock must know, if any.
+	Spell string `json:"spell,omitempty"`
+	// Repeatable invocations can be taken more than once.
+	Repeatable bool              `json:"repeatable,omitempty"`
+	Damage     *InvocationDamage `json:"damage,omitempty"`
+}
+
+// LoadInvocations reads every invocation from the data directories.
+func (o *Overlay) LoadInvocations() ([]Invocation, error) {
+	var invs []Invocation
+	if err := o.Load(InvocationsFile, &invs); err != nil {
+		return nil, err
+	}
+	return invs, nil
+}
+
+// FindInvocation returns the named invocation.
+func FindInvocation(invs []Invocation, name string) (Invocation, bool) {
+	for _, inv := range invs {
+		if strings.EqualFold(inv.Name, name) {
+			return inv, true
+		}
+	}
+	return Invocation{}, false
+}
+
+// Eligible reports whether a warlock meets the invocation's prerequisites.
+func (inv Invocation) Eligible(warlockLevel int, pact string, knownSpells []string) bool {
+	if warlockLevel < inv.Level {
+		return false
+	}
+	if inv.Pact != "" && !strings.EqualFold(inv.Pact, pact) {
+		return false
+	}
+	if inv.Spell != "" && !slices.ContainsFunc(knownSpells, func(s string) bool {
+		return strings.EqualFold(s, inv.Spell)
+	}) {
+		return false
+	}
+	return true
+}
+
+// AvailableInvocations lists the invocations a warlock can pick, excluding
+// ones already taken unless they are repeatable.
+func AvailableInvocations(invs []Invocation, warlockLevel int, pact string, knownSpells, taken []string) []Invocation {
+	var out []Invocation
+	for _, inv := range invs {
+		already := slices.ContainsFunc(taken, func(t string) bool {
+			return strings.EqualFold(t, inv.Name)
+		})
+		if already && !inv.Repeatable {
+			continue
+		}
+		if inv.Eligible(warlockLevel, pact, knownSpells) {
+			out = append(out, inv)
+		}
+	}
+	return out
+}
+
+// invocationsKnown is the SRD Warlock table's Invocations Known column,
+// indexed by warlock level.
+var invocationsKnown = [21]int{0, 0, 2, 2, 2, 3, 3, 4, 4, 5, 5, 5, 6, 6, 6, 7, 7, 7, 8, 8, 8}
+
+// InvocationsKnown returns how many invocations a warlock of the given
+// level knows.
+func InvocationsKnown(warlockLevel int) int {
+	return invocationsKnown[min(max(warlockLevel, 0), 20)]
+}
+
+// SpellDamageBonus totals the flat damage the taken invocations add to a
+// spell, e.g. Agonizing Blast's CHA modifier on Eldritch Blast.
+func SpellDamageBonus(invs []Invocation, taken []string, spell string, scores rules.Scores) int {
+	bonus := 0
+	for _, name := range taken {
+		inv, ok := FindInvocation(invs, name)
+		if !ok || inv.Damage == nil || !strings.EqualFold(inv.Damage.Spell, spell) {
+			continue
+		}
+		bonus += scores.Modifier(inv.Damage.Ability)
+	}
+	return bonus
+}
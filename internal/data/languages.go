@@ -0,0 +1,58 @@
+package data
+
+import (
+	"strings"
+)
+
+// LanguagesFile is the data file languages are loaded from.
+const LanguagesFile = "languages.json"
+
+// LanguageType is whether a language is standard or exotic. Characters
+// usually pick standard languages; exotic ones need the DM's say-so.
+type LanguageType string
+
+const (
+	StandardLanguage LanguageType = "standard"
+	ExoticLanguage   LanguageType = "exotic"
+)
+
+// Language is a language definition.
+type Language struct {
+	Name string       `json:"name"`
+	Type LanguageType `json:"type"`
+	// Script is the alphabet it is written in; empty for spoken-only
+	// languages like Deep Speech.
+	Script   string `json:"script,omitempty"`
+	Speakers string `json:"speakers,omitempty"`
+}
+
+// LoadLanguages reads every language from the data directories.
+func (o *Overlay) LoadLanguages() ([]Language, error) {
+	var langs []Language
+	if err := o.Load(LanguagesFile, &langs); err != nil {
+		return nil, err
+	}
+	return langs, nil
+}
+
+// FindLanguage returns the named language.
+func FindLanguage(langs []Language, name string) (Language, bool) {
+	for _, l := range langs {
+		if strings.EqualFold(l.Name, name) {
+			return l, true
+		}
+	}
+	return Language{}, false
+}
+
+// CompleteLanguage returns the first language whose name starts with
+// prefix, for tab completion.
+func CompleteLanguage(langs []Language, prefix string) (Language, bool) {
+	prefix = strings.ToLower(prefix)
+	for _, l := range langs {
+		if strings.HasPrefix(strings.ToLower(l.Name), prefix) {
+			return l, true
+		}
+	}
+	return Language{}, false
+}
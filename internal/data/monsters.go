@@ -0,0 +1,90 @@
+package data
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// MonstersFile is the data file monster stat blocks are loaded from.
+const MonstersFile = "monsters.json"
+
+// MonsterFeature is a trait, action or legendary action in a stat block.
+type MonsterFeature struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Recharge is the lowest d6 face that recharges the action, or 0.
+	Recharge int `json:"recharge,omitempty"`
+	// Cost is the number of legendary actions the action takes, if more
+	// than one.
+	Cost int `json:"cost,omitempty"`
+}
+
+// Monster is a stat block.
+type Monster struct {
+	Name      string `json:"name"`
+	Size      string `json:"size"`
+	Type      string `json:"type"`
+	Alignment string `json:"alignment,omitempty"`
+
+	ArmorClass int    `json:"armor_class"`
+	ArmorNote  string `json:"armor_note,omitempty"`
+	HP         int    `json:"hp"`
+	HitDice    string `json:"hit_dice"`
+	Speed      string `json:"speed"`
+
+	Abilities rules.Scores `json:"abilities"`
+	Senses    string       `json:"senses,omitempty"`
+	Languages string       `json:"languages,omitempty"`
+	CR        string       `json:"cr"`
+
+	LegendaryResistances int              `json:"legendary_resistances,omitempty"`
+	Traits               []MonsterFeature `json:"traits,omitempty"`
+	Actions              []MonsterFeature `json:"actions,omitempty"`
+	LegendaryActions     []MonsterFeature `json:"legendary_actions,omitempty"`
+}
+
+// XP returns the XP the monster is worth, or 0 if its CR is unknown.
+func (m Monster) XP() int {
+	xp, _ := rules.ChallengeXP(m.CR)
+	return xp
+}
+
+// LoadMonsters reads every stat block from the data directories.
+func (o *Overlay) LoadMonsters() ([]Monster, error) {
+	var ms []Monster
+	if err := o.Load(MonstersFile, &ms); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// FindMonster returns the named monster.
+func FindMonster(ms []Monster, name string) (Monster, bool) {
+	for _, m := range ms {
+		if strings.EqualFold(m.Name, name) {
+			return m, true
+		}
+	}
+	return Monster{}, false
+}
+
+// SearchMonsters returns the monsters whose name or type contains query,
+// ordered by challenge rating and then name. An empty query matches all.
+func SearchMonsters(ms []Monster, query string) []Monster {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var out []Monster
+	for _, m := range ms {
+		if strings.Contains(strings.ToLower(m.Name), query) || strings.Contains(strings.ToLower(m.Type), query) {
+			out = append(out, m)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Monster) int {
+		ca, _ := rules.ChallengeValue(a.CR)
+		cb, _ := rules.ChallengeValue(b.CR)
+		return cmp.Or(cmp.Compare(ca, cb), strings.Compare(a.Name, b.Name))
+	})
+	return out
+}
@@ -0,0 +1,146 @@
+// Package data loads game data files (races, classes, spells, feats, items)
+// from one or more directories.
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Override records that an entry from a later directory replaced one with
+// the same name from an earlier directory.
+type Override struct {
+	File string
+	Name string
+	// Dir is the directory whose entry won.
+	Dir string
+	// Replaced is the directory whose entry was replaced.
+	Replaced string
+}
+
+// Overlay reads data files from a list of directories, such as the shipped
+// data directory followed by a homebrew directory. Each file is a JSON
+// array of objects with a "name" field; entries are merged by name
+// (case-insensitively) and the later directory wins.
+type Overlay struct {
+	dirs      []string
+	overrides []Override
+}
+
+// NewOverlay returns an overlay over dirs, in increasing precedence. A
+// leading "~" is expanded to the home directory.
+func NewOverlay(dirs ...string) *Overlay {
+	o := &Overlay{}
+	for _, d := range dirs {
+		o.dirs = append(o.dirs, expandHome(d))
+	}
+	return o
+}
+
+// Dirs returns the directories in increasing precedence.
+func (o *Overlay) Dirs() []string {
+	return o.dirs
+}
+
+// Load merges file across all directories and unmarshals the result into v.
+// Directories that don't contain the file are skipped, but at least one
+// must.
+func (o *Overlay) Load(file string, v any) error {
+	merged, err := o.merge(file)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("failed to marshal merged %s: %w", file, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse merged %s: %w", file, err)
+	}
+	return nil
+}
+
+// Overrides lists the replacements made by the latest Load of each file.
+func (o *Overlay) Overrides() []Override {
+	out := make([]Override, len(o.overrides))
+	copy(out, o.overrides)
+	return out
+}
+
+type entry struct {
+	raw json.RawMessage
+	dir string
+}
+
+func (o *Overlay) merge(file string) ([]json.RawMessage, error) {
+	// Loading a file again replaces what was recorded for it.
+	o.overrides = slices.DeleteFunc(o.overrides, func(ov Override) bool { return ov.File == file })
+
+	var order []string
+	byName := make(map[string]entry)
+	found := false
+
+	for _, dir := range o.dirs {
+		path := filepath.Join(dir, file)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		found = true
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for i, raw := range items {
+			var named struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(raw, &named); err != nil || named.Name == "" {
+				return nil, fmt.Errorf("%s: entry %d has no name", path, i+1)
+			}
+
+			key := strings.ToLower(named.Name)
+			if prev, ok := byName[key]; ok {
+				o.overrides = append(o.overrides, Override{
+					File:     file,
+					Name:     named.Name,
+					Dir:      dir,
+					Replaced: prev.dir,
+				})
+			} else {
+				order = append(order, key)
+			}
+			byName[key] = entry{raw: raw, dir: dir}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%s not found in %s", file, strings.Join(o.dirs, ", "))
+	}
+
+	merged := make([]json.RawMessage, len(order))
+	for i, key := range order {
+		merged[i] = byName[key].raw
+	}
+	return merged, nil
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
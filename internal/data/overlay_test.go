@@ -0,0 +1,42 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOverridesAfterReload(t *testing.T) {
+	core, homebrew := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		filepath.Join(core, SpellsFile):     `[{"name": "Fireball"}, {"name": "Shield"}]`,
+		filepath.Join(homebrew, SpellsFile): `[{"name": "Fireball"}]`,
+		filepath.Join(core, FeatsFile):      `[{"name": "Tough"}]`,
+		filepath.Join(homebrew, FeatsFile):  `[{"name": "tough"}]`,
+	}
+	for path, contents := range files {
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	o := NewOverlay(core, homebrew)
+	tests := []struct {
+		load string
+		want int
+	}{
+		{SpellsFile, 1},
+		{SpellsFile, 1},
+		{FeatsFile, 2},
+		{FeatsFile, 2},
+		{SpellsFile, 2},
+	}
+	for i, tt := range tests {
+		var v []map[string]any
+		if err := o.Load(tt.load, &v); err != nil {
+			t.Fatal(err)
+		}
+		if got := len(o.Overrides()); got != tt.want {
+			t.Errorf("load %d (%s): %d overrides, want %d: %+v", i+1, tt.load, got, tt.want, o.Overrides())
+		}
+	}
+}
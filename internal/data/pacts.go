@@ -0,0 +1,102 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/inventory"
+)
+
+// PactBoonsFile is the data file warlock pact boons are loaded from.
+const PactBoonsFile = "pact_boons.json"
+
+// PactBoonLevel is the warlock level a pact boon is chosen at.
+const PactBoonLevel = 3
+
+// PactBoon is a warlock's Pact Boon. Its name is the one invocation
+// prerequisites use, e.g. "Tome".
+type PactBoon struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Spells are learned outright; SpellChoice is picked by the player.
+	// A SpellChoice with no classes allows any class's list.
+	Spells      []string         `json:"spells,omitempty"`
+	SpellChoice *SpellChoice     `json:"spell_choice,omitempty"`
+	Actions     []FeatAction     `json:"actions,omitempty"`
+	Items       []inventory.Item `json:"items,omitempty"`
+}
+
+// Title returns the boon's full name, e.g. "Pact of the Tome".
+func (b PactBoon) Title() string {
+	return "Pact of the " + b.Name
+}
+
+// LoadPactBoons reads every pact boon from the data directories.
+func (o *Overlay) LoadPactBoons() ([]PactBoon, error) {
+	var boons []PactBoon
+	if err := o.Load(PactBoonsFile, &boons); err != nil {
+		return nil, err
+	}
+	return boons, nil
+}
+
+// FindPactBoon returns the named boon, by short name or title.
+func FindPactBoon(boons []PactBoon, name string) (PactBoon, bool) {
+	for _, b := range boons {
+		if strings.EqualFold(b.Name, name) || strings.EqualFold(b.Title(), name) {
+			return b, true
+		}
+	}
+	return PactBoon{}, false
+}
+
+// PactActions returns the actions a boon grants, each sourced to it.
+func (b PactBoon) PactActions() []FeatAction {
+	out := make([]FeatAction, len(b.Actions))
+	for i, a := range b.Actions {
+		a.Feat = b.Title()
+		out[i] = a
+	}
+	return out
+}
+
+// PactTarget is what a pact boon's grants are applied to. The level-up
+// model implements it over the warlock being edited.
+type PactTarget interface {
+	SetPactBoon(name string)
+	LearnSpell(name, source string)
+	AddItem(it inventory.Item)
+}
+
+// Validate checks that a warlock of the given level can take the boon
+// with the chosen spells.
+func (b PactBoon) Validate(warlockLevel int, spells []string) error {
+	if warlockLevel < PactBoonLevel {
+		return fmt.Errorf("%s needs warlock level %d", b.Title(), PactBoonLevel)
+	}
+	want := 0
+	if sc := b.SpellChoice; sc != nil {
+		want = sc.Cantrips + sc.Level1
+	}
+	if len(spells) != want {
+		return fmt.Errorf("%s requires choosing %d spells, got %d", b.Title(), want, len(spells))
+	}
+	return nil
+}
+
+// Apply validates the choice and applies the boon's spells and items to
+// t. The boon's actions are derived from it with PactActions rather than
+// stored.
+func (b PactBoon) Apply(t PactTarget, warlockLevel int, spells []string) error {
+	if err := b.Validate(warlockLevel, spells); err != nil {
+		return err
+	}
+	t.SetPactBoon(b.Name)
+	for _, s := range append(b.Spells[:len(b.Spells):len(b.Spells)], spells...) {
+		t.LearnSpell(s, b.Title())
+	}
+	for _, it := range b.Items {
+		t.AddItem(it)
+	}
+	return nil
+}
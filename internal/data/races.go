@@ -0,0 +1,255 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// RacesFile is the data file races are loaded from.
+const RacesFile = "races.json"
+
+// AbilityChoice is a set of +1 increases the player assigns, like the
+// Half-Elf's two. An empty From allows any ability.
+type AbilityChoice struct {
+	From  []rules.Ability `json:"from,omitempty"`
+	Count int             `json:"count"`
+}
+
+// InnateSpell is a spell a race can cast, like the Tiefling's Hellish
+// Rebuke. Level is the character level it is gained at.
+type InnateSpell struct {
+	Name  string `json:"name"`
+	Level int    `json:"level,omitempty"`
+}
+
+// RaceTraits are the mechanical traits a race or subrace grants.
+type RaceTraits struct {
+	// Darkvision is its range in feet; zero means none.
+	Darkvision  int                `json:"darkvision,omitempty"`
+	Resistances []rules.DamageType `json:"resistances,omitempty"`
+
+	Skills      []string `json:"skills,omitempty"`
+	SkillChoice int      `json:"skill_choice,omitempty"`
+
+	Languages      []string `json:"languages,omitempty"`
+	LanguageChoice int      `json:"language_choice,omitempty"`
+
+	Spells      []InnateSpell `json:"spells,omitempty"`
+	SpellChoice *SpellChoice  `json:"spell_choice,omitempty"`
+
+	// HPPerLevel is extra hit points for every character level, like the
+	// Hill Dwarf's Dwarven Toughness.
+	HPPerLevel int `json:"hp_per_level,omitempty"`
+}
+
+// Subrace is a race's subrace. Its ability bonuses and traits add to the
+// race's.
+type Subrace struct {
+	Name string `json:"name"`
+	// Speed replaces the race's walking speed when set, like the Wood
+	// Elf's 35 feet.
+	Speed          int                   `json:"speed,omitempty"`
+	AbilityBonuses map[rules.Ability]int `json:"ability_bonuses,omitempty"`
+	RaceTraits
+}
+
+// Race is a race definition. Its ability bonuses only apply under the 2014
+// rules; see AbilityBonuses.
+type Race struct {
+	Name           string                `json:"name"`
+	Size           string                `json:"size"`
+	Speed          int                   `json:"speed"`
+	AbilityBonuses map[rules.Ability]int `json:"ability_bonuses,omitempty"`
+	AbilityChoice  *AbilityChoice        `json:"ability_choice,omitempty"`
+	RaceTraits
+	Subraces []Subrace `json:"subraces,omitempty"`
+}
+
+// LoadRaces reads every race from the data directories.
+func (o *Overlay) LoadRaces() ([]Race, error) {
+	var races []Race
+	if err := o.Load(RacesFile, &races); err != nil {
+		return nil, err
+	}
+	return races, nil
+}
+
+// FindRace returns the named race.
+func FindRace(races []Race, name string) (Race, bool) {
+	for _, r := range races {
+		if strings.EqualFold(r.Name, name) {
+			return r, true
+		}
+	}
+	return Race{}, false
+}
+
+// HasSubraces reports whether the player must pick a subrace.
+func (r Race) HasSubraces() bool {
+	return len(r.Subraces) > 0
+}
+
+// SpeedFor returns the walking speed of the race with the subrace.
+func (r Race) SpeedFor(subrace string) int {
+	if sub, ok := r.FindSubrace(subrace); ok && sub.Speed > 0 {
+		return sub.Speed
+	}
+	return r.Speed
+}
+
+// RaceLabel returns the race as the sheet header shows it, e.g. "Hill
+// Dwarf", or "Lightfoot Halfling" for subraces not named after their race.
+func RaceLabel(race, subrace string) string {
+	switch {
+	case subrace == "":
+		return race
+	case strings.Contains(strings.ToLower(subrace), strings.ToLower(race)):
+		return subrace
+	}
+	return subrace + " " + race
+}
+
+// FindSubrace returns the race's named subrace.
+func (r Race) FindSubrace(name string) (Subrace, bool) {
+	for _, s := range r.Subraces {
+		if strings.EqualFold(s.Name, name) {
+			return s, true
+		}
+	}
+	return Subrace{}, false
+}
+
+// RaceChoices are the player's picks for a race's open choices.
+type RaceChoices struct {
+	Skills    []string `json:"skills,omitempty"`
+	Languages []string `json:"languages,omitempty"`
+	Spells    []string `json:"spells,omitempty"`
+}
+
+// RaceTarget is what a race's traits are applied to. The creation wizard
+// implements it over the new character.
+type RaceTarget interface {
+	SetDarkvision(feet int)
+	AddResistance(dt rules.DamageType, source string)
+	AddSkillProficiency(skill string)
+	AddLanguage(language string)
+	LearnSpell(name, source string)
+}
+
+// Traits returns the race's traits combined with the subrace's.
+func (r Race) Traits(subrace string) (RaceTraits, error) {
+	t := r.RaceTraits
+	if subrace == "" {
+		return t, nil
+	}
+	sub, ok := r.FindSubrace(subrace)
+	if !ok {
+		return RaceTraits{}, fmt.Errorf("%s has no subrace %q", r.Name, subrace)
+	}
+	s := sub.RaceTraits
+	t.Darkvision = max(t.Darkvision, s.Darkvision)
+	t.Resistances = append(slices.Clone(t.Resistances), s.Resistances...)
+	t.Skills = append(slices.Clone(t.Skills), s.Skills...)
+	t.SkillChoice += s.SkillChoice
+	t.Languages = append(slices.Clone(t.Languages), s.Languages...)
+	t.LanguageChoice += s.LanguageChoice
+	t.Spells = append(slices.Clone(t.Spells), s.Spells...)
+	if s.SpellChoice != nil {
+		t.SpellChoice = s.SpellChoice
+	}
+	t.HPPerLevel += s.HPPerLevel
+	return t, nil
+}
+
+// Validate checks the player's choices against what the traits ask for.
+func (t RaceTraits) Validate(race string, c RaceChoices) error {
+	if len(c.Skills) != t.SkillChoice {
+		return fmt.Errorf("%s requires choosing %d skills, got %d", race, t.SkillChoice, len(c.Skills))
+	}
+	if len(c.Languages) != t.LanguageChoice {
+		return fmt.Errorf("%s requires choosing %d languages, got %d", race, t.LanguageChoice, len(c.Languages))
+	}
+	want := 0
+	if sc := t.SpellChoice; sc != nil {
+		want = sc.Cantrips + sc.Level1
+	}
+	if len(c.Spells) != want {
+		return fmt.Errorf("%s requires choosing %d spells, got %d", race, want, len(c.Spells))
+	}
+	return nil
+}
+
+// Apply validates the choices and applies the race's and subrace's traits
+// to t for a character of the given level. Innate spells of higher levels
+// are left for SpellsGainedAt.
+func (r Race) Apply(t RaceTarget, subrace string, level int, c RaceChoices) error {
+	if r.HasSubraces() && subrace == "" {
+		return fmt.Errorf("%s requires choosing a subrace", r.Name)
+	}
+	traits, err := r.Traits(subrace)
+	if err != nil {
+		return err
+	}
+	if err := traits.Validate(r.Name, c); err != nil {
+		return err
+	}
+	source := r.Name
+	if sub, ok := r.FindSubrace(subrace); ok {
+		source = sub.Name
+	}
+	if traits.Darkvision > 0 {
+		t.SetDarkvision(traits.Darkvision)
+	}
+	for _, dt := range traits.Resistances {
+		t.AddResistance(dt, source)
+	}
+	for _, s := range append(slices.Clone(traits.Skills), c.Skills...) {
+		t.AddSkillProficiency(s)
+	}
+	for _, l := range append(slices.Clone(traits.Languages), c.Languages...) {
+		t.AddLanguage(l)
+	}
+	for _, s := range traits.Spells {
+		if s.Level <= level {
+			t.LearnSpell(s.Name, source)
+		}
+	}
+	for _, s := range c.Spells {
+		t.LearnSpell(s, source)
+	}
+	return nil
+}
+
+// HPModifiers returns the race's and subrace's per-level hit point bonus,
+// sourced like the rest of its traits, for rules.MaxHP alongside the
+// feats' HPModifiers.
+func (r Race) HPModifiers(subrace string) []rules.HPModifier {
+	traits, err := r.Traits(subrace)
+	if err != nil || traits.HPPerLevel == 0 {
+		return nil
+	}
+	source := r.Name
+	if sub, ok := r.FindSubrace(subrace); ok {
+		source = sub.Name
+	}
+	return []rules.HPModifier{{Source: source, PerLevel: traits.HPPerLevel}}
+}
+
+// SpellsGainedAt returns the innate spells gained on reaching level, for
+// the level-up flow.
+func (r Race) SpellsGainedAt(subrace string, level int) []string {
+	traits, err := r.Traits(subrace)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, s := range traits.Spells {
+		if s.Level > 1 && s.Level == level {
+			out = append(out, s.Name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package data
+
+import (
+	"slices"
+	"testing"
+
+	"sheet/internal/rules"
+)
+
+func TestRaceHPModifiers(t *testing.T) {
+	races, err := NewOverlay("../../data").LoadRaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dwarf, ok := FindRace(races, "Dwarf")
+	if !ok {
+		t.Fatal("no Dwarf in races.json")
+	}
+	tests := []struct {
+		subrace string
+		want    []rules.HPModifier
+	}{
+		{"Hill Dwarf", []rules.HPModifier{{Source: "Hill Dwarf", PerLevel: 1}}},
+		{"Mountain Dwarf", nil},
+		{"", nil},
+		{"Deep Dwarf", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subrace, func(t *testing.T) {
+			got := dwarf.HPModifiers(tt.subrace)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("HPModifiers(%q) = %v, want %v", tt.subrace, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHillDwarfMaxHP(t *testing.T) {
+	races, err := NewOverlay("../../data").LoadRaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dwarf, _ := FindRace(races, "Dwarf")
+	mods := dwarf.HPModifiers("Hill Dwarf")
+	classes := []rules.ClassLevel{{Class: "Fighter", Level: 3}}
+	// 10 at 1st level and 6 for each of the next two, plus 1 per level.
+	if got := rules.MaxHP(classes, 0, rules.RoundUp, mods); got != 25 {
+		t.Errorf("MaxHP = %d, want 25", got)
+	}
+}
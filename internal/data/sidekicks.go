@@ -0,0 +1,86 @@
+package data
+
+import (
+	"slices"
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// SidekickClassesFile is the data file sidekick classes are loaded from.
+const SidekickClassesFile = "sidekick_classes.json"
+
+// ClassFeature is a feature a class grants at a level.
+type ClassFeature struct {
+	Level       int    `json:"level,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SidekickRole is a Spellcaster's or Warrior's role, picked at 1st level.
+// A Spellcaster's role sets its spellcasting ability and spell lists.
+type SidekickRole struct {
+	Name       string        `json:"name"`
+	Ability    rules.Ability `json:"ability,omitempty"`
+	SpellLists []string      `json:"spell_lists,omitempty"`
+}
+
+// SidekickClass is one of Tasha's sidekick classes, the reduced feature
+// set a DM-run companion levels in.
+type SidekickClass struct {
+	Name string `json:"name"`
+	// Saves are the abilities the sidekick picks one saving throw
+	// proficiency from.
+	Saves       []rules.Ability `json:"saves"`
+	SkillChoice int             `json:"skill_choice"`
+	ASILevels   []int           `json:"asi_levels"`
+	Roles       []SidekickRole  `json:"roles,omitempty"`
+	Features    []ClassFeature  `json:"features"`
+}
+
+// LoadSidekickClasses reads every sidekick class from the data
+// directories.
+func (o *Overlay) LoadSidekickClasses() ([]SidekickClass, error) {
+	var classes []SidekickClass
+	if err := o.Load(SidekickClassesFile, &classes); err != nil {
+		return nil, err
+	}
+	return classes, nil
+}
+
+// FindSidekickClass returns the named sidekick class.
+func FindSidekickClass(classes []SidekickClass, name string) (SidekickClass, bool) {
+	for _, c := range classes {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return SidekickClass{}, false
+}
+
+// FeaturesAt returns the features gained on reaching level.
+func (c SidekickClass) FeaturesAt(level int) []ClassFeature {
+	var out []ClassFeature
+	for _, f := range c.Features {
+		if f.Level == level {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// GrantsASI reports whether reaching level grants an Ability Score
+// Improvement.
+func (c SidekickClass) GrantsASI(level int) bool {
+	return slices.Contains(c.ASILevels, level)
+}
+
+// FindRole returns the class's named role.
+func (c SidekickClass) FindRole(name string) (SidekickRole, bool) {
+	for _, r := range c.Roles {
+		if strings.EqualFold(r.Name, name) {
+			return r, true
+		}
+	}
+	return SidekickRole{}, false
+}
@@ -0,0 +1,133 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SpellIndexEntry is the part of a spell kept in memory by a SpellIndex,
+// with where the full entry is stored.
+type SpellIndexEntry struct {
+	Name        string   `json:"name"`
+	Level       int      `json:"level"`
+	School      string   `json:"school"`
+	CastingTime string   `json:"casting_time"`
+	Classes     []string `json:"classes"`
+
+	path   string
+	offset int64
+	length int64
+}
+
+// SpellIndex holds only the index fields of every spell, for large spell
+// collections on memory-constrained machines. Descriptions and the other
+// fields are read from the data file when asked for.
+type SpellIndex struct {
+	Entries []SpellIndexEntry
+}
+
+// IndexSpells indexes the spells file in every data directory. Spells
+// are merged by name as Load merges them: the later directory wins. The
+// files are read as a stream, so only the index is kept.
+func (o *Overlay) IndexSpells() (*SpellIndex, error) {
+	var order []string
+	byName := make(map[string]SpellIndexEntry)
+	found := false
+	for _, dir := range o.dirs {
+		entries, err := indexFile(filepath.Join(dir, SpellsFile))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, e := range entries {
+			key := strings.ToLower(e.Name)
+			if _, ok := byName[key]; !ok {
+				order = append(order, key)
+			}
+			byName[key] = e
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%s not found in %s", SpellsFile, strings.Join(o.dirs, ", "))
+	}
+	idx := &SpellIndex{Entries: make([]SpellIndexEntry, len(order))}
+	for i, key := range order {
+		idx.Entries[i] = byName[key]
+	}
+	return idx, nil
+}
+
+// indexFile decodes the index fields of each entry in a spells file and
+// records the byte range the entry occupies.
+func indexFile(path string) ([]SpellIndexEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, fmt.Errorf("failed to parse %s: want a JSON array", path)
+	}
+	var entries []SpellIndexEntry
+	for dec.More() {
+		// The offset before decoding may sit before the separating comma;
+		// Spell trims it when reading the entry back.
+		start := dec.InputOffset()
+		var e SpellIndexEntry
+		if err := dec.Decode(&e); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if e.Name == "" {
+			return nil, fmt.Errorf("%s: entry %d has no name", path, len(entries)+1)
+		}
+		e.path, e.offset, e.length = path, start, dec.InputOffset()-start
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// Find returns the named spell's index entry.
+func (idx *SpellIndex) Find(name string) (SpellIndexEntry, bool) {
+	for _, e := range idx.Entries {
+		if strings.EqualFold(e.Name, name) {
+			return e, true
+		}
+	}
+	return SpellIndexEntry{}, false
+}
+
+// Spell reads the entry's full spell from its data file.
+func (e SpellIndexEntry) Spell() (Spell, error) {
+	f, err := os.Open(e.path)
+	if err != nil {
+		return Spell{}, fmt.Errorf("failed to read %s: %w", e.Name, err)
+	}
+	defer f.Close()
+	buf := make([]byte, e.length)
+	if _, err := f.ReadAt(buf, e.offset); err != nil {
+		return Spell{}, fmt.Errorf("failed to read %s: %w", e.Name, err)
+	}
+	var s Spell
+	if err := json.Unmarshal(bytes.TrimLeft(buf, ", \t\r\n"), &s); err != nil {
+		return Spell{}, fmt.Errorf("failed to parse %s: %w", e.Name, err)
+	}
+	return s, nil
+}
+
+// Description reads the entry's description from its data file.
+func (e SpellIndexEntry) Description() (string, error) {
+	s, err := e.Spell()
+	return s.Description, err
+}
@@ -0,0 +1,70 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSpellIndexMatchesLoad(t *testing.T) {
+	o := NewOverlay("../../data")
+	spells, err := o.LoadSpells()
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, err := o.IndexSpells()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Entries) != len(spells) {
+		t.Fatalf("indexed %d spells, loaded %d", len(idx.Entries), len(spells))
+	}
+	for i, e := range idx.Entries {
+		got, err := e.Spell()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, spells[i]) {
+			t.Errorf("entry %d reads %+v, want %+v", i, got, spells[i])
+		}
+	}
+}
+
+func TestSpellIndexOverride(t *testing.T) {
+	core, homebrew := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		core:     `[{"name": "Fireball", "level": 3, "description": "core"}, {"name": "Shield", "level": 1, "description": "shield"}]`,
+		homebrew: `[{"name": "fireball", "level": 4, "description": "homebrew"}]`,
+	}
+	for dir, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, SpellsFile), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	idx, err := NewOverlay(core, homebrew).IndexSpells()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		level int
+		desc  string
+	}{
+		{"Fireball", 4, "homebrew"},
+		{"shield", 1, "shield"},
+	}
+	for _, tt := range tests {
+		e, ok := idx.Find(tt.name)
+		if !ok {
+			t.Fatalf("%s not indexed", tt.name)
+		}
+		desc, err := e.Description()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Level != tt.level || desc != tt.desc {
+			t.Errorf("%s = level %d %q, want level %d %q", tt.name, e.Level, desc, tt.level, tt.desc)
+		}
+	}
+}
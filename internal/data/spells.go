@@ -0,0 +1,161 @@
+package data
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// SpellsFile is the data file spells are loaded from.
+const SpellsFile = "spells.json"
+
+// Spell is a spell definition.
+type Spell struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+	// School is lowercase, e.g. "evocation".
+	School      string `json:"school"`
+	CastingTime string `json:"casting_time"`
+	Range       string `json:"range"`
+	// Area is the area of effect of spells whose range doesn't describe
+	// it, like Fireball's sphere 150 feet away.
+	Area          *SpellArea `json:"area,omitempty"`
+	Components    string     `json:"components"`
+	Material      string     `json:"material,omitempty"`
+	Duration      string     `json:"duration"`
+	Concentration bool       `json:"concentration,omitempty"`
+	Ritual        bool       `json:"ritual,omitempty"`
+	Classes       []string   `json:"classes"`
+	Description   string     `json:"description"`
+}
+
+// IsCantrip reports whether the spell is a cantrip.
+func (s Spell) IsCantrip() bool {
+	return s.Level == 0
+}
+
+// ForClass reports whether the spell is on the class's spell list.
+func (s Spell) ForClass(class string) bool {
+	return slices.ContainsFunc(s.Classes, func(c string) bool {
+		return strings.EqualFold(c, class)
+	})
+}
+
+// AreaOfEffect returns the spell's area, from Area or from a range like
+// "Self (15-foot cone)".
+func (s Spell) AreaOfEffect() (SpellArea, bool) {
+	if s.Area != nil {
+		return *s.Area, true
+	}
+	return ParseArea(s.Range)
+}
+
+// DamageTypes returns the damage types the spell's description mentions.
+func (s Spell) DamageTypes() []rules.DamageType {
+	return rules.FindDamageTypes(s.Description)
+}
+
+// LoadSpells reads every spell from the data directories.
+func (o *Overlay) LoadSpells() ([]Spell, error) {
+	var spells []Spell
+	if err := o.Load(SpellsFile, &spells); err != nil {
+		return nil, err
+	}
+	return spells, nil
+}
+
+// FindSpell returns the named spell.
+func FindSpell(spells []Spell, name string) (Spell, bool) {
+	for _, s := range spells {
+		if strings.EqualFold(s.Name, name) {
+			return s, true
+		}
+	}
+	return Spell{}, false
+}
+
+// Casting times as they appear in spell data.
+const (
+	CastAction      = "1 action"
+	CastBonusAction = "1 bonus action"
+	CastReaction    = "1 reaction"
+)
+
+// SpellFilter narrows a spell list. Zero fields don't filter.
+type SpellFilter struct {
+	// Query matches the name, or the description as well when FullText is
+	// set.
+	Query    string
+	FullText bool
+	Class    string
+	// LevelSet enables the MinLevel to MaxLevel bound, inclusive.
+	LevelSet           bool
+	MinLevel, MaxLevel int
+	School             string
+	RitualOnly         bool
+	// Concentration is nil for either, or must match.
+	Concentration *bool
+	DamageType    rules.DamageType
+	// CastingTime matches the start of the casting time, e.g. "1 bonus
+	// action".
+	CastingTime string
+}
+
+// Matches reports whether s passes the filter.
+func (f SpellFilter) Matches(s Spell) bool {
+	if q := strings.ToLower(strings.TrimSpace(f.Query)); q != "" {
+		found := strings.Contains(strings.ToLower(s.Name), q)
+		if !found && f.FullText {
+			found = strings.Contains(strings.ToLower(s.Description), q)
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Class != "" && !s.ForClass(f.Class) {
+		return false
+	}
+	if f.LevelSet && (s.Level < f.MinLevel || s.Level > f.MaxLevel) {
+		return false
+	}
+	if f.School != "" && !strings.EqualFold(s.School, f.School) {
+		return false
+	}
+	if f.RitualOnly && !s.Ritual {
+		return false
+	}
+	if f.Concentration != nil && s.Concentration != *f.Concentration {
+		return false
+	}
+	if f.DamageType != "" && !slices.Contains(s.DamageTypes(), f.DamageType) {
+		return false
+	}
+	if f.CastingTime != "" && !strings.HasPrefix(strings.ToLower(s.CastingTime), strings.ToLower(f.CastingTime)) {
+		return false
+	}
+	return true
+}
+
+// FilterSpells returns the spells that pass f, ordered by level and then
+// name.
+func FilterSpells(spells []Spell, f SpellFilter) []Spell {
+	var out []Spell
+	for _, s := range spells {
+		if f.Matches(s) {
+			out = append(out, s)
+		}
+	}
+	slices.SortFunc(out, func(a, b Spell) int {
+		return cmp.Or(cmp.Compare(a.Level, b.Level), strings.Compare(a.Name, b.Name))
+	})
+	return out
+}
+
+// SpellSchools lists the schools of magic in the order the filter cycles
+// through them.
+var SpellSchools = []string{
+	"abjuration", "conjuration", "divination", "enchantment",
+	"evocation", "illusion", "necromancy", "transmutation",
+}
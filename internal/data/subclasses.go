@@ -0,0 +1,74 @@
+package data
+
+import (
+	"slices"
+	"strings"
+)
+
+// SubclassesFile is the data file subclasses are loaded from.
+const SubclassesFile = "subclasses.json"
+
+// SubclassSpells are spells a subclass always has prepared from a class
+// level on, like a Cleric domain's or a Paladin oath's.
+type SubclassSpells struct {
+	Level  int      `json:"level"`
+	Spells []string `json:"spells"`
+}
+
+// Subclass is a class's subclass, such as the Life Domain.
+type Subclass struct {
+	Name        string           `json:"name"`
+	Class       string           `json:"class"`
+	Description string           `json:"description,omitempty"`
+	Spells      []SubclassSpells `json:"spells,omitempty"`
+}
+
+// LoadSubclasses reads every subclass from the data directories.
+func (o *Overlay) LoadSubclasses() ([]Subclass, error) {
+	var subs []Subclass
+	if err := o.Load(SubclassesFile, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// FindSubclass returns the class's named subclass.
+func FindSubclass(subs []Subclass, class, name string) (Subclass, bool) {
+	for _, s := range subs {
+		if strings.EqualFold(s.Class, class) && strings.EqualFold(s.Name, name) {
+			return s, true
+		}
+	}
+	return Subclass{}, false
+}
+
+// SubclassesOf returns the class's subclasses, in data order.
+func SubclassesOf(subs []Subclass, class string) []Subclass {
+	return slices.DeleteFunc(slices.Clone(subs), func(s Subclass) bool {
+		return !strings.EqualFold(s.Class, class)
+	})
+}
+
+// SpellsGainedAt returns the always-prepared spells gained on reaching
+// class level, for the level-up flow.
+func (s Subclass) SpellsGainedAt(level int) []string {
+	var out []string
+	for _, g := range s.Spells {
+		if g.Level == level {
+			out = append(out, g.Spells...)
+		}
+	}
+	return out
+}
+
+// SpellsUpTo returns every always-prepared spell of a subclass at class
+// level, for characters created above 1st level.
+func (s Subclass) SpellsUpTo(level int) []string {
+	var out []string
+	for _, g := range s.Spells {
+		if g.Level <= level {
+			out = append(out, g.Spells...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSubclassSpells(t *testing.T) {
+	subs, err := NewOverlay("../../data").LoadSubclasses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	life, ok := FindSubclass(subs, "cleric", "life domain")
+	if !ok {
+		t.Fatal("no Life Domain in subclasses.json")
+	}
+	tests := []struct {
+		level      int
+		gained, to []string
+	}{
+		{1, []string{"Bless", "Cure Wounds"}, []string{"Bless", "Cure Wounds"}},
+		{2, nil, []string{"Bless", "Cure Wounds"}},
+		{3, []string{"Lesser Restoration", "Spiritual Weapon"}, []string{"Bless", "Cure Wounds", "Lesser Restoration", "Spiritual Weapon"}},
+	}
+	for _, tt := range tests {
+		if got := life.SpellsGainedAt(tt.level); !slices.Equal(got, tt.gained) {
+			t.Errorf("SpellsGainedAt(%d) = %v, want %v", tt.level, got, tt.gained)
+		}
+		if got := life.SpellsUpTo(tt.level); !slices.Equal(got, tt.to) {
+			t.Errorf("SpellsUpTo(%d) = %v, want %v", tt.level, got, tt.to)
+		}
+	}
+	if _, ok := FindSubclass(subs, "Wizard", "Life Domain"); ok {
+		t.Error("FindSubclass matched another class's subclass")
+	}
+	if got := SubclassesOf(subs, "Paladin"); len(got) != 1 || got[0].Name != "Oath of Devotion" {
+		t.Errorf("SubclassesOf(Paladin) = %v", got)
+	}
+}
@@ -0,0 +1,49 @@
+package data
+
+import (
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// ToolsFile is the data file tools are loaded from.
+const ToolsFile = "tools.json"
+
+// ToolUse is a typical check made with a tool, with the ability it uses
+// and a suggested DC.
+type ToolUse struct {
+	Name    string        `json:"name"`
+	Ability rules.Ability `json:"ability"`
+	DC      int           `json:"dc,omitempty"`
+}
+
+// Tool is a tool, kit, gaming set or instrument a character can be
+// proficient with.
+type Tool struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// Ability is the ability checks with the tool use unless a use says
+	// otherwise.
+	Ability     rules.Ability `json:"ability"`
+	Description string        `json:"description"`
+	Uses        []ToolUse     `json:"uses,omitempty"`
+}
+
+// LoadTools reads every tool from the data directories.
+func (o *Overlay) LoadTools() ([]Tool, error) {
+	var tools []Tool
+	if err := o.Load(ToolsFile, &tools); err != nil {
+		return nil, err
+	}
+	return tools, nil
+}
+
+// FindTool returns the named tool.
+func FindTool(tools []Tool, name string) (Tool, bool) {
+	for _, t := range tools {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return Tool{}, false
+}
@@ -0,0 +1,416 @@
+// Package dice parses and rolls standard dice notation ("4d6kh3+2",
+// "2d20kl1", "1d8+1d6+3") and reports a per-die breakdown of every roll.
+package dice
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxExplosions bounds how many extra dice a single exploding die can add.
+const maxExplosions = 100
+
+// Mode is whether a d20 roll has advantage or disadvantage.
+type Mode int
+
+const (
+	Normal Mode = iota
+	Advantage
+	Disadvantage
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Advantage:
+		return "advantage"
+	case Disadvantage:
+		return "disadvantage"
+	}
+	return "normal"
+}
+
+// Combine applies the 5e rule that any advantage and any disadvantage cancel
+// out, however many sources of each there are.
+func Combine(advantage, disadvantage bool) Mode {
+	switch {
+	case advantage && !disadvantage:
+		return Advantage
+	case disadvantage && !advantage:
+		return Disadvantage
+	}
+	return Normal
+}
+
+// Expr is a parsed dice expression.
+type Expr struct {
+	terms []term
+}
+
+// D20 returns a d20 roll in the given mode plus a flat modifier.
+func D20(mode Mode, modifier int) Expr {
+	return D20Extra(mode, 0, modifier)
+}
+
+// D20Extra is D20 with extra d20s rolled when the roll has advantage, such
+// as Elven Accuracy's third die. The highest die is still the one kept.
+func D20Extra(mode Mode, extra, modifier int) Expr {
+	t := term{count: 1, sides: 20}
+	switch mode {
+	case Advantage:
+		t.count, t.keep = 2+max(extra, 0), &keepRule{highest: true, n: 1}
+	case Disadvantage:
+		t.count, t.keep = 2, &keepRule{n: 1}
+	}
+	return Expr{terms: []term{t}}.Plus(modifier)
+}
+
+// Plus returns e with a flat modifier added. A zero modifier is omitted.
+func (e Expr) Plus(modifier int) Expr {
+	if modifier == 0 {
+		return e
+	}
+	terms := append(e.terms[:len(e.terms):len(e.terms)], term{
+		negative: modifier < 0,
+		constant: abs(modifier),
+	})
+	return Expr{terms: terms}
+}
+
+// Add returns the sum of e and o, e.g. a spell's base damage plus its
+// upcast dice.
+func (e Expr) Add(o Expr) Expr {
+	terms := append(e.terms[:len(e.terms):len(e.terms)], o.terms...)
+	return Expr{terms: terms}
+}
+
+// Labeled returns e with every term attributed to label, so the breakdown
+// can say which effect contributed which dice.
+func (e Expr) Labeled(label string) Expr {
+	terms := make([]term, len(e.terms))
+	for i, t := range e.terms {
+		t.label = label
+		terms[i] = t
+	}
+	return Expr{terms: terms}
+}
+
+// Dice returns the dice terms of e without its flat modifiers, e.g. the
+// "2d6" of "2d6+3", for the extra dice of a critical hit.
+func (e Expr) Dice() Expr {
+	var terms []term
+	for _, t := range e.terms {
+		if t.isDice() {
+			terms = append(terms, t)
+		}
+	}
+	return Expr{terms: terms}
+}
+
+// RerollOnce returns e with the character's own dice of the given sides
+// (any size when sides is 0) rerolled once on faces at or below upTo, for
+// features like Halfling Luck and Great Weapon Fighting. Dice added by
+// effects, and dice that already have a reroll rule, are left alone.
+// source is shown next to rerolled dice in the breakdown.
+func (e Expr) RerollOnce(sides, upTo int, source string) Expr {
+	c := compare{op: '<', n: upTo}
+	if upTo == 1 {
+		c.op = '='
+	}
+	terms := make([]term, len(e.terms))
+	for i, t := range e.terms {
+		if t.isDice() && t.label == "" && t.reroll == nil && (sides == 0 || t.sides == sides) && !c.matchesAll(t.sides) {
+			t.reroll, t.rerollOnce, t.rerollSource = &c, true, source
+		}
+		terms[i] = t
+	}
+	return Expr{terms: terms}
+}
+
+// IsZero reports whether e is the empty expression.
+func (e Expr) IsZero() bool {
+	return len(e.terms) == 0
+}
+
+// String returns e in canonical notation.
+func (e Expr) String() string {
+	var b strings.Builder
+	for i, t := range e.terms {
+		switch {
+		case t.negative:
+			b.WriteString("-")
+		case i > 0:
+			b.WriteString("+")
+		}
+		b.WriteString(t.String())
+	}
+	return b.String()
+}
+
+// Min returns the lowest total e can roll, ignoring reroll rules.
+func (e Expr) Min() int {
+	return e.bound(false)
+}
+
+// Max returns the highest total e can roll, ignoring explosions.
+func (e Expr) Max() int {
+	return e.bound(true)
+}
+
+func (e Expr) bound(high bool) int {
+	total := 0
+	for _, t := range e.terms {
+		lo, hi := t.constant, t.constant
+		if t.isDice() {
+			n := keptCount(t)
+			lo, hi = n, n*t.sides
+		}
+		if t.negative {
+			lo, hi = -hi, -lo
+		}
+		if high {
+			total += hi
+		} else {
+			total += lo
+		}
+	}
+	return total
+}
+
+func keptCount(t term) int {
+	if t.keep == nil {
+		return t.count
+	}
+	if t.keep.drop {
+		return max(t.count-t.keep.n, 0)
+	}
+	return min(t.keep.n, t.count)
+}
+
+// Roller rolls dice. The zero value uses the global random source.
+type Roller struct {
+	rng *rand.Rand
+}
+
+// NewRoller returns a roller with a deterministic seed, for replays and
+// reproducible bug reports.
+func NewRoller(seed uint64) *Roller {
+	return &Roller{rng: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// Default is the roller used by the package-level helpers.
+var Default = &Roller{}
+
+func (r *Roller) face(sides int) int {
+	if r == nil || r.rng == nil {
+		return rand.IntN(sides) + 1
+	}
+	return r.rng.IntN(sides) + 1
+}
+
+// Roll parses notation and rolls it with the default roller.
+func Roll(notation string) (Result, error) {
+	e, err := Parse(notation)
+	if err != nil {
+		return Result{}, err
+	}
+	return Default.Roll(e), nil
+}
+
+// Roll rolls every term of e.
+func (r *Roller) Roll(e Expr) Result {
+	res := Result{Notation: e.String()}
+	for _, t := range e.terms {
+		tr := r.rollTerm(t)
+		res.Terms = append(res.Terms, tr)
+		res.Total += tr.Value
+	}
+	return res
+}
+
+func (r *Roller) rollTerm(t term) TermResult {
+	tr := TermResult{Notation: t.String(), Negative: t.negative, Label: t.label, RerollSource: t.rerollSource}
+	if !t.isDice() {
+		tr.Value = t.constant
+	} else {
+		for range t.count {
+			d := r.rollDie(t)
+			tr.Dice = append(tr.Dice, d)
+			for n := 0; t.explode != nil && t.explode.match(d.Value) && n < maxExplosions; n++ {
+				tr.Dice[len(tr.Dice)-1].Exploded = true
+				d = r.rollDie(t)
+				tr.Dice = append(tr.Dice, d)
+			}
+		}
+		applyKeep(tr.Dice, t.keep)
+		for _, d := range tr.Dice {
+			if !d.Dropped {
+				tr.Value += d.Value
+			}
+		}
+	}
+	if t.negative {
+		tr.Value = -tr.Value
+	}
+	return tr
+}
+
+func (r *Roller) rollDie(t term) Die {
+	v := r.face(t.sides)
+	d := Die{Sides: t.sides, Value: v, Rolls: []int{v}}
+	if t.reroll == nil {
+		return d
+	}
+	for t.reroll.match(d.Value) {
+		d.Value = r.face(t.sides)
+		d.Rolls = append(d.Rolls, d.Value)
+		if t.rerollOnce {
+			break
+		}
+	}
+	return d
+}
+
+// applyKeep marks the dice a keep or drop rule discards.
+func applyKeep(dice []Die, k *keepRule) {
+	if k == nil {
+		return
+	}
+	order := make([]int, len(dice))
+	for i := range order {
+		order[i] = i
+	}
+	// Keep highest and drop lowest both keep the top of a descending sort;
+	// the other two keep the top of an ascending one. The sort is stable so
+	// that among equal faces the earliest die is kept.
+	highFirst := k.highest != k.drop
+	sort.SliceStable(order, func(a, b int) bool {
+		if highFirst {
+			return dice[order[a]].Value > dice[order[b]].Value
+		}
+		return dice[order[a]].Value < dice[order[b]].Value
+	})
+
+	kept := keptCount(term{count: len(dice), keep: k})
+	for _, i := range order[kept:] {
+		dice[i].Dropped = true
+	}
+}
+
+// Die is a single die in a roll.
+type Die struct {
+	Sides int
+	// Value is the final face after any rerolls.
+	Value int
+	// Rolls lists every face rolled for this die, including rerolled ones.
+	Rolls []int
+	// Dropped is set when a keep or drop rule discarded the die.
+	Dropped bool
+	// Exploded is set when the die triggered an extra die.
+	Exploded bool
+}
+
+// Rerolled reports whether the die was rerolled.
+func (d Die) Rerolled() bool {
+	return len(d.Rolls) > 1
+}
+
+func (d Die) String() string {
+	parts := make([]string, len(d.Rolls))
+	for i, v := range d.Rolls {
+		parts[i] = strconv.Itoa(v)
+	}
+	s := strings.Join(parts, "->")
+	if d.Exploded {
+		s += "!"
+	}
+	if d.Dropped {
+		s = "(" + s + ")"
+	}
+	return s
+}
+
+// TermResult is the outcome of one term of an expression.
+type TermResult struct {
+	Notation string
+	Negative bool
+	// Label is the source of the term, if it was added by an effect.
+	Label string
+	// RerollSource is the feature whose reroll rule the term's dice follow.
+	RerollSource string
+	// Dice is empty for constant terms.
+	Dice []Die
+	// Value is the term's signed contribution to the total.
+	Value int
+}
+
+func (t TermResult) String() string {
+	s := strconv.Itoa(abs(t.Value))
+	if len(t.Dice) > 0 {
+		parts := make([]string, len(t.Dice))
+		for i, d := range t.Dice {
+			parts[i] = d.String()
+		}
+		s = fmt.Sprintf("%s [%s]", t.Notation, strings.Join(parts, ", "))
+	}
+	if t.Label != "" {
+		s += " (" + t.Label + ")"
+	}
+	if t.RerollSource != "" && slices.ContainsFunc(t.Dice, Die.Rerolled) {
+		s += " (" + t.RerollSource + ")"
+	}
+	return s
+}
+
+// Result is the outcome of rolling an expression.
+type Result struct {
+	Notation string
+	Terms    []TermResult
+	Total    int
+}
+
+// Natural returns the kept face of the first d20 in the roll, or 0 if there
+// is none. It is what crits, fumbles and death saves look at.
+func (r Result) Natural() int {
+	for _, t := range r.Terms {
+		for _, d := range t.Dice {
+			if d.Sides == 20 && !d.Dropped {
+				return d.Value
+			}
+		}
+	}
+	return 0
+}
+
+// Breakdown returns the per-term detail, e.g. "4d6kh3 [6, 5, (2), 4] + 2".
+func (r Result) Breakdown() string {
+	var b strings.Builder
+	for i, t := range r.Terms {
+		switch {
+		case t.Negative:
+			if i > 0 {
+				b.WriteString(" - ")
+			} else {
+				b.WriteString("-")
+			}
+		case i > 0:
+			b.WriteString(" + ")
+		}
+		b.WriteString(t.String())
+	}
+	return b.String()
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s = %d", r.Breakdown(), r.Total)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,165 @@
+package dice
+
+import (
+	"slices"
+	"testing"
+)
+
+// rolls is how many times each property is checked against a seeded
+// roller.
+const rolls = 500
+
+func TestRollDeterministic(t *testing.T) {
+	e := MustParse("4d6kh3+1d8!+2")
+	a, b := NewRoller(42), NewRoller(42)
+	for range 20 {
+		x, y := a.Roll(e), b.Roll(e)
+		if x.String() != y.String() {
+			t.Fatalf("same seed rolled %q and %q", x, y)
+		}
+	}
+}
+
+func TestRollTotals(t *testing.T) {
+	r := NewRoller(1)
+	for _, notation := range []string{"2d6+3", "1d8+1d6-2", "-1d4", "4d6kh3", "4d6dl1", "2d20kl1", "10"} {
+		e := MustParse(notation)
+		for range rolls {
+			res := r.Roll(e)
+			if res.Total < e.Min() || res.Total > e.Max() {
+				t.Fatalf("%s rolled %d, outside %d–%d", notation, res.Total, e.Min(), e.Max())
+			}
+			sum := 0
+			for _, term := range res.Terms {
+				sum += term.Value
+			}
+			if sum != res.Total {
+				t.Fatalf("%s: terms sum to %d, total is %d", notation, sum, res.Total)
+			}
+		}
+	}
+}
+
+func TestRollKeep(t *testing.T) {
+	tests := []struct {
+		notation string
+		kept     int
+		highest  bool
+	}{
+		{"4d6kh3", 3, true},
+		{"4d6dl1", 3, true},
+		{"2d20kl1", 1, false},
+		{"4d6dh1", 3, false},
+		{"4d6k0", 0, true},
+		{"2d6kh5", 2, true},
+	}
+	r := NewRoller(2)
+	for _, tt := range tests {
+		e := MustParse(tt.notation)
+		for range rolls {
+			dice := r.Roll(e).Terms[0].Dice
+			var kept, dropped []int
+			for _, d := range dice {
+				if d.Dropped {
+					dropped = append(dropped, d.Value)
+				} else {
+					kept = append(kept, d.Value)
+				}
+			}
+			if len(kept) != tt.kept {
+				t.Fatalf("%s kept %d dice, want %d", tt.notation, len(kept), tt.kept)
+			}
+			if len(kept) == 0 || len(dropped) == 0 {
+				continue
+			}
+			if tt.highest && slices.Min(kept) < slices.Max(dropped) {
+				t.Fatalf("%s kept %v over %v", tt.notation, kept, dropped)
+			}
+			if !tt.highest && slices.Max(kept) > slices.Min(dropped) {
+				t.Fatalf("%s kept %v over %v", tt.notation, kept, dropped)
+			}
+		}
+	}
+}
+
+func TestRollReroll(t *testing.T) {
+	r := NewRoller(3)
+	for range rolls {
+		for _, d := range r.Roll(MustParse("4d6r1")).Terms[0].Dice {
+			if d.Value == 1 {
+				t.Fatalf("r1 kept a 1: %v", d.Rolls)
+			}
+		}
+		for _, d := range r.Roll(MustParse("4d6ro<2")).Terms[0].Dice {
+			if len(d.Rolls) > 2 {
+				t.Fatalf("ro<2 rerolled more than once: %v", d.Rolls)
+			}
+			if d.Rerolled() && d.Rolls[0] > 2 {
+				t.Fatalf("ro<2 rerolled a %d", d.Rolls[0])
+			}
+		}
+	}
+}
+
+func TestRollExplode(t *testing.T) {
+	r := NewRoller(4)
+	exploded := false
+	for range rolls {
+		dice := r.Roll(MustParse("3d6!")).Terms[0].Dice
+		for i, d := range dice {
+			if d.Exploded != (d.Value == 6) {
+				t.Fatalf("die %d of %v: exploded %v on a %d", i, dice, d.Exploded, d.Value)
+			}
+			exploded = exploded || d.Exploded
+		}
+		if last := dice[len(dice)-1]; last.Exploded {
+			t.Fatalf("last die of %v exploded without a follow-up", dice)
+		}
+	}
+	if !exploded {
+		t.Fatalf("no die exploded in %d rolls", rolls)
+	}
+}
+
+func TestD20(t *testing.T) {
+	tests := []struct {
+		mode     Mode
+		extra    int
+		modifier int
+		want     string
+	}{
+		{Normal, 0, 0, "1d20"},
+		{Normal, 1, 5, "1d20+5"},
+		{Advantage, 0, 3, "2d20kh1+3"},
+		{Advantage, 1, -1, "3d20kh1-1"},
+		{Disadvantage, 1, 0, "2d20kl1"},
+	}
+	for _, tt := range tests {
+		if got := D20Extra(tt.mode, tt.extra, tt.modifier).String(); got != tt.want {
+			t.Errorf("D20Extra(%v, %d, %d) = %q, want %q", tt.mode, tt.extra, tt.modifier, got, tt.want)
+		}
+	}
+}
+
+func TestNatural(t *testing.T) {
+	r := NewRoller(5)
+	for range rolls {
+		res := r.Roll(D20(Advantage, 4))
+		if got := res.Natural(); got != res.Total-4 {
+			t.Fatalf("Natural() = %d, total %d with +4", got, res.Total)
+		}
+	}
+	if got := r.Roll(MustParse("2d6")).Natural(); got != 0 {
+		t.Errorf("Natural() of 2d6 = %d, want 0", got)
+	}
+}
+
+func TestRerollOnce(t *testing.T) {
+	e := MustParse("2d6+1d8").Add(MustParse("1d6").Labeled("Hex")).RerollOnce(6, 2, "Great Weapon Fighting")
+	if got, want := e.String(), "2d6ro<2+1d8+1d6"; got != want {
+		t.Errorf("RerollOnce = %q, want %q", got, want)
+	}
+	if got, want := MustParse("1d20").RerollOnce(20, 1, "Halfling Luck").String(), "1d20ro1"; got != want {
+		t.Errorf("RerollOnce = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,355 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxCount = 1000
+	maxSides = 1000
+)
+
+// compare is a face condition such as "1", "<2" or ">19". Like most online
+// rollers, < and > are inclusive.
+type compare struct {
+	op byte // '=', '<' or '>'
+	n  int
+}
+
+func (c compare) match(v int) bool {
+	switch c.op {
+	case '<':
+		return v <= c.n
+	case '>':
+		return v >= c.n
+	}
+	return v == c.n
+}
+
+func (c compare) String() string {
+	if c.op == '=' {
+		return strconv.Itoa(c.n)
+	}
+	return string(c.op) + strconv.Itoa(c.n)
+}
+
+// matchesAll reports whether every face of a die with the given sides
+// satisfies c.
+func (c compare) matchesAll(sides int) bool {
+	for v := 1; v <= sides; v++ {
+		if !c.match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// keepRule keeps or drops the highest or lowest n dice of a term.
+type keepRule struct {
+	drop    bool
+	highest bool
+	n       int
+}
+
+func (k keepRule) String() string {
+	s := "k"
+	if k.drop {
+		s = "d"
+	}
+	if k.highest {
+		s += "h"
+	} else {
+		s += "l"
+	}
+	return s + strconv.Itoa(k.n)
+}
+
+// term is one signed part of an expression: either a constant or a group of
+// identical dice with modifiers.
+type term struct {
+	negative bool
+	constant int
+
+	count int
+	sides int // 0 for constants
+
+	keep       *keepRule
+	reroll     *compare
+	rerollOnce bool
+	explode    *compare
+
+	// label names where the term came from, e.g. "Guidance".
+	label string
+	// rerollSource names the feature that added the reroll rule, e.g.
+	// "Halfling Luck".
+	rerollSource string
+}
+
+func (t term) isDice() bool {
+	return t.sides > 0
+}
+
+func (t term) String() string {
+	if !t.isDice() {
+		return strconv.Itoa(t.constant)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%dd%d", t.count, t.sides)
+	if t.reroll != nil {
+		b.WriteString("r")
+		if t.rerollOnce {
+			b.WriteString("o")
+		}
+		b.WriteString(t.reroll.String())
+	}
+	if t.explode != nil {
+		b.WriteString("!")
+		if !(t.explode.op == '>' && t.explode.n == t.sides) {
+			b.WriteString(t.explode.String())
+		}
+	}
+	if t.keep != nil {
+		b.WriteString(t.keep.String())
+	}
+	return b.String()
+}
+
+// Parse parses standard dice notation. Supported forms:
+//
+//	2d6+3        dice and constants joined by + or -
+//	d20, d%      count defaults to 1; d% is d100
+//	4d6kh3 4d6k3 keep highest n (kl keeps lowest)
+//	2d20kl1      disadvantage
+//	4d6dl1       drop lowest n (dh drops highest)
+//	2d6r1        reroll 1s until they stop coming up
+//	2d6ro<2      reroll 1s and 2s once, keeping the new roll
+//	3d6!         exploding dice: roll again on max (or !>5 etc.)
+//
+// Whitespace and case are ignored.
+func Parse(notation string) (Expr, error) {
+	p := parser{src: strings.ToLower(strings.Join(strings.Fields(notation), ""))}
+	if p.src == "" {
+		return Expr{}, fmt.Errorf("empty dice expression")
+	}
+
+	var terms []term
+	negative := false
+	if p.peek() == '+' || p.peek() == '-' {
+		negative = p.next() == '-'
+	}
+	for {
+		t, err := p.term()
+		if err != nil {
+			return Expr{}, fmt.Errorf("invalid dice expression %q: %w", notation, err)
+		}
+		t.negative = negative
+		terms = append(terms, t)
+
+		if p.done() {
+			break
+		}
+		switch c := p.next(); c {
+		case '+':
+			negative = false
+		case '-':
+			negative = true
+		default:
+			return Expr{}, fmt.Errorf("invalid dice expression %q: unexpected %q", notation, c)
+		}
+	}
+	return Expr{terms: terms}, nil
+}
+
+// MustParse is like Parse but panics on error. It is meant for notation
+// that is fixed at compile time.
+func MustParse(notation string) Expr {
+	e, err := Parse(notation)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) next() byte {
+	c := p.peek()
+	p.pos++
+	return c
+}
+
+// accept consumes s if the input continues with it.
+func (p *parser) accept(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// number reads a decimal number; ok is false if there are no digits.
+func (p *parser) number() (n int, ok bool, err error) {
+	start := p.pos
+	for !p.done() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, false, nil
+	}
+	n, err = strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		return 0, false, fmt.Errorf("number %q out of range", p.src[start:p.pos])
+	}
+	return n, true, nil
+}
+
+func (p *parser) term() (term, error) {
+	count, hasCount, err := p.number()
+	if err != nil {
+		return term{}, err
+	}
+	if !p.accept("d") {
+		if !hasCount {
+			return term{}, fmt.Errorf("expected number or dice at position %d", p.pos+1)
+		}
+		return term{constant: count}, nil
+	}
+	if !hasCount {
+		count = 1
+	}
+
+	t := term{count: count}
+	if p.accept("%") {
+		t.sides = 100
+	} else {
+		sides, ok, err := p.number()
+		if err != nil {
+			return term{}, err
+		}
+		if !ok {
+			return term{}, fmt.Errorf("missing die size at position %d", p.pos+1)
+		}
+		t.sides = sides
+	}
+
+	switch {
+	case t.count < 1 || t.count > maxCount:
+		return term{}, fmt.Errorf("dice count must be between 1 and %d", maxCount)
+	case t.sides < 1 || t.sides > maxSides:
+		return term{}, fmt.Errorf("die size must be between 1 and %d", maxSides)
+	}
+
+	if err := p.modifiers(&t); err != nil {
+		return term{}, err
+	}
+	return t, nil
+}
+
+func (p *parser) modifiers(t *term) error {
+	for !p.done() {
+		switch {
+		case p.accept("kl"):
+			if err := p.keep(t, keepRule{}); err != nil {
+				return err
+			}
+		case p.accept("kh"), p.accept("k"):
+			if err := p.keep(t, keepRule{highest: true}); err != nil {
+				return err
+			}
+		case p.accept("dh"):
+			if err := p.keep(t, keepRule{drop: true, highest: true}); err != nil {
+				return err
+			}
+		case p.accept("dl"):
+			if err := p.keep(t, keepRule{drop: true}); err != nil {
+				return err
+			}
+		case p.accept("ro"), p.accept("r"):
+			if t.reroll != nil {
+				return fmt.Errorf("only one reroll rule is allowed per dice group")
+			}
+			t.rerollOnce = p.src[p.pos-1] == 'o'
+			c, ok, err := p.compare()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("reroll needs a face, e.g. r1 or r<2")
+			}
+			if !t.rerollOnce && c.matchesAll(t.sides) {
+				return fmt.Errorf("reroll %s on d%d would never stop", c, t.sides)
+			}
+			t.reroll = &c
+		case p.accept("!"):
+			if t.explode != nil {
+				return fmt.Errorf("only one explode rule is allowed per dice group")
+			}
+			c, ok, err := p.compare()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				c = compare{op: '>', n: t.sides}
+			}
+			if c.matchesAll(t.sides) {
+				return fmt.Errorf("explode %s on d%d would never stop", c, t.sides)
+			}
+			t.explode = &c
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (p *parser) keep(t *term, k keepRule) error {
+	if t.keep != nil {
+		return fmt.Errorf("only one keep or drop rule is allowed per dice group")
+	}
+	n, ok, err := p.number()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		n = 1
+	}
+	k.n = n
+	t.keep = &k
+	return nil
+}
+
+func (p *parser) compare() (compare, bool, error) {
+	c := compare{op: '='}
+	switch p.peek() {
+	case '<', '>', '=':
+		c.op = p.next()
+	}
+	n, ok, err := p.number()
+	if err != nil {
+		return compare{}, false, err
+	}
+	if !ok {
+		if c.op != '=' {
+			return compare{}, false, fmt.Errorf("missing number after %q", c.op)
+		}
+		return compare{}, false, nil
+	}
+	c.n = n
+	return c, true, nil
+}
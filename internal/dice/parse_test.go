@@ -0,0 +1,89 @@
+package dice
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		notation string
+		want     string
+		min, max int
+	}{
+		{"2d6+3", "2d6+3", 5, 15},
+		{"d20", "1d20", 1, 20},
+		{"d%", "1d100", 1, 100},
+		{" 1D8 + 1d6 - 2 ", "1d8+1d6-2", 0, 12},
+		{"-1d4", "-1d4", -4, -1},
+		{"4d6kh3", "4d6kh3", 3, 18},
+		{"4d6k3", "4d6kh3", 3, 18},
+		{"2d20kl1", "2d20kl1", 1, 20},
+		{"4d6dl1", "4d6dl1", 3, 18},
+		{"4d6dh1", "4d6dh1", 3, 18},
+		{"4d6k", "4d6kh1", 1, 6},
+		{"4d6k0", "4d6kh0", 0, 0},
+		{"2d6r1", "2d6r1", 2, 12},
+		{"2d6ro<2", "2d6ro<2", 2, 12},
+		{"3d6!", "3d6!", 3, 18},
+		{"3d6!>5", "3d6!>5", 3, 18},
+		{"5", "5", 5, 5},
+	}
+	for _, tt := range tests {
+		e, err := Parse(tt.notation)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.notation, err)
+			continue
+		}
+		if got := e.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.notation, got, tt.want)
+		}
+		if got := e.Min(); got != tt.min {
+			t.Errorf("Parse(%q).Min() = %d, want %d", tt.notation, got, tt.min)
+		}
+		if got := e.Max(); got != tt.max {
+			t.Errorf("Parse(%q).Max() = %d, want %d", tt.notation, got, tt.max)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, notation := range []string{
+		"",
+		"   ",
+		"d",
+		"2d",
+		"0d6",
+		"1001d6",
+		"1d0",
+		"1d1001",
+		"2d6+",
+		"2d6*2",
+		"4d6kh3kl1",
+		"2d6r1r2",
+		"2d6r",
+		"1d6r<6",
+		"1d6!<6",
+		"2d6!!",
+		"2d6r<",
+		"99999999999999999999d6",
+	} {
+		if e, err := Parse(notation); err == nil {
+			t.Errorf("Parse(%q) = %q, want an error", notation, e)
+		}
+	}
+}
+
+func TestCombine(t *testing.T) {
+	tests := []struct {
+		adv, dis bool
+		want     Mode
+	}{
+		{false, false, Normal},
+		{true, false, Advantage},
+		{false, true, Disadvantage},
+		{true, true, Normal},
+	}
+	for _, tt := range tests {
+		if got := Combine(tt.adv, tt.dis); got != tt.want {
+			t.Errorf("Combine(%v, %v) = %v, want %v", tt.adv, tt.dis, got, tt.want)
+		}
+	}
+}
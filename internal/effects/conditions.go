@@ -0,0 +1,145 @@
+package effects
+
+import (
+	"slices"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/rules"
+)
+
+// strDexSaves auto-fails STR and DEX saves, for the conditions that leave
+// a creature helpless.
+var strDexSaves = Effect{Rolls: []RollType{SavingRoll}, Abilities: []rules.Ability{rules.Strength, rules.Dexterity}, AutoFail: true}
+
+// conditionEffects maps a condition name to the roll effects it imposes.
+var conditionEffects = map[string][]Effect{
+	"Blinded": {
+		{Rolls: []RollType{AttackRoll}, Disadvantage: true},
+	},
+	"Frightened": {
+		{Rolls: []RollType{AttackRoll, CheckRoll}, Disadvantage: true},
+	},
+	"Invisible": {
+		{Rolls: []RollType{AttackRoll}, Advantage: true},
+	},
+	"Paralyzed": {strDexSaves},
+	"Petrified": {strDexSaves},
+	"Poisoned": {
+		{Rolls: []RollType{AttackRoll, CheckRoll}, Disadvantage: true},
+	},
+	"Prone": {
+		{Rolls: []RollType{AttackRoll}, Disadvantage: true},
+	},
+	"Restrained": {
+		{Rolls: []RollType{SavingRoll}, Abilities: []rules.Ability{rules.Dexterity}, Disadvantage: true},
+		{Rolls: []RollType{AttackRoll}, Disadvantage: true},
+	},
+	"Stunned":     {strDexSaves},
+	"Unconscious": {strDexSaves},
+}
+
+// conditionName returns the canonical spelling of a condition, so
+// "poisoned" finds "Poisoned".
+func conditionName(c string) string {
+	c = strings.TrimSpace(c)
+	if c == "" {
+		return c
+	}
+	return strings.ToUpper(c[:1]) + strings.ToLower(c[1:])
+}
+
+// ForConditions returns the roll effects imposed by a character's
+// conditions, each named after its condition. Conditions match in any
+// case, and one listed twice counts once.
+func ForConditions(conditions []string) []Effect {
+	var out []Effect
+	var seen []string
+	for _, c := range conditions {
+		c = conditionName(c)
+		if slices.Contains(seen, c) {
+			continue
+		}
+		seen = append(seen, c)
+		for _, e := range conditionEffects[c] {
+			e.Name = c
+			e.Source = "condition"
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// immobilizing are the conditions that reduce speed to 0.
+var immobilizing = []string{"Grappled", "Paralyzed", "Petrified", "Restrained", "Stunned", "Unconscious"}
+
+// ConditionSpeed applies conditions to a speed, returning the speed and
+// the conditions that changed it.
+func ConditionSpeed(speed int, conditions []string) (int, []string) {
+	var reasons []string
+	for _, c := range conditions {
+		if c = conditionName(c); slices.Contains(immobilizing, c) {
+			reasons = append(reasons, c)
+		}
+	}
+	if len(reasons) > 0 {
+		return 0, reasons
+	}
+	return speed, nil
+}
+
+// exposing are the conditions that give attackers advantage. Prone only
+// does so within 5 feet.
+var exposing = []string{"Blinded", "Paralyzed", "Petrified", "Prone", "Restrained", "Stunned", "Unconscious"}
+
+// AttackedWithAdvantage returns the conditions that give attack rolls
+// against the character advantage, for a note beside AC.
+func AttackedWithAdvantage(conditions []string) []string {
+	var out []string
+	for _, c := range conditions {
+		if c = conditionName(c); slices.Contains(exposing, c) && !slices.Contains(out, c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ForExhaustion returns the roll effects of an exhaustion level:
+// disadvantage on checks from level 1, and on attacks and saves from
+// level 3.
+func ForExhaustion(level int) []Effect {
+	var out []Effect
+	if level >= 1 {
+		out = append(out, Effect{Name: "Exhaustion", Source: "condition", Rolls: []RollType{CheckRoll}, Disadvantage: true})
+	}
+	if level >= 3 {
+		out = append(out, Effect{Name: "Exhaustion", Source: "condition", Rolls: []RollType{AttackRoll, SavingRoll}, Disadvantage: true})
+	}
+	return out
+}
+
+// Suggestion is what active effects imply for a roll before it is made, so
+// the view can pre-select advantage or warn about an automatic failure.
+type Suggestion struct {
+	Mode     dice.Mode
+	AutoFail bool
+	// Reasons names the effects behind the suggestion.
+	Reasons []string
+}
+
+// Suggest summarizes how active effects affect r.
+func Suggest(active []Effect, r Roll) Suggestion {
+	var s Suggestion
+	adv, dis := false, false
+	for _, e := range Applicable(active, r) {
+		if !e.Advantage && !e.Disadvantage && !e.AutoFail {
+			continue
+		}
+		adv = adv || e.Advantage
+		dis = dis || e.Disadvantage
+		s.AutoFail = s.AutoFail || e.AutoFail
+		s.Reasons = append(s.Reasons, e.Name)
+	}
+	s.Mode = dice.Combine(adv, dis)
+	return s
+}
@@ -0,0 +1,52 @@
+package effects
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestForConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		want       []string
+	}{
+		{"canonical", []string{"Poisoned"}, []string{"Poisoned"}},
+		{"lower case", []string{"poisoned"}, []string{"Poisoned"}},
+		{"upper case and spaces", []string{" RESTRAINED "}, []string{"Restrained", "Restrained"}},
+		{"listed twice", []string{"Prone", "prone"}, []string{"Prone"}},
+		{"no roll effects", []string{"Deafened", "grappled"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range ForConditions(tt.conditions) {
+				if e.Source != "condition" {
+					t.Errorf("%s has source %q", e.Name, e.Source)
+				}
+				got = append(got, e.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ForConditions(%q) names = %v, want %v", tt.conditions, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionSpeed(t *testing.T) {
+	tests := []struct {
+		conditions  []string
+		speed       int
+		wantReasons []string
+	}{
+		{[]string{"grappled"}, 0, []string{"Grappled"}},
+		{[]string{"Poisoned"}, 30, nil},
+		{nil, 30, nil},
+	}
+	for _, tt := range tests {
+		speed, reasons := ConditionSpeed(30, tt.conditions)
+		if speed != tt.speed || !slices.Equal(reasons, tt.wantReasons) {
+			t.Errorf("ConditionSpeed(30, %q) = %d, %v; want %d, %v", tt.conditions, speed, reasons, tt.speed, tt.wantReasons)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Package effects models active effects on a character (spells like
+// Guidance and Bless, features, items) and how they change rolls.
+package effects
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/rules"
+)
+
+// RollType is the kind of roll an effect can modify.
+type RollType string
+
+const (
+	AttackRoll RollType = "attack"
+	SavingRoll RollType = "save"
+	CheckRoll  RollType = "check"
+	// DamageRoll is weapon or spell damage. Only reroll rules apply to it.
+	DamageRoll RollType = "damage"
+)
+
+// Roll describes a roll about to be made, so effects can decide whether
+// they apply.
+type Roll struct {
+	Type    RollType
+	Ability rules.Ability
+	// Skill is set for skill checks.
+	Skill string
+	// Properties are the weapon's properties, for attack and damage rolls.
+	Properties []string
+	// Melee is set for melee attacks and their damage.
+	Melee bool
+}
+
+// Effect is an active effect. The zero value of each restriction field
+// means "no restriction".
+type Effect struct {
+	Name   string `json:"name"`
+	Source string `json:"source,omitempty"`
+
+	// Rolls limits the effect to these roll types.
+	Rolls []RollType `json:"rolls,omitempty"`
+	// Abilities limits the effect to rolls using these abilities.
+	Abilities []rules.Ability `json:"abilities,omitempty"`
+	// Skills limits the effect to checks with these skills.
+	Skills []string `json:"skills,omitempty"`
+	// Properties limits the effect to weapons with any of these
+	// properties.
+	Properties []string `json:"properties,omitempty"`
+	// MeleeOnly limits the effect to melee attacks and their damage.
+	MeleeOnly bool `json:"melee_only,omitempty"`
+
+	// Dice is added to the roll, e.g. "1d4" for Guidance. A leading minus
+	// subtracts it.
+	Dice string `json:"dice,omitempty"`
+	// Bonus is a flat amount added to the roll.
+	Bonus        int  `json:"bonus,omitempty"`
+	Advantage    bool `json:"advantage,omitempty"`
+	Disadvantage bool `json:"disadvantage,omitempty"`
+	// ExtraAdvantageDice are extra d20s rolled when the roll ends up with
+	// advantage, like Elven Accuracy's third die.
+	ExtraAdvantageDice int `json:"extra_advantage_dice,omitempty"`
+	// AutoFail means the roll fails whatever the dice show, e.g. STR and
+	// DEX saves while paralyzed.
+	AutoFail bool `json:"auto_fail,omitempty"`
+	// NoCasting means the character can't cast spells while the effect
+	// lasts.
+	NoCasting bool `json:"no_casting,omitempty"`
+	// Reroll rerolls the character's dice once when they land on this face
+	// or lower: the d20 of a d20 roll, or every damage die. The new roll
+	// must be used.
+	Reroll int `json:"reroll,omitempty"`
+
+	// SingleUse effects end after the first roll they modify.
+	SingleUse bool `json:"single_use,omitempty"`
+}
+
+// AppliesTo reports whether the effect modifies r.
+func (e Effect) AppliesTo(r Roll) bool {
+	if len(e.Rolls) > 0 && !slices.Contains(e.Rolls, r.Type) {
+		return false
+	}
+	if len(e.Abilities) > 0 && !slices.Contains(e.Abilities, r.Ability) {
+		return false
+	}
+	if len(e.Skills) > 0 && !slices.ContainsFunc(e.Skills, func(s string) bool {
+		return strings.EqualFold(s, r.Skill)
+	}) {
+		return false
+	}
+	if len(e.Properties) > 0 && !slices.ContainsFunc(e.Properties, func(p string) bool {
+		return slices.ContainsFunc(r.Properties, func(q string) bool { return strings.EqualFold(p, q) })
+	}) {
+		return false
+	}
+	if e.MeleeOnly && !r.Melee {
+		return false
+	}
+	return true
+}
+
+// Applicable returns the effects in active that modify r.
+func Applicable(active []Effect, r Roll) []Effect {
+	var out []Effect
+	for _, e := range active {
+		if e.AppliesTo(r) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// D20Roll builds the expression for a d20 roll: the d20 in the combined
+// advantage state, with any extra advantage dice and reroll rules, the
+// character's modifier, then each applicable effect's dice and bonus
+// labelled with the effect name. It also returns the effects that were
+// applied so single-use ones can be removed.
+func D20Roll(r Roll, modifier int, mode dice.Mode, active []Effect) (dice.Expr, []Effect, error) {
+	applied := Applicable(active, r)
+
+	adv, dis := mode == dice.Advantage, mode == dice.Disadvantage
+	extra := 0
+	for _, e := range applied {
+		adv = adv || e.Advantage
+		dis = dis || e.Disadvantage
+		extra = max(extra, e.ExtraAdvantageDice)
+	}
+
+	expr := dice.D20Extra(dice.Combine(adv, dis), extra, modifier)
+	for _, e := range applied {
+		if e.Reroll > 0 {
+			expr = expr.RerollOnce(20, e.Reroll, e.Name)
+		}
+	}
+	for _, e := range applied {
+		if e.Dice != "" {
+			d, err := dice.Parse(e.Dice)
+			if err != nil {
+				return dice.Expr{}, nil, fmt.Errorf("effect %s: %w", e.Name, err)
+			}
+			expr = expr.Add(d.Labeled(e.Name))
+		}
+		if e.Bonus != 0 {
+			expr = expr.Add(dice.Expr{}.Plus(e.Bonus).Labeled(e.Name))
+		}
+	}
+	return expr, applied, nil
+}
+
+// DamageRerolls applies the reroll rules of the effects that apply to r, a
+// damage roll, to expr's damage dice. Apply it before a crit doubles the
+// dice so the extra dice reroll too.
+func DamageRerolls(r Roll, expr dice.Expr, active []Effect) dice.Expr {
+	for _, e := range Applicable(active, r) {
+		if e.Reroll > 0 {
+			expr = expr.RerollOnce(0, e.Reroll, e.Name)
+		}
+	}
+	return expr
+}
+
+// CanCast reports whether the character can cast spells, and if not, the
+// effects preventing it.
+func CanCast(active []Effect) (bool, []string) {
+	var reasons []string
+	for _, e := range active {
+		if e.NoCasting {
+			reasons = append(reasons, e.Name)
+		}
+	}
+	return len(reasons) == 0, reasons
+}
+
+// Without returns active minus the named effects, for a roll the player
+// opts out of them on, like declining Guidance to keep it for a later
+// check.
+func Without(active []Effect, names ...string) []Effect {
+	if len(names) == 0 {
+		return active
+	}
+	out := make([]Effect, 0, len(active))
+	for _, e := range active {
+		if !slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, e.Name) }) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// DiceModifiers returns the applicable effects that add or subtract dice
+// from r, so the roll prompt can list them for opting out.
+func DiceModifiers(active []Effect, r Roll) []Effect {
+	var out []Effect
+	for _, e := range Applicable(active, r) {
+		if e.Dice != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// RemoveUsed drops single-use effects that were applied to a roll.
+func RemoveUsed(active, applied []Effect) []Effect {
+	out := active[:0:0]
+	for _, e := range active {
+		used := e.SingleUse && slices.ContainsFunc(applied, func(a Effect) bool {
+			return a.Name == e.Name && a.Source == e.Source
+		})
+		if !used {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Guidance returns the Guidance cantrip's effect: 1d4 on one ability check.
+func Guidance(source string) Effect {
+	return Effect{
+		Name:      "Guidance",
+		Source:    source,
+		Rolls:     []RollType{CheckRoll},
+		Dice:      "1d4",
+		SingleUse: true,
+	}
+}
+
+// Bless returns the Bless spell's effect: 1d4 added to attack rolls and
+// saving throws.
+func Bless(source string) Effect {
+	return Effect{
+		Name:   "Bless",
+		Source: source,
+		Rolls:  []RollType{AttackRoll, SavingRoll},
+		Dice:   "1d4",
+	}
+}
+
+// Bane returns the Bane spell's effect: 1d4 subtracted from attack rolls
+// and saving throws.
+func Bane(source string) Effect {
+	return Effect{
+		Name:   "Bane",
+		Source: source,
+		Rolls:  []RollType{AttackRoll, SavingRoll},
+		Dice:   "-1d4",
+	}
+}
+
+// HalflingLuck returns the halfling trait: a 1 on the d20 of an attack
+// roll, ability check or saving throw is rerolled.
+func HalflingLuck(source string) Effect {
+	return Effect{
+		Name:   "Halfling Luck",
+		Source: source,
+		Rolls:  []RollType{AttackRoll, SavingRoll, CheckRoll},
+		Reroll: 1,
+	}
+}
+
+// GreatWeaponFighting returns the fighting style: 1s and 2s on the damage
+// dice of a melee weapon wielded with two hands are rerolled. Callers
+// leave Versatile out of the roll's properties when such a weapon is held
+// in one hand.
+func GreatWeaponFighting(source string) Effect {
+	return Effect{
+		Name:       "Great Weapon Fighting",
+		Source:     source,
+		Rolls:      []RollType{DamageRoll},
+		Properties: []string{"Two-Handed", "Versatile"},
+		MeleeOnly:  true,
+		Reroll:     2,
+	}
+}
+
+// EnhanceAbility returns Enhance Ability's effect for the chosen ability:
+// advantage on checks made with it.
+func EnhanceAbility(source string, ability rules.Ability) Effect {
+	return Effect{
+		Name:      "Enhance Ability",
+		Source:    source,
+		Rolls:     []RollType{CheckRoll},
+		Abilities: []rules.Ability{ability},
+		Advantage: true,
+	}
+}
+
+// UntrainedArmor returns the effect of wearing armor or a shield without
+// proficiency: disadvantage on every check, save and attack using STR or
+// DEX, and no spellcasting.
+func UntrainedArmor(item string) Effect {
+	return Effect{
+		Name:         "Untrained armor",
+		Source:       item,
+		Abilities:    []rules.Ability{rules.Strength, rules.Dexterity},
+		Disadvantage: true,
+		NoCasting:    true,
+	}
+}
+
+// HeavilyEncumbered returns the variant encumbrance rule's penalty for
+// carrying over 10 × STR pounds: disadvantage on checks, saves and
+// attacks using STR, DEX or CON.
+func HeavilyEncumbered() Effect {
+	return Effect{
+		Name:         "Heavily encumbered",
+		Source:       "encumbrance",
+		Abilities:    []rules.Ability{rules.Strength, rules.Dexterity, rules.Constitution},
+		Disadvantage: true,
+	}
+}
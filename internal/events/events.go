@@ -0,0 +1,250 @@
+// Package events provides a small synchronous event bus for character
+// changes. Views, autosave, the session log and the rules engine subscribe
+// to the events they care about instead of calling each other directly.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Type identifies the kind of an event.
+type Type string
+
+const (
+	TypeHPChanged      Type = "hp_changed"
+	TypeSpellCast      Type = "spell_cast"
+	TypeConditionAdded Type = "condition_added"
+	TypeLevelGained    Type = "level_gained"
+	TypeTableRolled    Type = "table_rolled"
+	TypeCharacterDied  Type = "character_died"
+	TypeLootGained     Type = "loot_gained"
+	TypeTempHPGranted  Type = "temp_hp_granted"
+	TypeRestFinished   Type = "rest_finished"
+)
+
+// Event is implemented by every event published on the bus.
+type Event interface {
+	Type() Type
+}
+
+// HPChanged is published whenever a character's current HP changes.
+type HPChanged struct {
+	Character string
+	Old       int
+	New       int
+	Max       int
+}
+
+func (HPChanged) Type() Type { return TypeHPChanged }
+
+// SpellCast is published when a character casts a spell. Level is the slot
+// level used, or 0 for cantrips.
+type SpellCast struct {
+	Character string
+	Spell     string
+	Level     int
+}
+
+func (SpellCast) Type() Type { return TypeSpellCast }
+
+// ConditionAdded is published when a condition is applied to a character.
+type ConditionAdded struct {
+	Character string
+	Condition string
+}
+
+func (ConditionAdded) Type() Type { return TypeConditionAdded }
+
+// LevelGained is published when a character gains a level in a class.
+type LevelGained struct {
+	Character string
+	Class     string
+	Level     int
+}
+
+func (LevelGained) Type() Type { return TypeLevelGained }
+
+// TableRolled is published when someone rolls on a random table.
+type TableRolled struct {
+	Character string
+	Table     string
+	Roll      int
+	Result    string
+}
+
+func (TableRolled) Type() Type { return TypeTableRolled }
+
+// CharacterDied is published when a character dies.
+type CharacterDied struct {
+	Character string
+	Cause     string
+}
+
+func (CharacterDied) Type() Type { return TypeCharacterDied }
+
+// LootGained is published when items or coins are added to a character's
+// inventory from the game rather than by editing. ValueGP is the total
+// value in gold pieces, or 0 if unknown.
+type LootGained struct {
+	Character string
+	Item      string
+	Quantity  int
+	ValueGP   float64
+}
+
+func (LootGained) Type() Type { return TypeLootGained }
+
+// TempHPGranted is published when one character grants another temporary
+// hit points, e.g. with Inspiring Leader. The target's session applies
+// them.
+type TempHPGranted struct {
+	Character string
+	Amount    int
+	Source    string
+}
+
+func (TempHPGranted) Type() Type { return TypeTempHPGranted }
+
+// RestFinished is published when a character's session finishes a short
+// or long rest, after its benefits are applied. Cancelled rests don't
+// publish it.
+type RestFinished struct {
+	Character string
+	Long      bool
+}
+
+func (RestFinished) Type() Type { return TypeRestFinished }
+
+// Handler receives published events.
+type Handler func(Event)
+
+type subscription struct {
+	id      int
+	handler Handler
+}
+
+// Bus dispatches events to subscribers synchronously, in subscription order.
+// The zero value is ready to use.
+type Bus struct {
+	mu     sync.RWMutex
+	nextID int
+	byType map[Type][]subscription
+	all    []subscription
+}
+
+// NewBus returns an empty event bus.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers h for events of type t. The returned function removes
+// the subscription.
+func (b *Bus) Subscribe(t Type, h Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.byType == nil {
+		b.byType = make(map[Type][]subscription)
+	}
+	b.nextID++
+	id := b.nextID
+	b.byType[t] = append(b.byType[t], subscription{id: id, handler: h})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.byType[t] = remove(b.byType[t], id)
+	}
+}
+
+// SubscribeAll registers h for every event published on the bus. The
+// returned function removes the subscription.
+func (b *Bus) SubscribeAll(h Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.all = append(b.all, subscription{id: id, handler: h})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.all = remove(b.all, id)
+	}
+}
+
+// Publish delivers e to all subscribers of its type, then to subscribers of
+// all events. Handlers may publish further events or unsubscribe.
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.byType[e.Type()])+len(b.all))
+	for _, s := range b.byType[e.Type()] {
+		handlers = append(handlers, s.handler)
+	}
+	for _, s := range b.all {
+		handlers = append(handlers, s.handler)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(e)
+	}
+}
+
+func remove(subs []subscription, id int) []subscription {
+	for i, s := range subs {
+		if s.id == id {
+			return append(subs[:i:i], subs[i+1:]...)
+		}
+	}
+	return subs
+}
+
+// Record is a published event along with the time it was seen.
+type Record struct {
+	Time  time.Time
+	Event Event
+}
+
+// Recorder keeps the most recent events published on a bus, for the session
+// log and crash reports.
+type Recorder struct {
+	mu      sync.Mutex
+	size    int
+	records []Record
+}
+
+// NewRecorder returns a recorder that keeps at most size events.
+func NewRecorder(size int) *Recorder {
+	if size < 1 {
+		size = 1
+	}
+	return &Recorder{size: size}
+}
+
+// Attach subscribes the recorder to every event on b.
+func (r *Recorder) Attach(b *Bus) func() {
+	return b.SubscribeAll(r.record)
+}
+
+func (r *Recorder) record(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, Record{Time: time.Now(), Event: e})
+	if len(r.records) > r.size {
+		r.records = r.records[len(r.records)-r.size:]
+	}
+}
+
+// Records returns a copy of the recorded events, oldest first.
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Record, len(r.records))
+	copy(out, r.records)
+	return out
+}
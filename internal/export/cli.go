@@ -0,0 +1,50 @@
+package export
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Loader reads the character file at path into a summary. The app provides
+// it, since the character model lives there.
+type Loader func(path string) (Summary, error)
+
+// Run implements the export subcommand:
+//
+//	sheet export [--format md|txt] [-o file] name.json
+//
+// The summary goes to stdout unless -o names a file.
+func Run(args []string, stdout io.Writer, load Loader) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	format := fs.String("format", string(Markdown), "md or txt")
+	out := fs.String("o", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: sheet export [--format md|txt] [-o file] name.json")
+	}
+	f, err := ParseFormat(*format)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	s, err := load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		return s.Write(stdout, f)
+	}
+	file, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *out, err)
+	}
+	if err := s.Write(file, f); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", *out, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,250 @@
+// Package export writes a readable character summary as Markdown or plain
+// text, for pasting into Discord, wikis and forum posts.
+package export
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"sheet/internal/inventory"
+	"sheet/internal/rules"
+)
+
+// Format is an export format.
+type Format string
+
+const (
+	Markdown Format = "md"
+	Text     Format = "txt"
+)
+
+// ParseFormat reads a --format value: "md", "markdown", "txt" or "text".
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "md", "markdown":
+		return Markdown, nil
+	case "txt", "text":
+		return Text, nil
+	}
+	return "", fmt.Errorf("unknown format %q (want md or txt)", s)
+}
+
+// Attack is a line of the attacks section.
+type Attack struct {
+	Name       string
+	Bonus      int
+	Damage     string
+	DamageType string
+}
+
+// Spell is a line of the spells section.
+type Spell struct {
+	Name     string
+	Level    int
+	Prepared bool
+}
+
+// Detail is a line of the details section, e.g. Alignment: Chaotic Good.
+type Detail struct {
+	Label string
+	Value string
+}
+
+// Organization is a line of the allies and organizations section.
+type Organization struct {
+	Name   string
+	Rank   string
+	Renown int
+}
+
+// Summary is what the export shows. The caller fills it in from the
+// character.
+type Summary struct {
+	Name    string
+	Race    string
+	Classes []rules.ClassLevel
+
+	HP, MaxHP int
+	AC        int
+	Speed     int
+
+	Scores rules.Scores
+	Saves  rules.SavingThrows
+	// Skills maps skill names to the character's proficiency in them.
+	Skills map[string]rules.Proficiency
+
+	// Details are descriptive fields such as alignment, age and faith.
+	// Blank ones are left out.
+	Details       []Detail
+	Organizations []Organization
+
+	Attacks   []Attack
+	Spells    []Spell
+	Inventory *inventory.Inventory
+}
+
+// style is the markup a format uses.
+type style struct {
+	heading func(string) string
+	strong  func(string) string
+}
+
+var styles = map[Format]style{
+	Markdown: {
+		heading: func(s string) string { return "## " + s },
+		strong:  func(s string) string { return "**" + s + "**" },
+	},
+	Text: {
+		heading: func(s string) string { return strings.ToUpper(s) },
+		strong:  func(s string) string { return s },
+	},
+}
+
+// Write renders the summary to w in format f.
+func (s Summary) Write(w io.Writer, f Format) error {
+	st, ok := styles[f]
+	if !ok {
+		return fmt.Errorf("unknown format %q", f)
+	}
+	_, err := io.WriteString(w, s.render(st, f))
+	return err
+}
+
+func (s Summary) render(st style, f Format) string {
+	var b strings.Builder
+	if f == Markdown {
+		fmt.Fprintf(&b, "# %s\n", s.Name)
+	} else {
+		fmt.Fprintf(&b, "%s\n%s\n", s.Name, strings.Repeat("=", len([]rune(s.Name))))
+	}
+	level := rules.TotalLevel(s.Classes)
+	profBonus := rules.ProficiencyBonus(level)
+	if line := strings.TrimSpace(s.Race + " " + classLine(s.Classes)); line != "" {
+		fmt.Fprintf(&b, "%s (level %d)\n", line, level)
+	}
+	if details := filledDetails(s.Details); len(details) > 0 {
+		b.WriteString("\n")
+		for _, d := range details {
+			fmt.Fprintf(&b, "%s: %s\n", st.strong(d.Label), d.Value)
+		}
+	}
+	fmt.Fprintf(&b, "\n%s %d/%d  %s %d  %s %d ft.  %s %+d\n",
+		st.strong("HP"), s.HP, s.MaxHP, st.strong("AC"), s.AC,
+		st.strong("Speed"), s.Speed, st.strong("Proficiency"), profBonus)
+
+	fmt.Fprintf(&b, "\n%s\n\n", st.heading("Abilities"))
+	for _, a := range rules.Abilities {
+		save := ""
+		if s.Saves.Proficient(a) {
+			save = ", save proficient"
+		}
+		fmt.Fprintf(&b, "- %s %d (%+d%s)\n", st.strong(string(a)), s.Scores[a], s.Scores.Modifier(a), save)
+	}
+
+	fmt.Fprintf(&b, "\n%s\n\n", st.heading("Skills"))
+	for _, sk := range rules.Skills {
+		p := s.Skills[sk.Name]
+		line := fmt.Sprintf("- %s %+d", sk.Name, rules.CheckModifier(s.Scores[sk.Ability], p, profBonus))
+		if p != rules.NotProficient {
+			line += " (" + p.String() + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if len(s.Organizations) > 0 {
+		fmt.Fprintf(&b, "\n%s\n\n", st.heading("Allies & Organizations"))
+		for _, o := range s.Organizations {
+			line := st.strong(o.Name)
+			if o.Rank != "" {
+				line += ", " + o.Rank
+			}
+			if o.Renown != 0 {
+				line += fmt.Sprintf(", renown %d", o.Renown)
+			}
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	if len(s.Attacks) > 0 {
+		fmt.Fprintf(&b, "\n%s\n\n", st.heading("Attacks"))
+		for _, a := range s.Attacks {
+			fmt.Fprintf(&b, "- %s %+d to hit, %s\n", st.strong(a.Name), a.Bonus, strings.TrimSpace(a.Damage+" "+a.DamageType))
+		}
+	}
+
+	if len(s.Spells) > 0 {
+		fmt.Fprintf(&b, "\n%s\n", st.heading("Spells"))
+		spells := slices.Clone(s.Spells)
+		slices.SortStableFunc(spells, func(a, b Spell) int { return a.Level - b.Level })
+		for i, sp := range spells {
+			if i == 0 || sp.Level != spells[i-1].Level {
+				fmt.Fprintf(&b, "\n%s\n", st.strong(spellLevel(sp.Level)))
+			}
+			name := sp.Name
+			if sp.Prepared && sp.Level > 0 {
+				name += " (prepared)"
+			}
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+
+	if inv := s.Inventory; inv != nil {
+		fmt.Fprintf(&b, "\n%s\n\n", st.heading("Inventory"))
+		for _, it := range inv.Items {
+			line := it.Name
+			if it.Quantity > 1 {
+				line = fmt.Sprintf("%s ×%d", it.Name, it.Quantity)
+			}
+			if inv.IsEquipped(it.Name) {
+				line += " (equipped)"
+			}
+			if it.MaxCharges > 0 {
+				line += fmt.Sprintf(", %d/%d charges", it.Charges, it.MaxCharges)
+			}
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+		if len(inv.Items) == 0 {
+			b.WriteString("- Nothing\n")
+		}
+		fmt.Fprintf(&b, "\n%s %s\n", st.strong("Coins"), inv.Wallet.Coins)
+	}
+	return b.String()
+}
+
+func filledDetails(details []Detail) []Detail {
+	var out []Detail
+	for _, d := range details {
+		if strings.TrimSpace(d.Value) != "" {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func classLine(classes []rules.ClassLevel) string {
+	var parts []string
+	for _, c := range classes {
+		name := c.Class
+		if c.Subclass != "" {
+			name = c.Subclass + " " + c.Class
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", name, c.Level))
+	}
+	return strings.Join(parts, " / ")
+}
+
+func spellLevel(n int) string {
+	switch n {
+	case 0:
+		return "Cantrips"
+	case 1:
+		return "1st level"
+	case 2:
+		return "2nd level"
+	case 3:
+		return "3rd level"
+	}
+	return fmt.Sprintf("%dth level", n)
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{"md", Markdown, false},
+		{"Markdown", Markdown, false},
+		{"txt", Text, false},
+		{"text", Text, false},
+		{"pdf", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteDetails(t *testing.T) {
+	s := Summary{
+		Name: "Tordek",
+		Details: []Detail{
+			{Label: "Alignment", Value: "Lawful Good"},
+			{Label: "Age", Value: ""},
+			{Label: "Faith", Value: "Moradin"},
+		},
+	}
+	tests := []struct {
+		format Format
+		want   []string
+		absent []string
+	}{
+		{Markdown, []string{"**Alignment**: Lawful Good\n", "**Faith**: Moradin\n"}, []string{"Age"}},
+		{Text, []string{"Alignment: Lawful Good\n", "Faith: Moradin\n"}, []string{"Age"}},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := s.Write(&b, tt.format); err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range tt.want {
+			if !strings.Contains(b.String(), w) {
+				t.Errorf("%s export lacks %q:\n%s", tt.format, w, b.String())
+			}
+		}
+		for _, a := range tt.absent {
+			if strings.Contains(b.String(), a) {
+				t.Errorf("%s export has blank %q:\n%s", tt.format, a, b.String())
+			}
+		}
+	}
+}
+
+func TestWriteOrganizations(t *testing.T) {
+	s := Summary{
+		Name: "Tordek",
+		Organizations: []Organization{
+			{Name: "Harpers", Rank: "Watcher", Renown: 3},
+			{Name: "Lords' Alliance"},
+		},
+	}
+	var b strings.Builder
+	if err := s.Write(&b, Markdown); err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range []string{
+		"## Allies & Organizations\n",
+		"- **Harpers**, Watcher, renown 3\n",
+		"- **Lords' Alliance**\n",
+	} {
+		if !strings.Contains(b.String(), w) {
+			t.Errorf("export lacks %q:\n%s", w, b.String())
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/dice"
+)
+
+// RechargeRule is a parsed Item.Recharge.
+type RechargeRule struct {
+	// Dice is how many charges return; zero means all of them.
+	Dice dice.Expr
+	// When is the trigger, e.g. "dawn".
+	When string
+}
+
+// ParseRecharge reads a recharge rule: an amount in dice notation or "all",
+// then "at" and when it happens, e.g. "1d6+4 at dawn". A bare "dawn"
+// restores every charge.
+func ParseRecharge(s string) (RechargeRule, error) {
+	amount, when, found := strings.Cut(strings.ToLower(strings.TrimSpace(s)), " at ")
+	if !found {
+		amount, when = "all", amount
+	}
+	when = strings.TrimPrefix(strings.TrimSpace(when), "at ")
+	if when == "" {
+		return RechargeRule{}, fmt.Errorf("invalid recharge rule %q", s)
+	}
+	rule := RechargeRule{When: when}
+	if amount = strings.TrimSpace(amount); amount != "all" {
+		e, err := dice.Parse(amount)
+		if err != nil {
+			return RechargeRule{}, fmt.Errorf("invalid recharge rule %q: %w", s, err)
+		}
+		rule.Dice = e
+	}
+	return rule, nil
+}
+
+// UseCharges spends n charges of the named item.
+func (inv *Inventory) UseCharges(name string, n int) error {
+	it, ok := inv.Find(name)
+	switch {
+	case !ok:
+		return fmt.Errorf("no item named %q", name)
+	case it.MaxCharges == 0:
+		return fmt.Errorf("%s has no charges", it.Name)
+	case n < 1:
+		return fmt.Errorf("charges must be positive")
+	case n > it.Charges:
+		return fmt.Errorf("%s has only %d of %d charges left", it.Name, it.Charges, it.MaxCharges)
+	}
+	it.Charges -= n
+	return nil
+}
+
+// Recharge rolls the recharge rule of every item with spent charges, as at
+// the end of a long rest (taken to include the dawn). Charges never
+// exceed the maximum. It returns a line per item for the rest summary,
+// e.g. "Wand of Web: +5 charges (1d6+1 [4] + 1), 7/7".
+func (inv *Inventory) Recharge(r *dice.Roller) ([]string, error) {
+	var lines []string
+	for i := range inv.Items {
+		it := &inv.Items[i]
+		if it.Recharge == "" || it.Charges >= it.MaxCharges {
+			continue
+		}
+		rule, err := ParseRecharge(it.Recharge)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", it.Name, err)
+		}
+		gained, detail := it.MaxCharges-it.Charges, ""
+		if !rule.Dice.IsZero() {
+			res := r.Roll(rule.Dice)
+			gained = max(min(res.Total, gained), 0)
+			detail = " (" + res.Breakdown() + ")"
+		}
+		it.Charges += gained
+		lines = append(lines, fmt.Sprintf("%s: +%d charges%s, %d/%d", it.Name, gained, detail, it.Charges, it.MaxCharges))
+	}
+	return lines, nil
+}
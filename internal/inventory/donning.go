@@ -0,0 +1,87 @@
+package inventory
+
+import (
+	"fmt"
+	"time"
+)
+
+// Round is the length of a combat round; donning or doffing a shield takes
+// an action, which fits in one.
+const Round = 6 * time.Second
+
+// DonDoff is how long a piece of armor takes to put on and take off.
+type DonDoff struct {
+	Don, Doff time.Duration
+}
+
+// ArmorTimes returns the PHB donning and doffing times for armor or a
+// shield. Other items take no time.
+func ArmorTimes(it Item) DonDoff {
+	switch {
+	case it.Type == Shield:
+		return DonDoff{Round, Round}
+	case it.Type != Armor:
+		return DonDoff{}
+	case it.ArmorCategory == HeavyArmor:
+		return DonDoff{10 * time.Minute, 5 * time.Minute}
+	case it.ArmorCategory == MediumArmor:
+		return DonDoff{5 * time.Minute, time.Minute}
+	}
+	return DonDoff{time.Minute, time.Minute}
+}
+
+// Worn reports whether the named armor or shield is being worn rather than
+// just carried. Only worn armor counts towards AC.
+func (inv *Inventory) Worn(name string) bool {
+	it, ok := inv.Find(name)
+	if !ok || (it.Type != Armor && it.Type != Shield) {
+		return false
+	}
+	return inv.IsEquipped(it.Name)
+}
+
+// Don puts on armor or takes up a shield, replacing what was worn there,
+// and describes how long it takes.
+func (inv *Inventory) Don(name string) (string, error) {
+	it, ok := inv.Find(name)
+	if !ok {
+		return "", fmt.Errorf("no item named %q", name)
+	}
+	slot := ArmorSlot
+	switch it.Type {
+	case Shield:
+		slot = OffHand
+	case Armor:
+	default:
+		return "", fmt.Errorf("%s is not armor or a shield", it.Name)
+	}
+	if err := inv.Equip(it.Name, slot); err != nil {
+		return "", err
+	}
+	return timeNote("Donning", it.Name, ArmorTimes(*it).Don), nil
+}
+
+// Doff takes off worn armor or a shield, keeping it in the inventory, and
+// describes how long it takes.
+func (inv *Inventory) Doff(name string) (string, error) {
+	if !inv.Worn(name) {
+		return "", fmt.Errorf("%s is not being worn", name)
+	}
+	it, _ := inv.Find(name)
+	inv.unequipItem(it.Name)
+	return timeNote("Doffing", it.Name, ArmorTimes(*it).Doff), nil
+}
+
+// timeNote describes an action's duration, warning when it won't fit in a
+// combat round.
+func timeNote(verb, name string, d time.Duration) string {
+	if d <= Round {
+		return fmt.Sprintf("%s %s takes an action", verb, name)
+	}
+	minutes := int(d / time.Minute)
+	unit := "minutes"
+	if minutes == 1 {
+		unit = "minute"
+	}
+	return fmt.Sprintf("%s %s takes %d %s (%d rounds), too long to do mid-combat", verb, name, minutes, unit, int(d/Round))
+}
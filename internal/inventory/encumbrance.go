@@ -0,0 +1,93 @@
+package inventory
+
+import "fmt"
+
+// Encumbrance is how weighed down a character is.
+type Encumbrance int
+
+const (
+	Unencumbered Encumbrance = iota
+	// Encumbered and HeavilyEncumbered only happen under the variant
+	// encumbrance rule: over 5 × STR and 10 × STR pounds.
+	Encumbered
+	HeavilyEncumbered
+	// OverCapacity is carrying more than the carrying capacity.
+	OverCapacity
+)
+
+func (e Encumbrance) String() string {
+	switch e {
+	case Encumbered:
+		return "encumbered"
+	case HeavilyEncumbered:
+		return "heavily encumbered"
+	case OverCapacity:
+		return "over capacity"
+	}
+	return "unencumbered"
+}
+
+// OverCapacitySpeed is the most a character carrying more than their
+// capacity can move.
+const OverCapacitySpeed = 5
+
+// SpeedPenalty returns how far the encumbrance reduces speed. Over
+// capacity, speed drops to OverCapacitySpeed instead; see
+// Inventory.Speed.
+func (e Encumbrance) SpeedPenalty() int {
+	switch e {
+	case Encumbered:
+		return 10
+	case HeavilyEncumbered:
+		return 20
+	}
+	return 0
+}
+
+// EncumbranceFor returns the encumbrance of carrying weight pounds with a
+// STR score. variant enables the variant encumbrance rule's two
+// intermediate thresholds.
+func EncumbranceFor(weight float64, strength int, variant bool) Encumbrance {
+	s := float64(strength)
+	switch {
+	case weight > CarryingCapacity(strength):
+		return OverCapacity
+	case variant && weight > 10*s:
+		return HeavilyEncumbered
+	case variant && weight > 5*s:
+		return Encumbered
+	}
+	return Unencumbered
+}
+
+// Encumbrance returns how weighed down the character is by everything
+// carried.
+func (inv *Inventory) Encumbrance(strength int, variant bool) Encumbrance {
+	return EncumbranceFor(inv.TotalWeight(), strength, variant)
+}
+
+// Speed applies the armor and encumbrance penalties to a walking speed,
+// for the main sheet.
+func (inv *Inventory) Speed(base, strength int, variant bool) int {
+	enc := inv.Encumbrance(strength, variant)
+	speed := max(base-inv.SpeedPenalty(strength)-enc.SpeedPenalty(), 0)
+	if enc == OverCapacity {
+		speed = min(speed, OverCapacitySpeed)
+	}
+	return speed
+}
+
+// encumbranceWarning describes the encumbrance for the inventory view, or
+// returns "" when unencumbered.
+func encumbranceWarning(e Encumbrance, weight float64, strength int) string {
+	switch e {
+	case Encumbered:
+		return fmt.Sprintf("Encumbered: carrying %.1f lb, over %d lb; speed reduced by %d ft", weight, 5*strength, e.SpeedPenalty())
+	case HeavilyEncumbered:
+		return fmt.Sprintf("Heavily encumbered: carrying %.1f lb, over %d lb; speed reduced by %d ft and disadvantage on STR, DEX and CON rolls",
+			weight, 10*strength, e.SpeedPenalty())
+	case OverCapacity:
+		return fmt.Sprintf("Carrying %.1f lb, over capacity of %.0f lb; speed is %d ft", weight, CarryingCapacity(strength), OverCapacitySpeed)
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FocusKind is the kind of spellcasting focus an item serves as.
+type FocusKind string
+
+const (
+	ArcaneFocus    FocusKind = "arcane focus"
+	DruidicFocus   FocusKind = "druidic focus"
+	HolySymbol     FocusKind = "holy symbol"
+	PactWeapon     FocusKind = "pact weapon"
+	ComponentPouch FocusKind = "component pouch"
+	Instrument     FocusKind = "musical instrument"
+)
+
+// FocusKinds lists the kinds in the order the view offers them.
+var FocusKinds = []FocusKind{ArcaneFocus, DruidicFocus, HolySymbol, PactWeapon, ComponentPouch, Instrument}
+
+// classFocus is the focus each class normally uses.
+var classFocus = map[string]FocusKind{
+	"Bard":     Instrument,
+	"Cleric":   HolySymbol,
+	"Druid":    DruidicFocus,
+	"Paladin":  HolySymbol,
+	"Ranger":   ComponentPouch,
+	"Sorcerer": ArcaneFocus,
+	"Warlock":  ArcaneFocus,
+	"Wizard":   ArcaneFocus,
+}
+
+// DefaultFocusKind suggests the focus kind for a class.
+func DefaultFocusKind(class string) FocusKind {
+	for c, k := range classFocus {
+		if strings.EqualFold(c, class) {
+			return k
+		}
+	}
+	return ArcaneFocus
+}
+
+// Focus designates an item as the character's spellcasting focus.
+type Focus struct {
+	Item string    `json:"item"`
+	Kind FocusKind `json:"kind"`
+}
+
+func (f Focus) String() string {
+	return fmt.Sprintf("%s (%s)", f.Item, f.Kind)
+}
+
+// SetFocus designates the named item as the spellcasting focus. A pact
+// weapon must be a weapon.
+func (inv *Inventory) SetFocus(name string, kind FocusKind) error {
+	it, ok := inv.Find(name)
+	if !ok {
+		return fmt.Errorf("no item named %q", name)
+	}
+	if kind == PactWeapon && it.Type != Weapon {
+		return fmt.Errorf("%s is not a weapon", it.Name)
+	}
+	inv.Focus = &Focus{Item: it.Name, Kind: kind}
+	return nil
+}
+
+// ClearFocus removes the focus designation.
+func (inv *Inventory) ClearFocus() {
+	inv.Focus = nil
+}
+
+// CoversMaterial reports whether the focus can stand in for a spell's
+// material components. Components with a gold cost, or that the spell
+// consumes, must still be carried.
+func (inv *Inventory) CoversMaterial(costly bool) bool {
+	if inv.Focus == nil || costly {
+		return false
+	}
+	_, ok := inv.Find(inv.Focus.Item)
+	return ok
+}
@@ -0,0 +1,367 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/currency"
+)
+
+// MaxAttuned is how many items a character can be attuned to at once.
+const MaxAttuned = 3
+
+// Slot is an equipment slot.
+type Slot string
+
+const (
+	MainHand  Slot = "main_hand"
+	OffHand   Slot = "off_hand"
+	ArmorSlot Slot = "armor"
+)
+
+// Inventory is a character's items and what is equipped where. Items are
+// stacked by name.
+type Inventory struct {
+	Items []Item `json:"items"`
+	// Equipped maps each occupied slot to the name of the item in it.
+	Equipped map[Slot]string `json:"equipped,omitempty"`
+	// Wallet holds the character's coins and their transaction log.
+	Wallet currency.Wallet `json:"wallet"`
+	// Focus is the item designated as the spellcasting focus, if any.
+	Focus *Focus `json:"focus,omitempty"`
+}
+
+// New returns an empty inventory.
+func New() *Inventory {
+	return &Inventory{Equipped: make(map[Slot]string)}
+}
+
+func (inv *Inventory) index(name string) int {
+	for i, it := range inv.Items {
+		if strings.EqualFold(it.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Find returns the item with the given name.
+func (inv *Inventory) Find(name string) (*Item, bool) {
+	i := inv.index(name)
+	if i < 0 {
+		return nil, false
+	}
+	return &inv.Items[i], true
+}
+
+// Add adds an item, stacking onto an existing item of the same name. A
+// zero quantity counts as one.
+func (inv *Inventory) Add(it Item) {
+	if it.Quantity < 1 {
+		it.Quantity = 1
+	}
+	if existing, ok := inv.Find(it.Name); ok {
+		existing.Quantity += it.Quantity
+		return
+	}
+	inv.Items = append(inv.Items, it)
+}
+
+// Remove takes qty of the named item away, deleting the stack (and
+// unequipping it) when none are left.
+func (inv *Inventory) Remove(name string, qty int) error {
+	i := inv.index(name)
+	if i < 0 {
+		return fmt.Errorf("no item named %q", name)
+	}
+	if qty < 1 {
+		return fmt.Errorf("quantity must be positive")
+	}
+	if qty < inv.Items[i].Quantity {
+		inv.Items[i].Quantity -= qty
+		inv.fitEquipped(inv.Items[i])
+		return nil
+	}
+	inv.unequipItem(inv.Items[i].Name)
+	if inv.Focus != nil && strings.EqualFold(inv.Focus.Item, inv.Items[i].Name) {
+		inv.Focus = nil
+	}
+	inv.Items = append(inv.Items[:i], inv.Items[i+1:]...)
+	return nil
+}
+
+// SetQuantity changes the size of a stack; zero removes it.
+func (inv *Inventory) SetQuantity(name string, qty int) error {
+	it, ok := inv.Find(name)
+	switch {
+	case !ok:
+		return fmt.Errorf("no item named %q", name)
+	case qty < 0:
+		return fmt.Errorf("quantity cannot be negative")
+	case qty == 0:
+		return inv.Remove(name, it.Quantity)
+	}
+	it.Quantity = qty
+	inv.fitEquipped(*it)
+	return nil
+}
+
+// Update replaces the named item with an edited copy, keeping its slots if
+// the name is unchanged.
+func (inv *Inventory) Update(name string, edited Item) error {
+	i := inv.index(name)
+	if i < 0 {
+		return fmt.Errorf("no item named %q", name)
+	}
+	if !strings.EqualFold(name, edited.Name) {
+		if inv.index(edited.Name) >= 0 {
+			return fmt.Errorf("an item named %q already exists", edited.Name)
+		}
+		for slot, equipped := range inv.Equipped {
+			if strings.EqualFold(equipped, name) {
+				inv.Equipped[slot] = edited.Name
+			}
+		}
+		if inv.Focus != nil && strings.EqualFold(inv.Focus.Item, name) {
+			inv.Focus.Item = edited.Name
+		}
+	}
+	if edited.Quantity < 1 {
+		edited.Quantity = 1
+	}
+	inv.Items[i] = edited
+	return nil
+}
+
+// Equip puts the named item in slot. Armor goes in the armor slot, shields
+// in the off hand, and weapons in either hand; two-handed weapons take
+// both hands. Whatever was in the slot is unequipped. A stack fills one
+// slot per item, so a pair of shortswords can be held in both hands; an
+// item with none to spare moves from its other slot.
+func (inv *Inventory) Equip(name string, slot Slot) error {
+	it, ok := inv.Find(name)
+	if !ok {
+		return fmt.Errorf("no item named %q", name)
+	}
+	if err := checkSlot(*it, slot); err != nil {
+		return err
+	}
+	if inv.Equipped == nil {
+		inv.Equipped = make(map[Slot]string)
+	}
+
+	if isTwoHanded(*it) {
+		inv.unequipItem(it.Name)
+		inv.Unequip(MainHand)
+		inv.Unequip(OffHand)
+		inv.Equipped[MainHand] = it.Name
+		inv.Equipped[OffHand] = it.Name
+		return nil
+	}
+	inv.Unequip(slot)
+	if inv.slotsHolding(it.Name) >= it.Quantity {
+		inv.unequipItem(it.Name)
+	}
+	inv.Equipped[slot] = it.Name
+	return nil
+}
+
+func isTwoHanded(it Item) bool {
+	return it.Type == Weapon && it.HasProperty("Two-Handed")
+}
+
+// slotsHolding returns how many slots the named item is equipped in.
+func (inv *Inventory) slotsHolding(name string) int {
+	n := 0
+	for _, equipped := range inv.Equipped {
+		if strings.EqualFold(equipped, name) {
+			n++
+		}
+	}
+	return n
+}
+
+// fitEquipped unequips it from the off hand, then its other slots, until
+// it is in no more slots than the stack has items. A two-handed weapon
+// needs only one item for both hands.
+func (inv *Inventory) fitEquipped(it Item) {
+	if isTwoHanded(it) {
+		return
+	}
+	for _, slot := range []Slot{OffHand, MainHand, ArmorSlot} {
+		if inv.slotsHolding(it.Name) <= it.Quantity {
+			return
+		}
+		if strings.EqualFold(inv.Equipped[slot], it.Name) {
+			delete(inv.Equipped, slot)
+		}
+	}
+}
+
+func checkSlot(it Item, slot Slot) error {
+	switch slot {
+	case ArmorSlot:
+		if it.Type != Armor {
+			return fmt.Errorf("%s is not armor", it.Name)
+		}
+	case OffHand:
+		if it.Type != Weapon && it.Type != Shield {
+			return fmt.Errorf("%s cannot be held in the off hand", it.Name)
+		}
+	case MainHand:
+		if it.Type != Weapon {
+			return fmt.Errorf("%s is not a weapon", it.Name)
+		}
+	default:
+		return fmt.Errorf("unknown slot %q", slot)
+	}
+	return nil
+}
+
+// Unequip empties a slot. Unequipping either hand of a two-handed weapon
+// frees both.
+func (inv *Inventory) Unequip(slot Slot) {
+	name, ok := inv.Equipped[slot]
+	if !ok {
+		return
+	}
+	if it, found := inv.Find(name); found && isTwoHanded(*it) {
+		inv.unequipItem(name)
+		return
+	}
+	delete(inv.Equipped, slot)
+}
+
+// unequipItem removes the named item from every slot.
+func (inv *Inventory) unequipItem(name string) {
+	for slot, equipped := range inv.Equipped {
+		if strings.EqualFold(equipped, name) {
+			delete(inv.Equipped, slot)
+		}
+	}
+}
+
+// InSlot returns the item equipped in slot.
+func (inv *Inventory) InSlot(slot Slot) (*Item, bool) {
+	name, ok := inv.Equipped[slot]
+	if !ok {
+		return nil, false
+	}
+	return inv.Find(name)
+}
+
+// IsEquipped reports whether the named item is in any slot.
+func (inv *Inventory) IsEquipped(name string) bool {
+	for _, equipped := range inv.Equipped {
+		if strings.EqualFold(equipped, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Attuned returns the items the character is attuned to.
+func (inv *Inventory) Attuned() []Item {
+	var out []Item
+	for _, it := range inv.Items {
+		if it.Attuned {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// Attune attunes to the named item, enforcing the three-item limit.
+// Attuning takes a short rest; views should go through rest.ShortRest
+// rather than calling this directly.
+func (inv *Inventory) Attune(name string) error {
+	it, ok := inv.Find(name)
+	switch {
+	case !ok:
+		return fmt.Errorf("no item named %q", name)
+	case !it.RequiresAttunement:
+		return fmt.Errorf("%s does not require attunement", it.Name)
+	case it.Attuned:
+		return nil
+	case len(inv.Attuned()) >= MaxAttuned:
+		return fmt.Errorf("already attuned to %d items", MaxAttuned)
+	}
+	it.Attuned = true
+	return nil
+}
+
+// Unattune ends attunement to the named item.
+func (inv *Inventory) Unattune(name string) error {
+	it, ok := inv.Find(name)
+	if !ok {
+		return fmt.Errorf("no item named %q", name)
+	}
+	it.Attuned = false
+	return nil
+}
+
+// TotalWeight returns the weight of everything carried, in pounds.
+func (inv *Inventory) TotalWeight() float64 {
+	total := 0.0
+	for _, it := range inv.Items {
+		total += it.TotalWeight()
+	}
+	return total
+}
+
+// ArmorClass computes AC from worn armor and shield; armor that is only
+// carried doesn't count. Without armor it is 10 + DEX; light armor adds
+// full DEX, medium adds up to +2 and heavy adds none. Shields and magic
+// bonuses stack on top.
+func (inv *Inventory) ArmorClass(dexMod int) int {
+	ac := 10 + dexMod
+	if armor, ok := inv.InSlot(ArmorSlot); ok {
+		switch armor.ArmorCategory {
+		case MediumArmor:
+			ac = armor.ArmorClass + min(dexMod, 2)
+		case HeavyArmor:
+			ac = armor.ArmorClass
+		default:
+			ac = armor.ArmorClass + dexMod
+		}
+		ac += armor.MagicBonus
+	}
+	if shield, ok := inv.InSlot(OffHand); ok && shield.Type == Shield {
+		ac += shield.ArmorClass + shield.MagicBonus
+	}
+	return ac
+}
+
+// CarryingCapacity is STR × 15 pounds.
+func CarryingCapacity(strength int) float64 {
+	return float64(strength) * 15
+}
+
+// ArmorSpeedPenalty is how far speed drops in armor whose strength
+// requirement isn't met.
+const ArmorSpeedPenalty = 10
+
+// SpeedPenalty returns the speed reduction from the equipped armor: 10 ft
+// if its strength requirement is above the character's STR score.
+func (inv *Inventory) SpeedPenalty(strength int) int {
+	if armor, ok := inv.InSlot(ArmorSlot); ok && strength < armor.StrengthRequirement {
+		return ArmorSpeedPenalty
+	}
+	return 0
+}
+
+// Warnings returns encumbrance, armor and attunement problems to show in
+// the inventory view. variant enables the variant encumbrance rule.
+func (inv *Inventory) Warnings(strength int, variant bool) []string {
+	var warnings []string
+	if armor, ok := inv.InSlot(ArmorSlot); ok && strength < armor.StrengthRequirement {
+		warnings = append(warnings, fmt.Sprintf("%s needs STR %d: speed reduced by %d ft", armor.Name, armor.StrengthRequirement, ArmorSpeedPenalty))
+	}
+	if w := encumbranceWarning(inv.Encumbrance(strength, variant), inv.TotalWeight(), strength); w != "" {
+		warnings = append(warnings, w)
+	}
+	if n := len(inv.Attuned()); n > MaxAttuned {
+		warnings = append(warnings, fmt.Sprintf("Attuned to %d items, limit is %d", n, MaxAttuned))
+	}
+	return warnings
+}
@@ -0,0 +1,124 @@
+package inventory
+
+import "testing"
+
+func weapon(name string, qty int, props ...string) Item {
+	return Item{Name: name, Type: Weapon, Quantity: qty, Properties: props}
+}
+
+// held returns the names in both hands, "" for an empty hand.
+func held(inv *Inventory) [2]string {
+	return [2]string{inv.Equipped[MainHand], inv.Equipped[OffHand]}
+}
+
+func TestEquipDualWield(t *testing.T) {
+	inv := New()
+	inv.Add(weapon("Shortsword", 2, "Light", "Finesse"))
+	if err := inv.Equip("Shortsword", MainHand); err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.Equip("shortsword", OffHand); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := held(inv), [2]string{"Shortsword", "Shortsword"}; got != want {
+		t.Fatalf("hands = %q, want %q", got, want)
+	}
+
+	// Unequipping one hand keeps the other sword.
+	inv.Unequip(OffHand)
+	if got, want := held(inv), [2]string{"Shortsword", ""}; got != want {
+		t.Errorf("after Unequip(OffHand), hands = %q, want %q", got, want)
+	}
+
+	// Losing one of the pair leaves it in the main hand only.
+	inv.Equip("Shortsword", OffHand)
+	if err := inv.Remove("Shortsword", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := held(inv), [2]string{"Shortsword", ""}; got != want {
+		t.Errorf("after losing one, hands = %q, want %q", got, want)
+	}
+}
+
+func TestEquipSingleItemMoves(t *testing.T) {
+	inv := New()
+	inv.Add(weapon("Dagger", 1, "Light"))
+	inv.Equip("Dagger", MainHand)
+	inv.Equip("Dagger", OffHand)
+	if got, want := held(inv), [2]string{"", "Dagger"}; got != want {
+		t.Errorf("hands = %q, want %q", got, want)
+	}
+}
+
+func TestEquipTwoHanded(t *testing.T) {
+	inv := New()
+	inv.Add(weapon("Greatsword", 1, "Two-Handed"))
+	inv.Add(weapon("Dagger", 2, "Light"))
+	inv.Add(Item{Name: "Shield", Type: Shield, ArmorClass: 2})
+
+	inv.Equip("Dagger", MainHand)
+	inv.Equip("Dagger", OffHand)
+	inv.Equip("Greatsword", MainHand)
+	if got, want := held(inv), [2]string{"Greatsword", "Greatsword"}; got != want {
+		t.Fatalf("hands = %q, want %q", got, want)
+	}
+
+	// Taking up a shield frees both hands of the greatsword.
+	if err := inv.Equip("Shield", OffHand); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := held(inv), [2]string{"", "Shield"}; got != want {
+		t.Errorf("hands = %q, want %q", got, want)
+	}
+}
+
+func TestEquipWrongSlot(t *testing.T) {
+	inv := New()
+	inv.Add(Item{Name: "Rope", Type: Gear})
+	inv.Add(Item{Name: "Shield", Type: Shield})
+	for _, tt := range []struct {
+		name string
+		slot Slot
+	}{
+		{"Rope", MainHand},
+		{"Shield", MainHand},
+		{"Shield", ArmorSlot},
+		{"Missing", MainHand},
+	} {
+		if err := inv.Equip(tt.name, tt.slot); err == nil {
+			t.Errorf("Equip(%s, %s) succeeded", tt.name, tt.slot)
+		}
+	}
+}
+
+func TestArmorClass(t *testing.T) {
+	tests := []struct {
+		name   string
+		armor  *Item
+		shield bool
+		dex    int
+		want   int
+	}{
+		{"unarmored", nil, false, 3, 13},
+		{"light", &Item{ArmorClass: 11, ArmorCategory: LightArmor}, false, 3, 14},
+		{"medium caps dex", &Item{ArmorClass: 14, ArmorCategory: MediumArmor}, false, 4, 16},
+		{"heavy ignores dex", &Item{ArmorClass: 18, ArmorCategory: HeavyArmor}, true, 2, 20},
+		{"magic", &Item{ArmorClass: 13, ArmorCategory: MediumArmor, MagicBonus: 1}, false, -1, 13},
+	}
+	for _, tt := range tests {
+		inv := New()
+		if tt.armor != nil {
+			a := *tt.armor
+			a.Name, a.Type = "Armor", Armor
+			inv.Add(a)
+			inv.Equip("Armor", ArmorSlot)
+		}
+		if tt.shield {
+			inv.Add(Item{Name: "Shield", Type: Shield, ArmorClass: 2})
+			inv.Equip("Shield", OffHand)
+		}
+		if got := inv.ArmorClass(tt.dex); got != tt.want {
+			t.Errorf("%s: AC %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
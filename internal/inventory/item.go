@@ -0,0 +1,124 @@
+// Package inventory manages a character's items: quantities, equipment
+// slots, attunement, weight and the armor class they produce.
+package inventory
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ItemType is the broad category of an item.
+type ItemType string
+
+const (
+	Weapon     ItemType = "weapon"
+	Armor      ItemType = "armor"
+	Shield     ItemType = "shield"
+	Gear       ItemType = "gear"
+	Tool       ItemType = "tool"
+	Consumable ItemType = "consumable"
+	Treasure   ItemType = "treasure"
+)
+
+// ArmorCategory determines how much Dexterity adds to armor class.
+type ArmorCategory string
+
+const (
+	LightArmor  ArmorCategory = "light"
+	MediumArmor ArmorCategory = "medium"
+	HeavyArmor  ArmorCategory = "heavy"
+)
+
+// Item is a stack of identical items.
+type Item struct {
+	Name     string   `json:"name"`
+	Type     ItemType `json:"type"`
+	Quantity int      `json:"quantity"`
+	// Weight is per unit, in pounds.
+	Weight float64 `json:"weight,omitempty"`
+
+	// ArmorClass is the base AC of armor, or the bonus of a shield.
+	ArmorClass    int           `json:"armor_class,omitempty"`
+	ArmorCategory ArmorCategory `json:"armor_category,omitempty"`
+	MagicBonus    int           `json:"magic_bonus,omitempty"`
+	// StrengthRequirement is the STR score heavy armor needs to avoid the
+	// speed penalty; zero means none.
+	StrengthRequirement int `json:"strength_requirement,omitempty"`
+
+	// WeaponCategory is "simple" or "martial".
+	WeaponCategory string   `json:"weapon_category,omitempty"`
+	Damage         string   `json:"damage,omitempty"`
+	DamageType     string   `json:"damage_type,omitempty"`
+	Properties     []string `json:"properties,omitempty"`
+
+	// Charges is how many charges the item has left, for wands, staffs
+	// and the like, out of MaxCharges.
+	Charges    int `json:"charges,omitempty"`
+	MaxCharges int `json:"max_charges,omitempty"`
+	// Recharge is how spent charges return, e.g. "1d6+4 at dawn" or "all
+	// at dawn". See ParseRecharge.
+	Recharge string `json:"recharge,omitempty"`
+
+	RequiresAttunement bool `json:"requires_attunement,omitempty"`
+	Attuned            bool `json:"attuned,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	// Tags are free-form labels like "loot", "quest" or "sell".
+	Tags []string `json:"tags,omitempty"`
+}
+
+// HasProperty reports whether the item has a weapon property such as
+// "Two-Handed" or "Finesse", ignoring case.
+func (it Item) HasProperty(p string) bool {
+	return slices.ContainsFunc(it.Properties, func(q string) bool {
+		return strings.EqualFold(q, p)
+	})
+}
+
+// TotalWeight returns the weight of the whole stack.
+func (it Item) TotalWeight() float64 {
+	return it.Weight * float64(it.Quantity)
+}
+
+// HasTag reports whether the item carries the tag, ignoring case.
+func (it Item) HasTag(tag string) bool {
+	return slices.ContainsFunc(it.Tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
+
+// AddTag adds a tag if the item doesn't already have it.
+func (it *Item) AddTag(tag string) {
+	tag = strings.TrimSpace(tag)
+	if tag != "" && !it.HasTag(tag) {
+		it.Tags = append(it.Tags, tag)
+	}
+}
+
+// RemoveTag removes a tag.
+func (it *Item) RemoveTag(tag string) {
+	it.Tags = slices.DeleteFunc(it.Tags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
+
+// ParseStrengthRequirement reads an armor table's strength column, such as
+// "Str 13" or "STR 15". An empty string or "—" means no requirement.
+func ParseStrengthRequirement(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "—" || s == "-" {
+		return 0, nil
+	}
+	fields := strings.Fields(s)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "str") {
+		return 0, fmt.Errorf("invalid strength requirement %q", s)
+	}
+	n, err := strconv.Atoi(fields[1])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid strength requirement %q", s)
+	}
+	return n, nil
+}
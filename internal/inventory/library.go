@@ -0,0 +1,99 @@
+package inventory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// LibraryFile is the name of the personal item library in the config
+// directory.
+const LibraryFile = "items.json"
+
+// Library is the player's collection of custom items, shared between
+// characters so homebrew items only need entering once.
+type Library struct {
+	Items []Item `json:"items"`
+}
+
+// LoadLibrary reads the library at path. A missing file yields an empty
+// library.
+func LoadLibrary(path string) (*Library, error) {
+	lib := &Library{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lib, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read item library: %w", err)
+	}
+	if err := json.Unmarshal(data, lib); err != nil {
+		return nil, fmt.Errorf("failed to parse item library: %w", err)
+	}
+	return lib, nil
+}
+
+// Save writes the library to path, creating its directory.
+func (l *Library) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal item library: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write item library: %w", err)
+	}
+	return nil
+}
+
+// Find returns the named item.
+func (l *Library) Find(name string) (Item, bool) {
+	for _, it := range l.Items {
+		if strings.EqualFold(it.Name, name) {
+			return it, true
+		}
+	}
+	return Item{}, false
+}
+
+// Complete returns the first item, by name, whose name starts with prefix.
+func (l *Library) Complete(prefix string) (Item, bool) {
+	prefix = strings.ToLower(prefix)
+	for _, it := range l.Items {
+		if strings.HasPrefix(strings.ToLower(it.Name), prefix) {
+			return it, true
+		}
+	}
+	return Item{}, false
+}
+
+// Put adds an item or replaces the one with the same name. Library items
+// are templates, so quantity, attunement and tags aren't kept, and charges
+// are full.
+func (l *Library) Put(it Item) {
+	it.Quantity, it.Attuned, it.Tags = 0, false, nil
+	it.Charges = it.MaxCharges
+	i := slices.IndexFunc(l.Items, func(o Item) bool { return strings.EqualFold(o.Name, it.Name) })
+	if i >= 0 {
+		l.Items[i] = it
+		return
+	}
+	l.Items = append(l.Items, it)
+	slices.SortFunc(l.Items, func(a, b Item) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+}
+
+// Delete removes the named item, reporting whether it was there.
+func (l *Library) Delete(name string) bool {
+	n := len(l.Items)
+	l.Items = slices.DeleteFunc(l.Items, func(it Item) bool { return strings.EqualFold(it.Name, name) })
+	return len(l.Items) < n
+}
@@ -0,0 +1,90 @@
+package inventory
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Weapon categories, as used in Item.WeaponCategory and in weapon
+// proficiencies.
+const (
+	SimpleWeapons  = "simple"
+	MartialWeapons = "martial"
+)
+
+// ShieldProficiency is the armor proficiency that covers shields.
+const ShieldProficiency = "shields"
+
+// Proficiencies are the armor and weapons a character is trained with.
+// Armor entries are categories ("light", "medium", "heavy") or "shields";
+// weapon entries are categories ("simple", "martial") or weapon names.
+type Proficiencies struct {
+	Armor   []string `json:"armor,omitempty"`
+	Weapons []string `json:"weapons,omitempty"`
+}
+
+func hasEntry(entries []string, name string) bool {
+	return slices.ContainsFunc(entries, func(e string) bool {
+		// Class lists use plurals ("longswords"), items the singular.
+		e = strings.ToLower(e)
+		name = strings.ToLower(name)
+		return e == name || e == name+"s"
+	})
+}
+
+// WithArmor reports whether the character is proficient with a piece of
+// armor or a shield. Other items always count as proficient.
+func (p Proficiencies) WithArmor(it Item) bool {
+	switch it.Type {
+	case Armor:
+		return hasEntry(p.Armor, string(it.ArmorCategory))
+	case Shield:
+		return hasEntry(p.Armor, ShieldProficiency)
+	}
+	return true
+}
+
+// WithWeapon reports whether the character is proficient with a weapon,
+// either by its category or by name. Weapons with no category need the
+// name.
+func (p Proficiencies) WithWeapon(it Item) bool {
+	if it.Type != Weapon {
+		return true
+	}
+	if it.WeaponCategory != "" && hasEntry(p.Weapons, it.WeaponCategory) {
+		return true
+	}
+	return hasEntry(p.Weapons, it.Name)
+}
+
+// NonProficientArmor returns the equipped armor and shield the character
+// isn't proficient with.
+func (inv *Inventory) NonProficientArmor(p Proficiencies) []Item {
+	var out []Item
+	for _, slot := range []Slot{ArmorSlot, OffHand} {
+		if it, ok := inv.InSlot(slot); ok && !p.WithArmor(*it) {
+			out = append(out, *it)
+		}
+	}
+	return out
+}
+
+// ProficiencyWarnings describes equipped items the character isn't
+// proficient with, for the inventory view.
+func (inv *Inventory) ProficiencyWarnings(p Proficiencies) []string {
+	var warnings []string
+	for _, it := range inv.NonProficientArmor(p) {
+		warnings = append(warnings, fmt.Sprintf("Not proficient with %s: disadvantage on STR and DEX rolls, can't cast spells", it.Name))
+	}
+	seen := map[string]bool{}
+	for _, slot := range []Slot{MainHand, OffHand} {
+		it, ok := inv.InSlot(slot)
+		if !ok || seen[it.Name] || p.WithWeapon(*it) {
+			continue
+		}
+		seen[it.Name] = true
+		warnings = append(warnings, fmt.Sprintf("Not proficient with %s: no proficiency bonus to attack", it.Name))
+	}
+	return warnings
+}
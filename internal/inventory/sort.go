@@ -0,0 +1,102 @@
+package inventory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SortOrder is how the inventory view orders items.
+type SortOrder string
+
+const (
+	// SortCustom keeps the order the player arranged with Move.
+	SortCustom   SortOrder = "custom"
+	SortName     SortOrder = "name"
+	SortType     SortOrder = "type"
+	SortWeight   SortOrder = "weight"
+	SortQuantity SortOrder = "quantity"
+)
+
+// SortOrders lists the orders in the order the view cycles through them.
+var SortOrders = []SortOrder{SortCustom, SortName, SortType, SortWeight, SortQuantity}
+
+// Next returns the order after o, wrapping around.
+func (o SortOrder) Next() SortOrder {
+	i := slices.Index(SortOrders, o)
+	return SortOrders[(i+1)%len(SortOrders)]
+}
+
+// Sorted returns a copy of the items in the given order. Ties keep the
+// custom order; heavier and larger stacks come first.
+func (inv *Inventory) Sorted(order SortOrder) []Item {
+	items := slices.Clone(inv.Items)
+	byName := func(a, b Item) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+	switch order {
+	case SortName:
+		slices.SortStableFunc(items, byName)
+	case SortType:
+		slices.SortStableFunc(items, func(a, b Item) int {
+			return cmp.Or(strings.Compare(string(a.Type), string(b.Type)), byName(a, b))
+		})
+	case SortWeight:
+		slices.SortStableFunc(items, func(a, b Item) int {
+			return cmp.Compare(b.TotalWeight(), a.TotalWeight())
+		})
+	case SortQuantity:
+		slices.SortStableFunc(items, func(a, b Item) int {
+			return cmp.Compare(b.Quantity, a.Quantity)
+		})
+	}
+	return items
+}
+
+// Filter returns the items that carry every one of the tags. No tags
+// matches everything.
+func Filter(items []Item, tags ...string) []Item {
+	var out []Item
+	for _, it := range items {
+		if !slices.ContainsFunc(tags, func(t string) bool { return !it.HasTag(t) }) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// Tags returns every tag in use, sorted, for the view's filter list.
+func (inv *Inventory) Tags() []string {
+	seen := make(map[string]string)
+	for _, it := range inv.Items {
+		for _, t := range it.Tags {
+			key := strings.ToLower(t)
+			if _, ok := seen[key]; !ok {
+				seen[key] = t
+			}
+		}
+	}
+	tags := make([]string, 0, len(seen))
+	for _, t := range seen {
+		tags = append(tags, t)
+	}
+	slices.SortFunc(tags, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return tags
+}
+
+// Move shifts the named item by delta places in the custom order, stopping
+// at either end.
+func (inv *Inventory) Move(name string, delta int) error {
+	i := inv.index(name)
+	if i < 0 {
+		return fmt.Errorf("no item named %q", name)
+	}
+	j := min(max(i+delta, 0), len(inv.Items)-1)
+	it := inv.Items[i]
+	inv.Items = slices.Delete(inv.Items, i, i+1)
+	inv.Items = slices.Insert(inv.Items, j, it)
+	return nil
+}
@@ -0,0 +1,188 @@
+// Package journal keeps a character's adventure journal: session notes
+// written by the player and entries logged automatically for notable
+// events like level-ups, deaths and big loot.
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"sheet/internal/events"
+	"sheet/internal/storage"
+)
+
+// Kind is what an entry records.
+type Kind string
+
+const (
+	Note    Kind = "note"
+	LevelUp Kind = "level_up"
+	Death   Kind = "death"
+	Loot    Kind = "loot"
+)
+
+// BigLootGP is the value in gold pieces from which gained loot is logged.
+const BigLootGP = 100
+
+// Entry is one journal entry.
+type Entry struct {
+	Time time.Time `json:"time"`
+	Kind Kind      `json:"kind"`
+	Text string    `json:"text"`
+}
+
+// Journal is a character's entries, oldest first.
+type Journal struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Path returns where a character's journal is stored: next to the
+// character file as "name.journal.json", so it moves with the character
+// when archived.
+func Path(characterPath string) string {
+	return storage.SidecarPath(characterPath, storage.JournalSidecar)
+}
+
+// Load reads a journal. A missing file is an empty journal.
+func Load(path string) (*Journal, error) {
+	j := &Journal{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("failed to parse journal: %w", err)
+	}
+	return j, nil
+}
+
+// Save writes the journal.
+func (j *Journal) Save(path string) error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// Add appends an entry, keeping entries in time order.
+func (j *Journal) Add(e Entry) {
+	i, _ := slices.BinarySearchFunc(j.Entries, e.Time, func(x Entry, t time.Time) int {
+		if x.Time.After(t) {
+			return 1
+		}
+		return -1
+	})
+	j.Entries = slices.Insert(j.Entries, i, e)
+}
+
+// Matches returns the indexes of the entries whose text contains query,
+// ignoring case, newest first. An empty query matches every entry.
+func (j *Journal) Matches(query string) []int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var out []int
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(j.Entries[i].Text), query) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// Search returns the entries Matches finds.
+func (j *Journal) Search(query string) []Entry {
+	var out []Entry
+	for _, i := range j.Matches(query) {
+		out = append(out, j.Entries[i])
+	}
+	return out
+}
+
+// kindLabels head automatic entries in the Markdown export.
+var kindLabels = map[Kind]string{
+	LevelUp: "Level up",
+	Death:   "Death",
+	Loot:    "Loot",
+}
+
+// Markdown renders the journal for export, one section per day.
+func (j *Journal) Markdown(character string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s's Journal\n", character)
+	day := ""
+	for _, e := range j.Entries {
+		if d := e.Time.Format("2006-01-02"); d != day {
+			day = d
+			fmt.Fprintf(&b, "\n## %s\n\n", d)
+		}
+		text := strings.TrimSpace(e.Text)
+		if label, ok := kindLabels[e.Kind]; ok {
+			fmt.Fprintf(&b, "- *%s %s:* %s\n", e.Time.Format("15:04"), label, text)
+			continue
+		}
+		fmt.Fprintf(&b, "- *%s* %s\n", e.Time.Format("15:04"), strings.ReplaceAll(text, "\n", "\n  "))
+	}
+	return b.String()
+}
+
+// WriteMarkdown exports the journal next to the character file as
+// "name.journal.md" and returns its path.
+func (j *Journal) WriteMarkdown(characterPath, character string) (string, error) {
+	out := strings.TrimSuffix(characterPath, storage.Ext) + ".journal.md"
+	if err := os.WriteFile(out, []byte(j.Markdown(character)), 0o644); err != nil {
+		return "", fmt.Errorf("failed to export journal: %w", err)
+	}
+	return out, nil
+}
+
+// Attach logs the character's notable events from bus into the journal.
+// now stamps the entries; the returned function unsubscribes.
+func (j *Journal) Attach(bus *events.Bus, character string, now func() time.Time) func() {
+	log := func(k Kind, format string, args ...any) {
+		j.Add(Entry{Time: now(), Kind: k, Text: fmt.Sprintf(format, args...)})
+	}
+	unsubs := []func(){
+		bus.Subscribe(events.TypeLevelGained, func(e events.Event) {
+			if ev := e.(events.LevelGained); ev.Character == character {
+				log(LevelUp, "Reached %s level %d", ev.Class, ev.Level)
+			}
+		}),
+		bus.Subscribe(events.TypeCharacterDied, func(e events.Event) {
+			if ev := e.(events.CharacterDied); ev.Character == character {
+				if ev.Cause == "" {
+					log(Death, "Died")
+					return
+				}
+				log(Death, "Died: %s", ev.Cause)
+			}
+		}),
+		bus.Subscribe(events.TypeLootGained, func(e events.Event) {
+			ev := e.(events.LootGained)
+			if ev.Character != character || ev.ValueGP < BigLootGP {
+				return
+			}
+			item := ev.Item
+			if ev.Quantity > 1 {
+				item = fmt.Sprintf("%d x %s", ev.Quantity, ev.Item)
+			}
+			log(Loot, "Found %s worth %g gp", item, ev.ValueGP)
+		}),
+	}
+	return func() {
+		for _, u := range unsubs {
+			u()
+		}
+	}
+}
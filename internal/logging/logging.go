@@ -0,0 +1,66 @@
+// Package logging configures the structured debug log. The TUI owns the
+// terminal, so logs go to a file and are only written when debug mode is on.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sheet/internal/events"
+)
+
+// DefaultPath returns the debug log location under the user's cache
+// directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "sheet", "debug.log"), nil
+}
+
+// Setup returns a logger for the given mode. With debug off the logger
+// discards everything; with debug on it appends JSON records to path. The
+// returned close function must be called before exit.
+func Setup(debug bool, path string) (*slog.Logger, func() error, error) {
+	if !debug {
+		return Discard(), func() error { return nil }, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logger.Info("debug logging started", "pid", os.Getpid())
+	return logger, f.Close, nil
+}
+
+// Discard returns a logger that drops all records.
+func Discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// Timed logs how long an operation took when the returned function is
+// called. Typical use is defer logging.Timed(logger, "load spells")().
+func Timed(logger *slog.Logger, op string, args ...any) func() {
+	start := time.Now()
+	return func() {
+		logger.Debug(op, append(args, "duration", time.Since(start))...)
+	}
+}
+
+// AttachEvents logs every event published on bus at debug level.
+func AttachEvents(logger *slog.Logger, bus *events.Bus) func() {
+	return bus.SubscribeAll(func(e events.Event) {
+		logger.Debug("event", "type", string(e.Type()), "event", fmt.Sprintf("%+v", e))
+	})
+}
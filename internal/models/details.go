@@ -0,0 +1,103 @@
+// Package models holds the character data saved in character files. It
+// depends on no UI code, so storage and export can use it as well as the
+// views that edit it.
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/export"
+)
+
+// Feature is a class, race or background feature with its description.
+type Feature struct {
+	Name        string `json:"name"`
+	Source      string `json:"source,omitempty"`
+	Description string `json:"description"`
+}
+
+// Organization is a faction the character belongs to or works with, with
+// their standing in it.
+type Organization struct {
+	Name   string `json:"name"`
+	Rank   string `json:"rank,omitempty"`
+	Renown int    `json:"renown,omitempty"`
+}
+
+// Label formats the organization with its standing, e.g. "Harpers
+// (Watcher, renown 3)".
+func (o Organization) Label() string {
+	var parts []string
+	if o.Rank != "" {
+		parts = append(parts, o.Rank)
+	}
+	if o.Renown != 0 {
+		parts = append(parts, fmt.Sprintf("renown %d", o.Renown))
+	}
+	if len(parts) == 0 {
+		return o.Name
+	}
+	return o.Name + " (" + strings.Join(parts, ", ") + ")"
+}
+
+// CharacterDetails is the roleplaying side of a character.
+type CharacterDetails struct {
+	Alignment string `json:"alignment,omitempty"`
+	Age       string `json:"age,omitempty"`
+	Height    string `json:"height,omitempty"`
+	Weight    string `json:"weight,omitempty"`
+	Faith     string `json:"faith,omitempty"`
+
+	PersonalityTraits string `json:"personality_traits,omitempty"`
+	Ideals            string `json:"ideals,omitempty"`
+	Bonds             string `json:"bonds,omitempty"`
+	Flaws             string `json:"flaws,omitempty"`
+	Backstory         string `json:"backstory,omitempty"`
+	Appearance        string `json:"appearance,omitempty"`
+	// Allies are free-text notes on the character's friends and contacts;
+	// factions go in Organizations.
+	Allies        string         `json:"allies,omitempty"`
+	Features      []Feature      `json:"features,omitempty"`
+	Organizations []Organization `json:"organizations,omitempty"`
+	Languages     []string       `json:"languages,omitempty"`
+}
+
+// DescriptiveField is one of the character's descriptive fields. OneLine
+// fields are short values like an age rather than paragraphs.
+type DescriptiveField struct {
+	Label   string
+	Value   *string
+	OneLine bool
+}
+
+// DescriptiveFields returns the descriptive fields in display order.
+// Their values point into d.
+func (d *CharacterDetails) DescriptiveFields() []DescriptiveField {
+	return []DescriptiveField{
+		{"Alignment", &d.Alignment, true},
+		{"Age", &d.Age, true},
+		{"Height", &d.Height, true},
+		{"Weight", &d.Weight, true},
+		{"Faith", &d.Faith, true},
+		{"Appearance", &d.Appearance, false},
+	}
+}
+
+// ExportDetails returns the descriptive fields for export.Summary.
+func (d *CharacterDetails) ExportDetails() []export.Detail {
+	var out []export.Detail
+	for _, f := range d.DescriptiveFields() {
+		out = append(out, export.Detail{Label: f.Label, Value: *f.Value})
+	}
+	return out
+}
+
+// ExportOrganizations returns the organizations for export.Summary.
+func (d *CharacterDetails) ExportOrganizations() []export.Organization {
+	var out []export.Organization
+	for _, o := range d.Organizations {
+		out = append(out, export.Organization{Name: o.Name, Rank: o.Rank, Renown: o.Renown})
+	}
+	return out
+}
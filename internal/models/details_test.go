@@ -0,0 +1,40 @@
+package models
+
+import (
+	"slices"
+	"testing"
+
+	"sheet/internal/export"
+)
+
+func TestOrganizationLabel(t *testing.T) {
+	tests := []struct {
+		org  Organization
+		want string
+	}{
+		{Organization{Name: "Harpers"}, "Harpers"},
+		{Organization{Name: "Harpers", Rank: "Watcher"}, "Harpers (Watcher)"},
+		{Organization{Name: "Harpers", Rank: "Watcher", Renown: 3}, "Harpers (Watcher, renown 3)"},
+		{Organization{Name: "Harpers", Renown: 3}, "Harpers (renown 3)"},
+	}
+	for _, tt := range tests {
+		if got := tt.org.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestExportDetails(t *testing.T) {
+	d := CharacterDetails{Alignment: "Neutral", Age: "87", Appearance: "Tall", Allies: "Gandalf"}
+	want := []export.Detail{
+		{Label: "Alignment", Value: "Neutral"},
+		{Label: "Age", Value: "87"},
+		{Label: "Height"},
+		{Label: "Weight"},
+		{Label: "Faith"},
+		{Label: "Appearance", Value: "Tall"},
+	}
+	if got := d.ExportDetails(); !slices.Equal(got, want) {
+		t.Errorf("ExportDetails() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+// Package party holds utilities one character uses on others in the
+// party, such as granting temporary hit points. Changes reach the other
+// characters as events on the bus, which each character's session
+// applies.
+package party
+
+import (
+	"fmt"
+	"slices"
+
+	"sheet/internal/events"
+	"sheet/internal/rules"
+)
+
+// MaxInspired is how many creatures Inspiring Leader can affect.
+const MaxInspired = 6
+
+// InspiringLeaderTempHP is the temporary hit points the feat grants: the
+// speaker's level plus their Charisma modifier.
+func InspiringLeaderTempHP(level, chaMod int) int {
+	return max(level+chaMod, 0)
+}
+
+// Inspired lists who has gained Inspiring Leader's temporary hit points
+// from one speaker. A creature can't gain them again until it finishes a
+// short or long rest.
+type Inspired []string
+
+// Has reports whether character has been inspired since their last rest.
+func (in Inspired) Has(character string) bool {
+	return slices.Contains(in, character)
+}
+
+// Rested clears character after they finish a rest.
+func (in *Inspired) Rested(character string) {
+	*in = slices.DeleteFunc(*in, func(name string) bool { return name == character })
+}
+
+// TrackRests clears each character from inspired when their session
+// publishes events.RestFinished. The returned function unsubscribes.
+func TrackRests(bus *events.Bus, inspired *Inspired) func() {
+	return bus.Subscribe(events.TypeRestFinished, func(e events.Event) {
+		inspired.Rested(e.(events.RestFinished).Character)
+	})
+}
+
+// InspiringLeader grants the feat's temporary hit points to the chosen
+// party members, which may include the speaker, and records them in
+// inspired. Targets who were already inspired and haven't rested since
+// are skipped and returned. It returns the amount.
+func InspiringLeader(bus *events.Bus, speaker string, level, chaMod int, targets []string, inspired *Inspired) (amount int, skipped []string, err error) {
+	if len(targets) == 0 {
+		return 0, nil, fmt.Errorf("choose who hears the speech")
+	}
+	if len(targets) > MaxInspired {
+		return 0, nil, fmt.Errorf("inspiring leader affects at most %d creatures", MaxInspired)
+	}
+	var fresh []string
+	for _, t := range targets {
+		if inspired.Has(t) {
+			skipped = append(skipped, t)
+		} else {
+			fresh = append(fresh, t)
+		}
+	}
+	if len(fresh) == 0 {
+		return 0, skipped, fmt.Errorf("everyone chosen needs a short or long rest before hearing another speech")
+	}
+	amount = InspiringLeaderTempHP(level, chaMod)
+	GrantTempHP(bus, "Inspiring Leader ("+speaker+")", amount, fresh)
+	for _, t := range fresh {
+		if !inspired.Has(t) {
+			*inspired = append(*inspired, t)
+		}
+	}
+	return amount, skipped, nil
+}
+
+// GrantTempHP publishes temporary hit points for each target.
+func GrantTempHP(bus *events.Bus, source string, amount int, targets []string) {
+	targets = slices.Clone(targets)
+	slices.Sort(targets)
+	for _, t := range slices.Compact(targets) {
+		bus.Publish(events.TempHPGranted{Character: t, Amount: amount, Source: source})
+	}
+}
+
+// ReceiveTempHP applies temporary hit points granted to character on bus
+// to temp. They don't stack: a character without any gains them outright,
+// but replacing existing ones is the player's choice, so a larger grant
+// is passed to offer for the session to ask about. Smaller grants are
+// ignored. The returned function unsubscribes.
+func ReceiveTempHP(bus *events.Bus, character string, temp *rules.TempHP, offer func(rules.TempHP)) func() {
+	return bus.Subscribe(events.TypeTempHPGranted, func(e events.Event) {
+		ev := e.(events.TempHPGranted)
+		if ev.Character != character {
+			return
+		}
+		g := rules.TempHP{Amount: ev.Amount, Source: ev.Source}
+		switch apply, ask := temp.Offer(g); {
+		case apply:
+			*temp = g
+		case ask:
+			offer(g)
+		}
+	})
+}
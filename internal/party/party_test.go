@@ -0,0 +1,69 @@
+package party
+
+import (
+	"slices"
+	"testing"
+
+	"sheet/internal/events"
+)
+
+func TestInspiringLeader(t *testing.T) {
+	tests := []struct {
+		name        string
+		inspired    Inspired
+		targets     []string
+		wantAmount  int
+		wantSkipped []string
+		wantGranted []string
+		wantErr     bool
+	}{
+		{"everyone fresh", nil, []string{"Bree", "Ash"}, 7, nil, []string{"Ash", "Bree"}, false},
+		{"skips the already inspired", Inspired{"Ash"}, []string{"Ash", "Bree"}, 7, []string{"Ash"}, []string{"Bree"}, false},
+		{"all already inspired", Inspired{"Ash", "Bree"}, []string{"Bree", "Ash"}, 0, []string{"Bree", "Ash"}, nil, true},
+		{"no targets", nil, nil, 0, nil, nil, true},
+		{"too many", nil, []string{"a", "b", "c", "d", "e", "f", "g"}, 0, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := events.NewBus()
+			var granted []string
+			bus.Subscribe(events.TypeTempHPGranted, func(e events.Event) {
+				granted = append(granted, e.(events.TempHPGranted).Character)
+			})
+			inspired := slices.Clone(tt.inspired)
+			amount, skipped, err := InspiringLeader(bus, "Cyr", 4, 3, tt.targets, &inspired)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if amount != tt.wantAmount {
+				t.Errorf("amount = %d, want %d", amount, tt.wantAmount)
+			}
+			if !slices.Equal(skipped, tt.wantSkipped) {
+				t.Errorf("skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+			if !slices.Equal(granted, tt.wantGranted) {
+				t.Errorf("granted = %v, want %v", granted, tt.wantGranted)
+			}
+			for _, g := range granted {
+				if !inspired.Has(g) {
+					t.Errorf("%s not recorded as inspired", g)
+				}
+			}
+		})
+	}
+}
+
+func TestTrackRests(t *testing.T) {
+	bus := events.NewBus()
+	inspired := Inspired{"Ash", "Bree"}
+	stop := TrackRests(bus, &inspired)
+	defer stop()
+
+	bus.Publish(events.RestFinished{Character: "Ash"})
+	if inspired.Has("Ash") || !inspired.Has("Bree") {
+		t.Fatalf("after Ash rests, inspired = %v, want [Bree]", inspired)
+	}
+	if _, _, err := InspiringLeader(bus, "Cyr", 4, 3, []string{"Ash"}, &inspired); err != nil {
+		t.Fatalf("Ash can't be inspired after resting: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Package portent tracks a Divination wizard's Portent dice: d20s rolled
+// after each long rest and later used in place of any d20 roll the wizard
+// can see.
+package portent
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"sheet/internal/dice"
+)
+
+// Count returns how many Portent dice a wizard of the given level rolls:
+// two from 2nd level, three once Greater Portent arrives at 14th.
+func Count(wizardLevel int) int {
+	switch {
+	case wizardLevel >= 14:
+		return 3
+	case wizardLevel >= 2:
+		return 2
+	}
+	return 0
+}
+
+// Use records a Portent die replacing a roll.
+type Use struct {
+	Time time.Time `json:"time"`
+	// Roll is what the die replaced, e.g. "Goblin attack" or "DEX save".
+	Roll     string `json:"roll"`
+	Replaced int    `json:"replaced"`
+	Value    int    `json:"value"`
+}
+
+func (u Use) String() string {
+	return fmt.Sprintf("%s %s: %d replaced with %d", u.Time.Format("15:04"), u.Roll, u.Replaced, u.Value)
+}
+
+// Portent is the wizard's unused dice and the log of the ones used since
+// the last long rest.
+type Portent struct {
+	Dice []int `json:"dice"`
+	Log  []Use `json:"log,omitempty"`
+}
+
+// Roll replaces the dice with fresh ones after a long rest; unused dice
+// are lost. It returns the new dice.
+func (p *Portent) Roll(r *dice.Roller, wizardLevel int) []int {
+	p.Dice, p.Log = nil, nil
+	for range Count(wizardLevel) {
+		p.Dice = append(p.Dice, r.Roll(dice.D20(dice.Normal, 0)).Total)
+	}
+	return p.Dice
+}
+
+// Substitute spends the i-th die in place of a d20 that showed replaced
+// on the named roll, logging the swap.
+func (p *Portent) Substitute(i int, roll string, replaced int, now time.Time) (Use, error) {
+	if i < 0 || i >= len(p.Dice) {
+		return Use{}, fmt.Errorf("no portent die %d", i+1)
+	}
+	u := Use{Time: now, Roll: roll, Replaced: replaced, Value: p.Dice[i]}
+	p.Dice = slices.Delete(p.Dice, i, i+1)
+	p.Log = append(p.Log, u)
+	return u, nil
+}
+
+// String lists the unused dice for the sheet, e.g. "Portent: 17 4".
+func (p *Portent) String() string {
+	if len(p.Dice) == 0 {
+		return "Portent: none left"
+	}
+	parts := make([]string, len(p.Dice))
+	for i, d := range p.Dice {
+		parts[i] = fmt.Sprint(d)
+	}
+	return "Portent: " + strings.Join(parts, " ")
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"fmt"
+	"time"
+)
+
+// ShortRestLength is how long a short rest takes.
+const ShortRestLength = time.Hour
+
+// Benefit is one kind of recovery a rest grants.
+type Benefit string
+
+const (
+	// BenefitResources restores short-rest features and pact slots.
+	BenefitResources Benefit = "resources"
+	// BenefitHitDice spends the hit dice rolled during the rest and heals
+	// what they rolled.
+	BenefitHitDice Benefit = "hit_dice"
+	// BenefitAttunement makes the planned attunement changes.
+	BenefitAttunement Benefit = "attunement"
+)
+
+// benefits are applied in this order.
+var benefits = []Benefit{BenefitResources, BenefitHitDice, BenefitAttunement}
+
+// PartialRest is a house rule for interrupted rests: the fraction of the
+// rest that must pass before each benefit is granted. Benefits that
+// aren't listed need the whole rest, so the zero value is the PHB's
+// all-or-nothing rule.
+type PartialRest map[Benefit]float64
+
+// Grants reports whether a rest that ran for the given fraction of its
+// length grants the benefit.
+func (p PartialRest) Grants(b Benefit, done float64) bool {
+	if done >= 1 {
+		return true
+	}
+	need, ok := p[b]
+	return ok && done >= need
+}
+
+// Cancel abandons the rest without applying anything, as when an ambush
+// breaks it up before any benefit is earned.
+func (s *ShortRest) Cancel() {
+	s.reset()
+}
+
+// expire ends what only lasts until a short rest, then drops every
+// planned change. Only a rest that ran its full length gets here.
+func (s *ShortRest) expire() {
+	if s.temp != nil {
+		s.temp.ShortRest()
+	}
+	s.reset()
+}
+
+// reset drops every planned change.
+func (s *ShortRest) reset() {
+	s.ClearAttunement()
+	s.resources = nil
+	s.spends = nil
+	s.offered, s.riders = nil, nil
+}
+
+// Interrupt ends the rest after elapsed, applying only the benefits the
+// house rule grants for that much of a rest. It returns a line for each
+// benefit applied or lost.
+func (s *ShortRest) Interrupt(elapsed time.Duration, house PartialRest) ([]string, error) {
+	done := float64(elapsed) / float64(ShortRestLength)
+	var lines []string
+	for _, b := range benefits {
+		if !s.plans(b) {
+			continue
+		}
+		if !house.Grants(b, done) {
+			lines = append(lines, fmt.Sprintf("Lost: %s", b.label()))
+			continue
+		}
+		if err := s.apply(b); err != nil {
+			return lines, err
+		}
+		lines = append(lines, fmt.Sprintf("Applied: %s", b.label()))
+	}
+	if done >= 1 {
+		s.expire()
+	} else {
+		s.reset()
+	}
+	return lines, nil
+}
+
+func (b Benefit) label() string {
+	switch b {
+	case BenefitResources:
+		return "feature recovery"
+	case BenefitHitDice:
+		return "hit dice healing"
+	case BenefitAttunement:
+		return "attunement changes"
+	}
+	return string(b)
+}
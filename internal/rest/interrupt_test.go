@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"testing"
+	"time"
+
+	"sheet/internal/inventory"
+	"sheet/internal/rules"
+)
+
+func TestInterruptTempHP(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		expiry  rules.TempHPExpiry
+		want    int
+		wantUse int
+	}{
+		{"cut short", 30 * time.Minute, rules.TempHPShortRest, 5, 0},
+		{"full length", ShortRestLength, rules.TempHPShortRest, 0, 1},
+		{"overran", 90 * time.Minute, rules.TempHPShortRest, 0, 1},
+		{"lasts until a long rest", ShortRestLength, rules.TempHPLongRest, 5, 1},
+	}
+	for _, tt := range tests {
+		temp := rules.TempHP{Amount: 5, Expiry: tt.expiry}
+		r := &Resource{Name: "Second Wind", Max: 1, Recovery: OnShortRest}
+		s := NewShortRest(inventory.New())
+		s.SetTempHP(&temp)
+		s.Recover(r)
+		if _, err := s.Interrupt(tt.elapsed, nil); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if temp.Amount != tt.want {
+			t.Errorf("%s: temp HP = %d, want %d", tt.name, temp.Amount, tt.want)
+		}
+		if r.Remaining != tt.wantUse {
+			t.Errorf("%s: %s remaining = %d, want %d", tt.name, r.Name, r.Remaining, tt.wantUse)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Package rest handles short and long rests: what the character has left
+// before one and what each rest restores.
+package rest
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/inventory"
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+)
+
+// Recovery is the rest that restores a resource.
+type Recovery string
+
+const (
+	OnShortRest Recovery = "short"
+	OnLongRest  Recovery = "long"
+)
+
+// Resource is a limited-use pool such as hit dice or Channel Divinity.
+type Resource struct {
+	Name      string   `json:"name"`
+	Remaining int      `json:"remaining"`
+	Max       int      `json:"max"`
+	Recovery  Recovery `json:"recovery"`
+}
+
+// Spent reports whether any uses have been spent.
+func (r Resource) Spent() bool {
+	return r.Remaining < r.Max
+}
+
+func (r Resource) String() string {
+	return fmt.Sprintf("%s %d/%d", r.Name, r.Remaining, r.Max)
+}
+
+// LuckPoints returns the Lucky feat's pool: three luck points, regained
+// on a long rest.
+func LuckPoints() Resource {
+	return Resource{Name: "Luck points", Remaining: 3, Max: 3, Recovery: OnLongRest}
+}
+
+// Readiness is a snapshot of what the character has left for the day.
+type Readiness struct {
+	HP, MaxHP int
+	// Slots and MaxSlots are indexed by spell level - 1.
+	Slots, MaxSlots [9]int
+	// Pact holds warlock slots, which return on a short rest.
+	Pact      Resource
+	PactLevel int
+	HitDice   rules.HitDicePools
+	Features  []Resource
+	// Consumables are the items of type consumable still carried.
+	Consumables []inventory.Item
+}
+
+// Consumables returns the consumable items in inv.
+func Consumables(inv *inventory.Inventory) []inventory.Item {
+	var out []inventory.Item
+	for _, it := range inv.Items {
+		if it.Type == inventory.Consumable && it.Quantity > 0 {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+func (r Readiness) slotTotals() (remaining, total int) {
+	for i := range r.Slots {
+		remaining += r.Slots[i]
+		total += r.MaxSlots[i]
+	}
+	return remaining, total
+}
+
+// Advice suggests a rest: a long rest when most slots, hit dice or HP are
+// gone, a short rest when short-rest resources have been spent. Empty
+// means the character is in good shape.
+func (r Readiness) Advice() string {
+	remaining, maxSlots := r.slotTotals()
+	switch {
+	case r.MaxHP > 0 && r.HP*4 <= r.MaxHP && r.HitDice.Remaining() == 0:
+		return "Low on HP with no hit dice left: take a long rest."
+	case maxSlots > 0 && remaining*3 <= maxSlots:
+		return "Most spell slots are spent: consider a long rest."
+	case r.HitDice.Max() > 0 && r.HitDice.Remaining()*2 < r.HitDice.Max() && r.MaxHP > 0 && r.HP*2 < r.MaxHP:
+		return "Under half HP and hit dice: consider a long rest."
+	}
+	if r.Pact.Spent() {
+		return "Pact slots spent: a short rest restores them."
+	}
+	for _, f := range r.Features {
+		if f.Recovery == OnShortRest && f.Spent() {
+			return fmt.Sprintf("%s spent: a short rest restores it.", f.Name)
+		}
+	}
+	if r.MaxHP > 0 && r.HP*2 < r.MaxHP && r.HitDice.Remaining() > 0 {
+		return "Under half HP: a short rest lets you spend hit dice."
+	}
+	return ""
+}
+
+// View renders the summary.
+func (r Readiness) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString("Daily Readiness\n")
+	if r.MaxHP > 0 {
+		fmt.Fprintf(&b, "\nHP %d/%d\n", r.HP, r.MaxHP)
+	}
+	if r.HitDice.Max() > 0 {
+		fmt.Fprintf(&b, "Hit Dice %s\n", r.HitDice)
+	}
+
+	if _, maxSlots := r.slotTotals(); maxSlots > 0 {
+		b.WriteString("\nSpell Slots\n")
+		for i, n := range r.MaxSlots {
+			if n > 0 {
+				fmt.Fprintf(&b, "  Level %d: %d/%d\n", i+1, r.Slots[i], n)
+			}
+		}
+	}
+	if r.Pact.Max > 0 {
+		fmt.Fprintf(&b, "  Pact (level %d): %d/%d\n", r.PactLevel, r.Pact.Remaining, r.Pact.Max)
+	}
+
+	if len(r.Features) > 0 {
+		b.WriteString("\nFeatures\n")
+		for _, f := range r.Features {
+			fmt.Fprintf(&b, "  %s (%s rest)\n", f, f.Recovery)
+		}
+	}
+	if len(r.Consumables) > 0 {
+		b.WriteString("\nConsumables\n")
+		for _, it := range r.Consumables {
+			fmt.Fprintf(&b, "  %s %s%d\n", it.Name, g.Times, it.Quantity)
+		}
+	}
+	if advice := r.Advice(); advice != "" {
+		fmt.Fprintf(&b, "\n%s\n", advice)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
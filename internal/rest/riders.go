@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/rules"
+)
+
+// Rider is a party member's feature that adds to everyone's short rest,
+// like a bard's Song of Rest.
+type Rider struct {
+	Name string
+	// Source is the party member providing it.
+	Source string
+	// HitDieHealing is extra healing, in dice notation, for each character
+	// who spends hit dice during the rest. It is rolled once per
+	// character.
+	HitDieHealing string
+}
+
+func (r Rider) String() string {
+	return fmt.Sprintf("%s (%s, +%s)", r.Name, r.Source, r.HitDieHealing)
+}
+
+// Member is a party member as the rest flow sees it.
+type Member struct {
+	Name    string
+	Classes []rules.ClassLevel
+}
+
+// SongOfRest returns the rider of a bard at the given level: a d6 from
+// 2nd level, growing to a d12 at 17th.
+func SongOfRest(bard string, bardLevel int) (Rider, bool) {
+	var die int
+	switch {
+	case bardLevel >= 17:
+		die = 12
+	case bardLevel >= 13:
+		die = 10
+	case bardLevel >= 9:
+		die = 8
+	case bardLevel >= 2:
+		die = 6
+	default:
+		return Rider{}, false
+	}
+	return Rider{Name: "Song of Rest", Source: bard, HitDieHealing: fmt.Sprintf("1d%d", die)}, true
+}
+
+// riderSources turns a party member into the riders they provide. New
+// party-wide rest features are added here.
+var riderSources = []func(Member) (Rider, bool){
+	func(m Member) (Rider, bool) {
+		return SongOfRest(m.Name, classLevel(m.Classes, "Bard"))
+	},
+}
+
+func classLevel(classes []rules.ClassLevel, class string) int {
+	for _, c := range classes {
+		if strings.EqualFold(c.Class, class) {
+			return c.Level
+		}
+	}
+	return 0
+}
+
+// PartyRiders returns the riders the party provides. Only the best rider
+// of each name counts, since Song of Rest from two bards doesn't stack.
+func PartyRiders(party []Member) []Rider {
+	var out []Rider
+	for _, m := range party {
+		for _, src := range riderSources {
+			r, ok := src(m)
+			if !ok {
+				continue
+			}
+			i := slices.IndexFunc(out, func(o Rider) bool { return o.Name == r.Name })
+			switch {
+			case i < 0:
+				out = append(out, r)
+			case dice.MustParse(r.HitDieHealing).Max() > dice.MustParse(out[i].HitDieHealing).Max():
+				out[i] = r
+			}
+		}
+	}
+	return out
+}
+
+// riderHealing is a rider rolled during the rest.
+type riderHealing struct {
+	rider  Rider
+	healed int
+}
+
+// Offer makes the riders available for this rest.
+func (s *ShortRest) Offer(riders ...Rider) {
+	s.offered = append(s.offered, riders...)
+}
+
+// Offered returns the riders on offer that haven't been rolled yet.
+func (s *ShortRest) Offered() []Rider {
+	var out []Rider
+	for _, r := range s.offered {
+		if !slices.ContainsFunc(s.riders, func(h riderHealing) bool { return h.rider.Name == r.Name }) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// AcceptRider rolls the named rider's healing, which is applied with the
+// hit dice when the rest ends. It needs at least one hit die spent.
+func (s *ShortRest) AcceptRider(name string, r *dice.Roller) (dice.Result, error) {
+	i := slices.IndexFunc(s.Offered(), func(o Rider) bool { return strings.EqualFold(o.Name, name) })
+	if i < 0 {
+		return dice.Result{}, fmt.Errorf("%s is not on offer", name)
+	}
+	rider := s.Offered()[i]
+	if len(s.spends) == 0 {
+		return dice.Result{}, fmt.Errorf("%s needs a hit die spent first", rider.Name)
+	}
+	expr, err := dice.Parse(rider.HitDieHealing)
+	if err != nil {
+		return dice.Result{}, fmt.Errorf("%s: %w", rider.Name, err)
+	}
+	res := r.Roll(expr.Labeled(rider.Name))
+	s.riders = append(s.riders, riderHealing{rider: rider, healed: max(res.Total, 0)})
+	return res, nil
+}
@@ -0,0 +1,292 @@
+package rest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/inventory"
+	"sheet/internal/rules"
+)
+
+// ShortRest is a short rest being planned. Changes are collected while the
+// rest screen is open and only applied by Finish, so backing out of the
+// screen changes nothing. A rest cut short goes through Interrupt, which
+// applies what the table's house rule allows, or Cancel.
+type ShortRest struct {
+	inv *inventory.Inventory
+
+	// endAttune and beginAttune are the attunement changes. Attuning to an
+	// item, or voluntarily ending attunement, takes a short rest focused on
+	// that item, so each rest allows one of each.
+	endAttune   string
+	beginAttune string
+
+	// resources are restored when the rest ends.
+	resources []*Resource
+
+	// pools, hp and maxHP are where hit dice spent during the rest are
+	// taken from and healed into, set by SetHitDice.
+	pools  rules.HitDicePools
+	hp     *int
+	maxHP  int
+	spends []hitDieSpend
+
+	// offered are the party's riders for this rest; riders are the ones
+	// accepted and rolled.
+	offered []Rider
+	riders  []riderHealing
+
+	// slots and slotBase are the character's spell slots, whose Pact
+	// Magic slots come back when the rest ends; set by SetSpellSlots.
+	slots    *rules.SpellSlotState
+	slotBase rules.SlotBase
+
+	// temp is the character's temporary hit points, ended by the rest if
+	// they only last until one; set by SetTempHP.
+	temp *rules.TempHP
+}
+
+// hitDieSpend is one hit die rolled during the rest.
+type hitDieSpend struct {
+	die, healed int
+}
+
+// NewShortRest starts planning a short rest for the character owning inv.
+func NewShortRest(inv *inventory.Inventory) *ShortRest {
+	return &ShortRest{inv: inv}
+}
+
+// Recover plans to restore the short-rest resources among rs, such as
+// pact slots and Channel Divinity. Long-rest resources are ignored.
+func (s *ShortRest) Recover(rs ...*Resource) {
+	for _, r := range rs {
+		if r.Recovery == OnShortRest {
+			s.resources = append(s.resources, r)
+		}
+	}
+}
+
+// SetHitDice lets the rest spend hit dice from pools, healing hp up to
+// maxHP when the rest ends.
+func (s *ShortRest) SetHitDice(pools rules.HitDicePools, hp *int, maxHP int) {
+	s.pools, s.hp, s.maxHP = pools, hp, maxHP
+}
+
+// SetSpellSlots lets the rest regain the Pact Magic slots of slots.
+func (s *ShortRest) SetSpellSlots(slots *rules.SpellSlotState, base rules.SlotBase) {
+	s.slots, s.slotBase = slots, base
+}
+
+// pactSpent reports whether Pact Magic slots have been spent.
+func (s *ShortRest) pactSpent() bool {
+	return s.slots != nil && s.slots.PactRemaining < s.slots.PactTotal(s.slotBase)
+}
+
+// SetTempHP lets the rest end temp when its temporary hit points only
+// last until a short rest.
+func (s *ShortRest) SetTempHP(temp *rules.TempHP) {
+	s.temp = temp
+}
+
+// Available returns how many dice of size die are left to spend this
+// rest.
+func (s *ShortRest) Available(die int) int {
+	p, ok := s.pools.Pool(die)
+	if !ok {
+		return 0
+	}
+	n := p.Remaining
+	for _, sp := range s.spends {
+		if sp.die == die {
+			n--
+		}
+	}
+	return n
+}
+
+// SpendHitDie rolls a hit die from the chosen pool plus the CON modifier.
+// The die is deducted and the healing applied when the rest ends.
+func (s *ShortRest) SpendHitDie(die int, r *dice.Roller, conMod int) (dice.Result, error) {
+	if s.hp == nil {
+		return dice.Result{}, fmt.Errorf("hit dice are not set up for this rest")
+	}
+	if s.Available(die) < 1 {
+		return dice.Result{}, fmt.Errorf("no d%d hit dice left", die)
+	}
+	expr, err := dice.Parse(fmt.Sprintf("1d%d", die))
+	if err != nil {
+		return dice.Result{}, err
+	}
+	res := r.Roll(expr.Plus(conMod))
+	s.spends = append(s.spends, hitDieSpend{die: die, healed: max(res.Total, 0)})
+	return res, nil
+}
+
+// Healing returns the hit points the dice spent so far, and any riders
+// rolled with them, will restore.
+func (s *ShortRest) Healing() int {
+	total := s.diceHealing()
+	for _, h := range s.riders {
+		total += h.healed
+	}
+	return total
+}
+
+func (s *ShortRest) diceHealing() int {
+	total := 0
+	for _, sp := range s.spends {
+		total += sp.healed
+	}
+	return total
+}
+
+// EndAttunement plans to end attunement to the named item.
+func (s *ShortRest) EndAttunement(name string) error {
+	it, ok := s.inv.Find(name)
+	switch {
+	case !ok:
+		return fmt.Errorf("no item named %q", name)
+	case !it.Attuned:
+		return fmt.Errorf("not attuned to %s", it.Name)
+	case s.endAttune != "" && !strings.EqualFold(s.endAttune, it.Name):
+		return fmt.Errorf("already ending attunement to %s this rest", s.endAttune)
+	}
+	s.endAttune = it.Name
+	return nil
+}
+
+// BeginAttunement plans to attune to the named item, counting any
+// attunement ended this rest against the limit.
+func (s *ShortRest) BeginAttunement(name string) error {
+	it, ok := s.inv.Find(name)
+	switch {
+	case !ok:
+		return fmt.Errorf("no item named %q", name)
+	case !it.RequiresAttunement:
+		return fmt.Errorf("%s does not require attunement", it.Name)
+	case it.Attuned:
+		return fmt.Errorf("already attuned to %s", it.Name)
+	case s.beginAttune != "" && !strings.EqualFold(s.beginAttune, it.Name):
+		return fmt.Errorf("already attuning to %s this rest", s.beginAttune)
+	}
+	count := len(s.inv.Attuned())
+	if s.endAttune != "" {
+		count--
+	}
+	if count >= inventory.MaxAttuned {
+		return fmt.Errorf("already attuned to %d items; end one attunement first", inventory.MaxAttuned)
+	}
+	s.beginAttune = it.Name
+	return nil
+}
+
+// ClearAttunement drops the planned attunement changes.
+func (s *ShortRest) ClearAttunement() {
+	s.endAttune, s.beginAttune = "", ""
+}
+
+// Attuned returns the names of the items the character will be attuned to
+// after the rest.
+func (s *ShortRest) Attuned() []string {
+	var names []string
+	for _, it := range s.inv.Attuned() {
+		if !strings.EqualFold(it.Name, s.endAttune) {
+			names = append(names, it.Name)
+		}
+	}
+	if s.beginAttune != "" {
+		names = append(names, s.beginAttune)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Summary describes the planned changes for the confirmation screen.
+func (s *ShortRest) Summary() []string {
+	var lines []string
+	for _, r := range s.resources {
+		if r.Spent() {
+			lines = append(lines, fmt.Sprintf("Recover: %s (%d/%d)", r.Name, r.Remaining, r.Max))
+		}
+	}
+	if s.pactSpent() {
+		lines = append(lines, fmt.Sprintf("Recover: Pact slots (%d/%d)", s.slots.PactRemaining, s.slots.PactTotal(s.slotBase)))
+	}
+	if len(s.spends) > 0 {
+		lines = append(lines, fmt.Sprintf("Hit dice: %d spent, +%d HP", len(s.spends), s.diceHealing()))
+	}
+	for _, h := range s.riders {
+		lines = append(lines, fmt.Sprintf("%s from %s: +%d HP", h.rider.Name, h.rider.Source, h.healed))
+	}
+	if s.endAttune != "" {
+		lines = append(lines, "End attunement: "+s.endAttune)
+	}
+	if s.beginAttune != "" {
+		lines = append(lines, "Attune to: "+s.beginAttune)
+	}
+	if s.temp != nil && s.temp.Amount > 0 && s.temp.Expiry == rules.TempHPShortRest {
+		lines = append(lines, "Ends: "+s.temp.String())
+	}
+	return lines
+}
+
+// Finish completes the rest and applies every planned change. Use
+// Interrupt or Cancel when the rest is cut short.
+func (s *ShortRest) Finish() error {
+	for _, b := range benefits {
+		if err := s.apply(b); err != nil {
+			return err
+		}
+	}
+	s.expire()
+	return nil
+}
+
+// plans reports whether the rest has any changes of kind b to apply.
+func (s *ShortRest) plans(b Benefit) bool {
+	switch b {
+	case BenefitResources:
+		return len(s.resources) > 0 || s.pactSpent()
+	case BenefitHitDice:
+		return len(s.spends) > 0
+	case BenefitAttunement:
+		return s.endAttune != "" || s.beginAttune != ""
+	}
+	return false
+}
+
+func (s *ShortRest) apply(b Benefit) error {
+	switch b {
+	case BenefitResources:
+		for _, r := range s.resources {
+			r.Remaining = r.Max
+		}
+		if s.slots != nil {
+			s.slots.ShortRest(s.slotBase)
+		}
+	case BenefitHitDice:
+		for _, sp := range s.spends {
+			if p, ok := s.pools.Pool(sp.die); ok && p.Remaining > 0 {
+				p.Remaining--
+			}
+			*s.hp = min(*s.hp+sp.healed, s.maxHP)
+		}
+		for _, h := range s.riders {
+			*s.hp = min(*s.hp+h.healed, s.maxHP)
+		}
+	case BenefitAttunement:
+		if s.endAttune != "" {
+			if err := s.inv.Unattune(s.endAttune); err != nil {
+				return err
+			}
+		}
+		if s.beginAttune != "" {
+			if err := s.inv.Attune(s.beginAttune); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package rules implements 5e rules calculations that don't depend on UI
+// state: modifiers, proficiency, spell slots, multiclassing and the like.
+package rules
+
+import "strings"
+
+// Ability is one of the six ability scores.
+type Ability string
+
+const (
+	Strength     Ability = "STR"
+	Dexterity    Ability = "DEX"
+	Constitution Ability = "CON"
+	Intelligence Ability = "INT"
+	Wisdom       Ability = "WIS"
+	Charisma     Ability = "CHA"
+)
+
+// MaxAbilityScore is as high as an Ability Score Improvement can raise a
+// score.
+const MaxAbilityScore = 20
+
+// Abilities lists the six abilities in sheet order.
+var Abilities = []Ability{Strength, Dexterity, Constitution, Intelligence, Wisdom, Charisma}
+
+// ParseAbility accepts an abbreviation or full name in any case.
+func ParseAbility(s string) (Ability, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "str", "strength":
+		return Strength, true
+	case "dex", "dexterity":
+		return Dexterity, true
+	case "con", "constitution":
+		return Constitution, true
+	case "int", "intelligence":
+		return Intelligence, true
+	case "wis", "wisdom":
+		return Wisdom, true
+	case "cha", "charisma":
+		return Charisma, true
+	}
+	return "", false
+}
+
+// Scores maps each ability to its score.
+type Scores map[Ability]int
+
+// Modifier returns the ability modifier for a score.
+func Modifier(score int) int {
+	// Integer division truncates toward zero, so shift before dividing to
+	// floor negative results (score 9 is -1, not 0).
+	return (score+10)/2 - 10
+}
+
+// Modifier returns the modifier for ability a.
+func (s Scores) Modifier(a Ability) int {
+	return Modifier(s[a])
+}
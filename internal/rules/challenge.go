@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// challengeXP maps challenge ratings to the XP a monster is worth.
+var challengeXP = map[string]int{
+	"0": 10, "1/8": 25, "1/4": 50, "1/2": 100,
+	"1": 200, "2": 450, "3": 700, "4": 1100, "5": 1800,
+	"6": 2300, "7": 2900, "8": 3900, "9": 5000, "10": 5900,
+	"11": 7200, "12": 8400, "13": 10000, "14": 11500, "15": 13000,
+	"16": 15000, "17": 18000, "18": 20000, "19": 22000, "20": 25000,
+	"21": 33000, "22": 41000, "23": 50000, "24": 62000, "25": 75000,
+	"26": 90000, "27": 105000, "28": 120000, "29": 135000, "30": 155000,
+}
+
+// ChallengeXP returns the XP for defeating a monster of challenge rating
+// cr, written as in a stat block ("1/4", "5").
+func ChallengeXP(cr string) (int, error) {
+	xp, ok := challengeXP[strings.TrimSpace(cr)]
+	if !ok {
+		return 0, fmt.Errorf("unknown challenge rating %q", cr)
+	}
+	return xp, nil
+}
+
+// ChallengeValue returns cr as a number, so "1/4" sorts below "1".
+func ChallengeValue(cr string) (float64, error) {
+	cr = strings.TrimSpace(cr)
+	if num, den, ok := strings.Cut(cr, "/"); ok {
+		n, err1 := strconv.Atoi(num)
+		d, err2 := strconv.Atoi(den)
+		if err1 != nil || err2 != nil || d == 0 {
+			return 0, fmt.Errorf("invalid challenge rating %q", cr)
+		}
+		return float64(n) / float64(d), nil
+	}
+	v, err := strconv.Atoi(cr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid challenge rating %q", cr)
+	}
+	return float64(v), nil
+}
@@ -0,0 +1,29 @@
+package rules
+
+// CritVariant is how a critical hit's damage is rolled.
+type CritVariant string
+
+const (
+	// CritDoubleDice is the PHB rule: roll the attack's damage dice twice.
+	CritDoubleDice CritVariant = "double_dice"
+	// CritMaxPlusRoll is the house rule of adding the dice's maximum to a
+	// normal roll, so crits never roll low.
+	CritMaxPlusRoll CritVariant = "max_plus_roll"
+	// CritBrutal doubles the dice and also rolls on the brutal critical
+	// hit table for an extra effect.
+	CritBrutal CritVariant = "brutal"
+)
+
+// CritVariants lists the variants in the order settings cycle through them.
+var CritVariants = []CritVariant{CritDoubleDice, CritMaxPlusRoll, CritBrutal}
+
+// String returns the short name shown in roll history, e.g. "max + roll".
+func (v CritVariant) String() string {
+	switch v {
+	case CritMaxPlusRoll:
+		return "max + roll"
+	case CritBrutal:
+		return "brutal"
+	}
+	return "double dice"
+}
@@ -0,0 +1,55 @@
+package rules
+
+import "strings"
+
+// DamageType is one of the 5e damage types.
+type DamageType string
+
+const (
+	Acid        DamageType = "acid"
+	Bludgeoning DamageType = "bludgeoning"
+	Cold        DamageType = "cold"
+	Fire        DamageType = "fire"
+	Force       DamageType = "force"
+	Lightning   DamageType = "lightning"
+	Necrotic    DamageType = "necrotic"
+	Piercing    DamageType = "piercing"
+	Poison      DamageType = "poison"
+	Psychic     DamageType = "psychic"
+	Radiant     DamageType = "radiant"
+	Slashing    DamageType = "slashing"
+	Thunder     DamageType = "thunder"
+)
+
+// DamageTypes lists every damage type alphabetically.
+var DamageTypes = []DamageType{
+	Acid, Bludgeoning, Cold, Fire, Force, Lightning, Necrotic,
+	Piercing, Poison, Psychic, Radiant, Slashing, Thunder,
+}
+
+// ParseDamageType matches a damage type name in any case.
+func ParseDamageType(s string) (DamageType, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, dt := range DamageTypes {
+		if string(dt) == s {
+			return dt, true
+		}
+	}
+	return "", false
+}
+
+// FindDamageTypes returns the damage types mentioned in free text such as a
+// spell description or "1d8 slashing + 1d6 fire", in order of appearance.
+func FindDamageTypes(text string) []DamageType {
+	var found []DamageType
+	seen := make(map[DamageType]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r < 'a' || r > 'z'
+	}) {
+		if dt, ok := ParseDamageType(word); ok && !seen[dt] {
+			seen[dt] = true
+			found = append(found, dt)
+		}
+	}
+	return found
+}
@@ -0,0 +1,87 @@
+package rules
+
+// DeathSaveDC is the number a death saving throw must meet.
+const DeathSaveDC = 10
+
+// DeathSaves are a dying character's death saving throw tallies.
+type DeathSaves struct {
+	Successes int `json:"successes"`
+	Failures  int `json:"failures"`
+}
+
+// Stable reports whether three successes have been marked.
+func (d DeathSaves) Stable() bool {
+	return d.Successes >= 3
+}
+
+// Dead reports whether three failures have been marked.
+func (d DeathSaves) Dead() bool {
+	return d.Failures >= 3
+}
+
+// Succeed marks a success, up to three.
+func (d *DeathSaves) Succeed() {
+	d.Successes = min(d.Successes+1, 3)
+}
+
+// Fail marks n failures, up to three.
+func (d *DeathSaves) Fail(n int) {
+	d.Failures = min(d.Failures+n, 3)
+}
+
+// Reset clears both tallies, as when the character regains hit points.
+func (d *DeathSaves) Reset() {
+	*d = DeathSaves{}
+}
+
+// DeathSaveOutcome is what one death saving throw did.
+type DeathSaveOutcome struct {
+	Natural, Total int
+	// Revived is set on a natural 20: the character regains 1 HP and the
+	// tallies reset.
+	Revived bool
+	// Failures is how many failures were marked: two on a natural 1.
+	Failures int
+	Stable   bool
+	Dead     bool
+}
+
+func (o DeathSaveOutcome) String() string {
+	switch {
+	case o.Revived:
+		return "natural 20: regain 1 HP"
+	case o.Dead:
+		return "third failure: dead"
+	case o.Natural == 1:
+		return "natural 1: two failures"
+	case o.Stable:
+		return "third success: stable"
+	case o.Failures > 0:
+		return "failure"
+	}
+	return "success"
+}
+
+// Apply records a death saving throw with the given natural d20 and total
+// (bonuses like Bless count towards the DC; only the natural die decides
+// criticals).
+func (d *DeathSaves) Apply(natural, total int) DeathSaveOutcome {
+	o := DeathSaveOutcome{Natural: natural, Total: total}
+	switch {
+	case natural == 20:
+		d.Reset()
+		o.Revived = true
+		return o
+	case natural == 1:
+		o.Failures = 2
+	case total < DeathSaveDC:
+		o.Failures = 1
+	}
+	if o.Failures > 0 {
+		d.Fail(o.Failures)
+	} else {
+		d.Succeed()
+	}
+	o.Stable, o.Dead = d.Stable(), d.Dead()
+	return o
+}
@@ -0,0 +1,16 @@
+package rules
+
+// Edition is the rules edition a table plays by. It decides where ability
+// score increases come from at character creation.
+type Edition string
+
+const (
+	// Edition2014 takes fixed increases from the race and subrace.
+	Edition2014 Edition = "2014"
+	// Edition2024 takes a +2/+1 or +1/+1/+1 from the background's three
+	// abilities.
+	Edition2024 Edition = "2024"
+)
+
+// Editions lists the editions in the order settings cycle through them.
+var Editions = []Edition{Edition2024, Edition2014}
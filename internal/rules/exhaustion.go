@@ -0,0 +1,74 @@
+package rules
+
+import "strings"
+
+// MaxExhaustion is the exhaustion level at which a creature dies.
+const MaxExhaustion = 6
+
+// exhaustionEffects describes each exhaustion level; effects are
+// cumulative.
+var exhaustionEffects = [MaxExhaustion + 1]string{
+	"",
+	"Disadvantage on ability checks",
+	"Speed halved",
+	"Disadvantage on attack rolls and saving throws",
+	"Hit point maximum halved",
+	"Speed reduced to 0",
+	"Death",
+}
+
+// ClampExhaustion keeps an exhaustion level between 0 and MaxExhaustion.
+func ClampExhaustion(level int) int {
+	return min(max(level, 0), MaxExhaustion)
+}
+
+// ExhaustionEffects lists the effects in force at an exhaustion level.
+func ExhaustionEffects(level int) []string {
+	level = ClampExhaustion(level)
+	var out []string
+	for l := 1; l <= level; l++ {
+		out = append(out, exhaustionEffects[l])
+	}
+	return out
+}
+
+// ExhaustedSpeed applies exhaustion to a walking speed.
+func ExhaustedSpeed(speed, level int) int {
+	switch {
+	case level >= 5:
+		return 0
+	case level >= 2:
+		return speed / 2
+	}
+	return speed
+}
+
+// ExhaustedMaxHP applies exhaustion to a hit point maximum.
+func ExhaustedMaxHP(maxHP, level int) int {
+	if level >= 4 {
+		return maxHP / 2
+	}
+	return maxHP
+}
+
+// ExhaustionAfterLongRest returns the level after a long rest with food
+// and drink, which removes one level.
+func ExhaustionAfterLongRest(level int) int {
+	return ClampExhaustion(level - 1)
+}
+
+// ExhaustionFromConditions converts the old representation, one
+// "Exhaustion" condition per level, into a level. It returns the level and
+// the remaining conditions.
+func ExhaustionFromConditions(conditions []string) (int, []string) {
+	level := 0
+	var rest []string
+	for _, c := range conditions {
+		if strings.EqualFold(strings.TrimSpace(c), "Exhaustion") {
+			level++
+			continue
+		}
+		rest = append(rest, c)
+	}
+	return ClampExhaustion(level), rest
+}
@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+
+	"sheet/internal/dice"
+)
+
+// AbilityMethod is how a character's ability scores were generated.
+type AbilityMethod string
+
+const (
+	StandardArray AbilityMethod = "standard_array"
+	PointBuy      AbilityMethod = "point_buy"
+	RolledScores  AbilityMethod = "rolled"
+	ManualScores  AbilityMethod = "manual"
+)
+
+// AbilityRules are the table's options for generating ability scores. The
+// defaults are the PHB's; each can be changed as a house rule.
+type AbilityRules struct {
+	// PointBudget is the points to spend in point buy, and Costs the
+	// price of each score from the lowest up. Scores outside Costs can't
+	// be bought.
+	PointBudget int         `json:"point_budget"`
+	Costs       map[int]int `json:"costs,omitempty"`
+	// Array is the set of scores to assign with the standard array.
+	Array []int `json:"array,omitempty"`
+	// Roll is rolled once per score, e.g. "4d6kh3" or "4d6r1kh3" to
+	// reroll 1s.
+	Roll string `json:"roll"`
+	// MinRolledTotal rerolls the whole set when the six scores add up to
+	// less; zero accepts any set.
+	MinRolledTotal int `json:"min_rolled_total,omitempty"`
+}
+
+// maxSetRerolls bounds how often a rolled set is thrown out.
+const maxSetRerolls = 100
+
+// DefaultAbilityRules returns the PHB rules: 27-point buy from 8 to 15,
+// the 15, 14, 13, 12, 10, 8 array, and 4d6 dropping the lowest.
+func DefaultAbilityRules() AbilityRules {
+	return AbilityRules{
+		PointBudget: 27,
+		Costs:       map[int]int{8: 0, 9: 1, 10: 2, 11: 3, 12: 4, 13: 5, 14: 7, 15: 9},
+		Array:       []int{15, 14, 13, 12, 10, 8},
+		Roll:        "4d6kh3",
+	}
+}
+
+// Validate checks the rules themselves, for settings loaded from disk.
+func (r AbilityRules) Validate() error {
+	if r.PointBudget < 0 {
+		return fmt.Errorf("point budget can't be negative")
+	}
+	if len(r.Costs) == 0 {
+		return fmt.Errorf("point buy needs a cost for at least one score")
+	}
+	if len(r.Array) != len(Abilities) {
+		return fmt.Errorf("the array needs %d scores, got %d", len(Abilities), len(r.Array))
+	}
+	if _, err := dice.Parse(r.Roll); err != nil {
+		return fmt.Errorf("roll: %w", err)
+	}
+	return nil
+}
+
+// ScoreRange returns the lowest and highest scores point buy allows.
+func (r AbilityRules) ScoreRange() (lo, hi int) {
+	first := true
+	for s := range r.Costs {
+		if first || s < lo {
+			lo = s
+		}
+		if first || s > hi {
+			hi = s
+		}
+		first = false
+	}
+	return lo, hi
+}
+
+// PointsSpent returns the point-buy cost of scores, before racial
+// increases, or an error for a score that can't be bought.
+func (r AbilityRules) PointsSpent(scores Scores) (int, error) {
+	total := 0
+	for _, a := range Abilities {
+		cost, ok := r.Costs[scores[a]]
+		if !ok {
+			lo, hi := r.ScoreRange()
+			return 0, fmt.Errorf("%s %d can't be bought; point buy allows %d to %d", a, scores[a], lo, hi)
+		}
+		total += cost
+	}
+	return total, nil
+}
+
+// ValidatePointBuy checks that scores can be bought within the budget.
+func (r AbilityRules) ValidatePointBuy(scores Scores) error {
+	spent, err := r.PointsSpent(scores)
+	if err != nil {
+		return err
+	}
+	if spent > r.PointBudget {
+		return fmt.Errorf("%d points spent, budget is %d", spent, r.PointBudget)
+	}
+	return nil
+}
+
+// ValidateArray checks that scores use each value of the array once.
+func (r AbilityRules) ValidateArray(scores Scores) error {
+	got := make([]int, 0, len(Abilities))
+	for _, a := range Abilities {
+		got = append(got, scores[a])
+	}
+	want := slices.Clone(r.Array)
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		return fmt.Errorf("scores must use the array %v once each", r.Array)
+	}
+	return nil
+}
+
+// ValidateScores checks scores against the rules for the method they were
+// generated with. Rolled and manual scores are taken as entered.
+func (r AbilityRules) ValidateScores(method AbilityMethod, scores Scores) error {
+	switch method {
+	case PointBuy:
+		return r.ValidatePointBuy(scores)
+	case StandardArray:
+		return r.ValidateArray(scores)
+	}
+	return nil
+}
+
+// RollSet rolls six scores, rerolling the whole set while it totals less
+// than MinRolledTotal. It returns every roll of the set kept, for the
+// breakdown.
+func (r AbilityRules) RollSet(roller *dice.Roller) ([]dice.Result, error) {
+	expr, err := dice.Parse(r.Roll)
+	if err != nil {
+		return nil, fmt.Errorf("roll: %w", err)
+	}
+	for range maxSetRerolls {
+		set, total := make([]dice.Result, 0, len(Abilities)), 0
+		for range Abilities {
+			res := roller.Roll(expr)
+			set = append(set, res)
+			total += res.Total
+		}
+		if total >= r.MinRolledTotal {
+			return set, nil
+		}
+	}
+	return nil, fmt.Errorf("no set of %s reached a total of %d in %d tries", r.Roll, r.MinRolledTotal, maxSetRerolls)
+}
@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Gestalt is a character under the gestalt house rule: two classes that
+// advance together, one level of each per character level, taking the
+// better of each benefit.
+type Gestalt [2]ClassLevel
+
+// NewGestalt returns a 1st-level gestalt character of classes a and b.
+func NewGestalt(a, b string) (Gestalt, error) {
+	g := Gestalt{{Class: a, Level: 1}, {Class: b, Level: 1}}
+	return g, g.Validate()
+}
+
+// Validate checks that the two classes differ and are at the same level.
+// Gestalt characters don't multiclass further.
+func (g Gestalt) Validate() error {
+	if strings.EqualFold(g[0].Class, g[1].Class) {
+		return fmt.Errorf("gestalt needs two different classes, not %s twice", g[0].Class)
+	}
+	if IsSidekickClass(g[0].Class) || IsSidekickClass(g[1].Class) {
+		return fmt.Errorf("sidekick classes can't be gestalt")
+	}
+	if g[0].Level != g[1].Level {
+		return fmt.Errorf("%s is level %d but %s is level %d; gestalt classes advance together",
+			g[0].Class, g[0].Level, g[1].Class, g[1].Level)
+	}
+	return nil
+}
+
+// Classes returns both classes, for everything that works per class, like
+// features, saving throws and spells known.
+func (g Gestalt) Classes() []ClassLevel {
+	return []ClassLevel{g[0], g[1]}
+}
+
+// Level returns the character level, which proficiency bonus and XP use.
+func (g Gestalt) Level() int {
+	return max(g[0].Level, g[1].Level)
+}
+
+// LevelUp returns g with a level added to both classes.
+func (g Gestalt) LevelUp() Gestalt {
+	g[0].Level++
+	g[1].Level++
+	return g
+}
+
+// HitDie returns the larger of the two classes' hit dice, which every
+// level uses.
+func (g Gestalt) HitDie() int {
+	return max(HitDie(g[0].Class), HitDie(g[1].Class))
+}
+
+// HitDieTrack returns the class whose hit die the character uses, at the
+// character level, for the functions that take a class list: PoolsFor
+// and MaxHP.
+func (g Gestalt) HitDieTrack() []ClassLevel {
+	c := g[0]
+	if HitDie(g[1].Class) > HitDie(c.Class) {
+		c = g[1]
+	}
+	return []ClassLevel{{Class: c.Class, Level: g.Level()}}
+}
+
+// MaxHP is MaxHP with the larger hit die at every level.
+func (g Gestalt) MaxHP(conMod int, r HPRounding, mods []HPModifier) int {
+	return MaxHP(g.HitDieTrack(), conMod, r, mods)
+}
+
+// Pools returns full hit dice: one die of the larger size per level.
+func (g Gestalt) Pools() HitDicePools {
+	return PoolsFor(g.HitDieTrack())
+}
+
+// CasterLevel returns the better of the two classes' spellcaster levels.
+// The classes' slots don't add up as a multiclass character's would.
+func (g Gestalt) CasterLevel(e Edition) int {
+	return max(CasterLevel([]ClassLevel{g[0]}, e), CasterLevel([]ClassLevel{g[1]}, e))
+}
+
+// SpellSlots returns the spell slots of the better caster, excluding Pact
+// Magic.
+func (g Gestalt) SpellSlots(e Edition) [9]int {
+	return SlotsForCasterLevel(g.CasterLevel(e))
+}
@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// hitDie is the hit die size of each class.
+var hitDie = map[string]int{
+	"barbarian": 12,
+	"fighter":   10, "paladin": 10, "ranger": 10,
+	"artificer": 8, "bard": 8, "cleric": 8, "druid": 8, "monk": 8, "rogue": 8, "warlock": 8,
+	"sorcerer": 6, "wizard": 6,
+}
+
+// HitDie returns the hit die size of a class, 8 for unknown (homebrew)
+// classes.
+func HitDie(class string) int {
+	if d, ok := hitDie[strings.ToLower(class)]; ok {
+		return d
+	}
+	return 8
+}
+
+// HitDiePool is the hit dice of one size a character has, e.g. their d10s
+// from fighter levels.
+type HitDiePool struct {
+	Die       int `json:"die"`
+	Remaining int `json:"remaining"`
+	Max       int `json:"max"`
+}
+
+func (p HitDiePool) String() string {
+	return fmt.Sprintf("d%d %d/%d", p.Die, p.Remaining, p.Max)
+}
+
+// HitDicePools are a character's hit dice, largest die first.
+type HitDicePools []HitDiePool
+
+// PoolsFor returns full hit dice pools for a character's classes, one per
+// die size.
+func PoolsFor(classes []ClassLevel) HitDicePools {
+	var pools HitDicePools
+	for _, c := range classes {
+		d := HitDie(c.Class)
+		if i := slices.IndexFunc(pools, func(p HitDiePool) bool { return p.Die == d }); i >= 0 {
+			pools[i].Max += c.Level
+			pools[i].Remaining += c.Level
+			continue
+		}
+		pools = append(pools, HitDiePool{Die: d, Remaining: c.Level, Max: c.Level})
+	}
+	slices.SortFunc(pools, func(a, b HitDiePool) int { return cmp.Compare(b.Die, a.Die) })
+	return pools
+}
+
+// Resize updates the pools after the character's classes change, keeping
+// the dice already spent.
+func (ps HitDicePools) Resize(classes []ClassLevel) HitDicePools {
+	out := PoolsFor(classes)
+	for i := range out {
+		if old, ok := ps.Pool(out[i].Die); ok {
+			spent := old.Max - old.Remaining
+			out[i].Remaining = max(out[i].Max-spent, 0)
+		}
+	}
+	return out
+}
+
+// Pool returns the pool of die size d.
+func (ps HitDicePools) Pool(d int) (*HitDiePool, bool) {
+	i := slices.IndexFunc(ps, func(p HitDiePool) bool { return p.Die == d })
+	if i < 0 {
+		return nil, false
+	}
+	return &ps[i], true
+}
+
+// Remaining returns the hit dice left across all pools.
+func (ps HitDicePools) Remaining() int {
+	n := 0
+	for _, p := range ps {
+		n += p.Remaining
+	}
+	return n
+}
+
+// Max returns the total hit dice across all pools.
+func (ps HitDicePools) Max() int {
+	n := 0
+	for _, p := range ps {
+		n += p.Max
+	}
+	return n
+}
+
+// SetRemaining edits the dice left in a pool, for corrections by hand.
+func (ps HitDicePools) SetRemaining(d, n int) error {
+	p, ok := ps.Pool(d)
+	switch {
+	case !ok:
+		return fmt.Errorf("no d%d hit dice", d)
+	case n < 0 || n > p.Max:
+		return fmt.Errorf("d%d hit dice must be between 0 and %d", d, p.Max)
+	}
+	p.Remaining = n
+	return nil
+}
+
+// LongRest regains spent hit dice up to half the character's total (at
+// least one), largest dice first, and returns how many were regained.
+func (ps HitDicePools) LongRest() int {
+	budget := max(ps.Max()/2, 1)
+	regained := 0
+	for i := range ps {
+		n := min(ps[i].Max-ps[i].Remaining, budget-regained)
+		ps[i].Remaining += n
+		regained += n
+	}
+	return regained
+}
+
+func (ps HitDicePools) String() string {
+	parts := make([]string, len(ps))
+	for i, p := range ps {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,76 @@
+package rules
+
+// HPRounding is how the fixed hit points per level are rounded from a hit
+// die's average.
+type HPRounding string
+
+const (
+	// RoundUp is the PHB fixed value: half the die plus one (6 for a d10).
+	RoundUp HPRounding = "up"
+	// RoundDown is the house rule of taking half the die (5 for a d10).
+	RoundDown HPRounding = "down"
+)
+
+// FixedHP returns the hit points gained per level without rolling.
+func FixedHP(die int, r HPRounding) int {
+	if r == RoundDown {
+		return die / 2
+	}
+	return die/2 + 1
+}
+
+// HPModifier adds hit points for every character level, like the Tough
+// feat (+2) or the Hill Dwarf's Dwarven Toughness (+1). It applies
+// retroactively: taking Tough at 8th level adds 16 HP at once.
+type HPModifier struct {
+	Source   string `json:"source"`
+	PerLevel int    `json:"per_level"`
+}
+
+// PerLevelHP totals the modifiers' hit points per level.
+func PerLevelHP(mods []HPModifier) int {
+	n := 0
+	for _, m := range mods {
+		n += m.PerLevel
+	}
+	return n
+}
+
+// LevelUpHP returns the hit points gained for one level: rolled (or the
+// fixed value when rolled is 0) plus CON, at least 1, plus the per-level
+// modifiers. The minimum applies before the modifiers, so a low CON never
+// swallows Tough's bonus.
+func LevelUpHP(die, rolled, conMod int, r HPRounding, mods []HPModifier) int {
+	base := rolled
+	if base == 0 {
+		base = FixedHP(die, r)
+	}
+	return max(base+conMod, 1) + PerLevelHP(mods)
+}
+
+// MaxHP recomputes a character's hit point maximum using fixed hit points:
+// the full hit die of the first class at 1st level, the fixed value for
+// every other level, CON for every level and the per-level modifiers.
+// classes[0] must be the starting class. Each level gains at least 1 HP
+// before the modifiers, as in LevelUpHP.
+func MaxHP(classes []ClassLevel, conMod int, r HPRounding, mods []HPModifier) int {
+	total := 0
+	for i, c := range classes {
+		die := HitDie(c.Class)
+		for l := 1; l <= c.Level; l++ {
+			if i == 0 && l == 1 {
+				total += max(die+conMod, 1) + PerLevelHP(mods)
+				continue
+			}
+			total += LevelUpHP(die, 0, conMod, r, mods)
+		}
+	}
+	return total
+}
+
+// HPModifierDelta is how much the maximum changes when a per-level
+// modifier is gained (or lost, with a negative PerLevel) at a total
+// level.
+func HPModifierDelta(m HPModifier, totalLevel int) int {
+	return m.PerLevel * totalLevel
+}
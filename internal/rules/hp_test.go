@@ -0,0 +1,48 @@
+package rules
+
+import "testing"
+
+var tough = []HPModifier{{Source: "Tough", PerLevel: 2}}
+
+func TestLevelUpHP(t *testing.T) {
+	tests := []struct {
+		name                string
+		die, rolled, conMod int
+		mods                []HPModifier
+		want                int
+	}{
+		{"fixed", 10, 0, 2, nil, 8},
+		{"rolled", 10, 3, 1, nil, 4},
+		{"minimum 1", 6, 1, -3, nil, 1},
+		{"tough", 8, 0, 1, tough, 8},
+		{"tough survives low CON", 6, 1, -3, tough, 3},
+	}
+	for _, tt := range tests {
+		if got := LevelUpHP(tt.die, tt.rolled, tt.conMod, RoundUp, tt.mods); got != tt.want {
+			t.Errorf("%s: LevelUpHP = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMaxHPMatchesModifierDelta(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []ClassLevel
+		conMod  int
+	}{
+		{"fighter", []ClassLevel{{Class: "Fighter", Level: 5}}, 2},
+		{"wizard low CON", []ClassLevel{{Class: "Wizard", Level: 4}}, -4},
+		{"multiclass low CON", []ClassLevel{{Class: "Sorcerer", Level: 1}, {Class: "Wizard", Level: 2}}, -5},
+	}
+	for _, tt := range tests {
+		level := 0
+		for _, c := range tt.classes {
+			level += c.Level
+		}
+		without := MaxHP(tt.classes, tt.conMod, RoundUp, nil)
+		with := MaxHP(tt.classes, tt.conMod, RoundUp, tough)
+		if got, want := with-without, HPModifierDelta(tough[0], level); got != want {
+			t.Errorf("%s: Tough adds %d HP, HPModifierDelta says %d", tt.name, got, want)
+		}
+	}
+}
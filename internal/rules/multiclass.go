@@ -0,0 +1,118 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClassLevel is the number of levels a character has in one class.
+type ClassLevel struct {
+	Class    string `json:"class"`
+	Subclass string `json:"subclass,omitempty"`
+	Level    int    `json:"level"`
+}
+
+// TotalLevel returns the character level across all classes.
+func TotalLevel(classes []ClassLevel) int {
+	total := 0
+	for _, c := range classes {
+		total += c.Level
+	}
+	return total
+}
+
+// ProficiencyBonus returns the proficiency bonus for a total character level.
+func ProficiencyBonus(totalLevel int) int {
+	if totalLevel < 1 {
+		totalLevel = 1
+	}
+	return 2 + (totalLevel-1)/4
+}
+
+// prerequisite is a set of minimum scores; AnyOf means one of them is enough.
+type prerequisite struct {
+	Abilities []Ability
+	AnyOf     bool
+}
+
+const multiclassMinimum = 13
+
+var multiclassPrerequisites = map[string]prerequisite{
+	"artificer": {Abilities: []Ability{Intelligence}},
+	"barbarian": {Abilities: []Ability{Strength}},
+	"bard":      {Abilities: []Ability{Charisma}},
+	"cleric":    {Abilities: []Ability{Wisdom}},
+	"druid":     {Abilities: []Ability{Wisdom}},
+	"fighter":   {Abilities: []Ability{Strength, Dexterity}, AnyOf: true},
+	"monk":      {Abilities: []Ability{Dexterity, Wisdom}},
+	"paladin":   {Abilities: []Ability{Strength, Charisma}},
+	"ranger":    {Abilities: []Ability{Dexterity, Wisdom}},
+	"rogue":     {Abilities: []Ability{Dexterity}},
+	"sorcerer":  {Abilities: []Ability{Charisma}},
+	"warlock":   {Abilities: []Ability{Charisma}},
+	"wizard":    {Abilities: []Ability{Intelligence}},
+}
+
+// MulticlassPrerequisite describes the ability minimums for entering or
+// leaving a class, e.g. "STR 13 or DEX 13".
+func MulticlassPrerequisite(class string) string {
+	p, ok := multiclassPrerequisites[strings.ToLower(class)]
+	if !ok {
+		return ""
+	}
+	parts := make([]string, len(p.Abilities))
+	for i, a := range p.Abilities {
+		parts[i] = fmt.Sprintf("%s %d", a, multiclassMinimum)
+	}
+	if p.AnyOf {
+		return strings.Join(parts, " or ")
+	}
+	return strings.Join(parts, " and ")
+}
+
+func meetsPrerequisite(class string, scores Scores) bool {
+	p, ok := multiclassPrerequisites[strings.ToLower(class)]
+	if !ok {
+		// Homebrew classes without a known prerequisite are allowed.
+		return true
+	}
+	for _, a := range p.Abilities {
+		met := scores[a] >= multiclassMinimum
+		if p.AnyOf && met {
+			return true
+		}
+		if !p.AnyOf && !met {
+			return false
+		}
+	}
+	return !p.AnyOf
+}
+
+// CanMulticlass checks the PHB rule that a character must meet the
+// prerequisites of both every class they already have and the new one. The
+// returned error names the first unmet requirement. Sidekicks keep to
+// their one sidekick class.
+func CanMulticlass(current []ClassLevel, newClass string, scores Scores) error {
+	if IsSidekickClass(newClass) {
+		return fmt.Errorf("%s is a sidekick class and can't be multiclassed into", newClass)
+	}
+	for _, c := range current {
+		if IsSidekickClass(c.Class) {
+			return fmt.Errorf("%s sidekicks can't multiclass", c.Class)
+		}
+	}
+	for _, c := range current {
+		if strings.EqualFold(c.Class, newClass) {
+			return fmt.Errorf("already has levels in %s", c.Class)
+		}
+	}
+	for _, c := range current {
+		if !meetsPrerequisite(c.Class, scores) {
+			return fmt.Errorf("leaving %s requires %s", c.Class, MulticlassPrerequisite(c.Class))
+		}
+	}
+	if !meetsPrerequisite(newClass, scores) {
+		return fmt.Errorf("%s requires %s", newClass, MulticlassPrerequisite(newClass))
+	}
+	return nil
+}
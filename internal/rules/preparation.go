@@ -0,0 +1,28 @@
+package rules
+
+import "strings"
+
+// preparedDivisor is how a preparing class's level counts towards the
+// number of spells it prepares: full level for full casters, half for
+// half casters.
+var preparedDivisor = map[string]int{
+	"artificer": 2, "cleric": 1, "druid": 1, "paladin": 2, "wizard": 1,
+}
+
+// Prepares reports whether a class prepares spells from its list each day
+// rather than knowing a fixed set.
+func Prepares(class string) bool {
+	_, ok := preparedDivisor[strings.ToLower(class)]
+	return ok
+}
+
+// MaxPrepared returns how many spells a class prepares at a class level
+// with the given spellcasting ability modifier, at least one. Classes that
+// don't prepare spells return zero.
+func MaxPrepared(class string, level, abilityMod int) int {
+	div, ok := preparedDivisor[strings.ToLower(class)]
+	if !ok || level < 1 {
+		return 0
+	}
+	return max(level/div+abilityMod, 1)
+}
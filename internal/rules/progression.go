@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+)
+
+// earlySubclasses are the classes that choose their subclass before 3rd
+// level under the 2014 rules. In 2024 every class chooses at 3rd.
+var earlySubclasses = map[string]int{
+	"cleric": 1, "sorcerer": 1, "warlock": 1,
+	"druid": 2, "wizard": 2,
+}
+
+// SubclassLevel returns the class level a class chooses its subclass at.
+func SubclassLevel(class string, edition Edition) int {
+	if edition == Edition2014 {
+		if l, ok := earlySubclasses[strings.ToLower(class)]; ok {
+			return l
+		}
+	}
+	return 3
+}
+
+// asiLevels are the class levels that grant an Ability Score Improvement
+// or feat; extraASILevels are the additional ones of some classes.
+var (
+	asiLevels      = []int{4, 8, 12, 16, 19}
+	extraASILevels = map[string][]int{
+		"fighter": {6, 14},
+		"rogue":   {10},
+	}
+)
+
+// ASIsAt returns how many Ability Score Improvements a class has granted
+// by level.
+func ASIsAt(class string, level int) int {
+	n := 0
+	for _, l := range slices.Concat(asiLevels, extraASILevels[strings.ToLower(class)]) {
+		if l <= level {
+			n++
+		}
+	}
+	return n
+}
+
+// classSkillChoices is how many skills a class chooses at 1st level,
+// where it isn't two.
+var classSkillChoices = map[string]int{
+	"bard": 3, "ranger": 3, "rogue": 4,
+}
+
+// ClassSkillChoices returns how many skill proficiencies a character
+// starting in class chooses from the class's list.
+func ClassSkillChoices(class string) int {
+	if n, ok := classSkillChoices[strings.ToLower(class)]; ok {
+		return n
+	}
+	return 2
+}
@@ -0,0 +1,48 @@
+package rules
+
+import "slices"
+
+// SavingThrows records save proficiencies along with what granted each one
+// ("Fighter", "Resilient (CON)"), for the breakdown popup.
+type SavingThrows map[Ability][]string
+
+// Grant adds proficiency in a's saves from source. Granting the same source
+// twice is a no-op.
+func (s SavingThrows) Grant(a Ability, source string) {
+	if !slices.Contains(s[a], source) {
+		s[a] = append(s[a], source)
+	}
+}
+
+// Revoke removes source's grant for a.
+func (s SavingThrows) Revoke(a Ability, source string) {
+	s[a] = slices.DeleteFunc(s[a], func(src string) bool { return src == source })
+	if len(s[a]) == 0 {
+		delete(s, a)
+	}
+}
+
+// Proficient reports whether the character is proficient in a's saves.
+func (s SavingThrows) Proficient(a Ability) bool {
+	return len(s[a]) > 0
+}
+
+// Sources lists what granted proficiency in a's saves.
+func (s SavingThrows) Sources(a Ability) []string {
+	return s[a]
+}
+
+// FeatRecord is a feat a character has taken, with the ability chosen for
+// feats that ask for one.
+type FeatRecord struct {
+	Name    string  `json:"name"`
+	Ability Ability `json:"ability,omitempty"`
+}
+
+// Label formats the feat with its chosen ability, e.g. "Resilient (CON)".
+func (f FeatRecord) Label() string {
+	if f.Ability == "" {
+		return f.Name
+	}
+	return f.Name + " (" + string(f.Ability) + ")"
+}
@@ -0,0 +1,29 @@
+package rules
+
+import "strings"
+
+// sidekickClasses are the sidekick classes from Tasha's Cauldron of
+// Everything, taken by DM-run companions instead of a player class.
+var sidekickClasses = map[string]bool{
+	"expert": true, "spellcaster": true, "warrior": true,
+}
+
+// IsSidekickClass reports whether class is a sidekick class.
+func IsSidekickClass(class string) bool {
+	return sidekickClasses[strings.ToLower(class)]
+}
+
+// sizeHitDie is the hit die of a creature of each size, which is what a
+// sidekick's levels use.
+var sizeHitDie = map[string]int{
+	"tiny": 4, "small": 6, "medium": 8, "large": 10, "huge": 12, "gargantuan": 20,
+}
+
+// SidekickHitDie returns the hit die a sidekick of the given size gains
+// per level, 8 for an unknown size.
+func SidekickHitDie(size string) int {
+	if d, ok := sizeHitDie[strings.ToLower(size)]; ok {
+		return d
+	}
+	return 8
+}
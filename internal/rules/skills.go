@@ -0,0 +1,111 @@
+package rules
+
+import "strings"
+
+// Skill is one of the 18 standard skills.
+type Skill struct {
+	Name    string
+	Ability Ability
+}
+
+// Skills lists the standard skills alphabetically, as the Skills panel
+// shows them.
+var Skills = []Skill{
+	{"Acrobatics", Dexterity},
+	{"Animal Handling", Wisdom},
+	{"Arcana", Intelligence},
+	{"Athletics", Strength},
+	{"Deception", Charisma},
+	{"History", Intelligence},
+	{"Insight", Wisdom},
+	{"Intimidation", Charisma},
+	{"Investigation", Intelligence},
+	{"Medicine", Wisdom},
+	{"Nature", Intelligence},
+	{"Perception", Wisdom},
+	{"Performance", Charisma},
+	{"Persuasion", Charisma},
+	{"Religion", Intelligence},
+	{"Sleight of Hand", Dexterity},
+	{"Stealth", Dexterity},
+	{"Survival", Wisdom},
+}
+
+// FindSkill looks a skill up by name in any case.
+func FindSkill(name string) (Skill, bool) {
+	for _, s := range Skills {
+		if strings.EqualFold(s.Name, strings.TrimSpace(name)) {
+			return s, true
+		}
+	}
+	return Skill{}, false
+}
+
+// Proficiency is the degree of training in a skill, save or tool.
+type Proficiency int
+
+const (
+	NotProficient Proficiency = iota
+	Proficient
+	Expertise
+)
+
+func (p Proficiency) String() string {
+	switch p {
+	case Proficient:
+		return "proficient"
+	case Expertise:
+		return "expertise"
+	}
+	return "none"
+}
+
+// Next cycles none → proficient → expertise → none, the order the Skills
+// panel toggles through.
+func (p Proficiency) Next() Proficiency {
+	return (p + 1) % 3
+}
+
+// Bonus returns the proficiency contribution for a given proficiency bonus.
+func (p Proficiency) Bonus(profBonus int) int {
+	switch p {
+	case Proficient:
+		return profBonus
+	case Expertise:
+		return profBonus * 2
+	}
+	return 0
+}
+
+// CheckModifier returns the total modifier for a check or save made with an
+// ability score at the given proficiency.
+func CheckModifier(score int, p Proficiency, profBonus int) int {
+	return Modifier(score) + p.Bonus(profBonus)
+}
+
+// ProficiencyChange records a manual edit to a proficiency, so that edits
+// made outside creation and level-up (corrections, feat-granted skills) can
+// be shown and undone.
+type ProficiencyChange struct {
+	Name   string      `json:"name"`
+	From   Proficiency `json:"from"`
+	To     Proficiency `json:"to"`
+	Reason string      `json:"reason,omitempty"`
+}
+
+// Toggle advances the proficiency for name in profs and returns the change
+// to confirm or record. The map is not modified; call Apply once the user
+// has confirmed.
+func Toggle(profs map[string]Proficiency, name string) ProficiencyChange {
+	from := profs[name]
+	return ProficiencyChange{Name: name, From: from, To: from.Next()}
+}
+
+// Apply stores the change in profs, removing entries that drop to none.
+func (c ProficiencyChange) Apply(profs map[string]Proficiency) {
+	if c.To == NotProficient {
+		delete(profs, c.Name)
+		return
+	}
+	profs[c.Name] = c.To
+}
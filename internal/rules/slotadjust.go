@@ -0,0 +1,186 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SlotBase is the spell slots a character's classes give: the shared
+// table's slots and the Warlock's Pact Magic slots, which are a separate
+// pool regained on a short rest.
+type SlotBase struct {
+	Slots     [9]int
+	Pact      int
+	PactLevel int
+}
+
+// SlotBaseFor returns the slots a character's classes give under the
+// edition's rules.
+func SlotBaseFor(classes []ClassLevel, e Edition) SlotBase {
+	b := SlotBase{Slots: SpellSlots(classes, e)}
+	for _, c := range classes {
+		if CasterTypeOf(c.Class, c.Subclass) == PactCaster {
+			b.Pact, b.PactLevel = PactSlots(c.Level)
+		}
+	}
+	return b
+}
+
+// SlotAdjustment changes the number of spell slots beyond what the
+// character's classes give, e.g. +1 1st-level slot from a boon. Pact
+// adjustments change the Pact Magic slots and have no level of their own.
+type SlotAdjustment struct {
+	Level  int    `json:"level,omitempty"`
+	Pact   bool   `json:"pact,omitempty"`
+	Delta  int    `json:"delta"`
+	Source string `json:"source"`
+}
+
+func (a SlotAdjustment) String() string {
+	return fmt.Sprintf("%+d (%s)", a.Delta, a.Source)
+}
+
+// SpellSlotState is a character's spell slots beyond what the class table
+// derives: the slots left of each level and of Pact Magic, and any
+// adjustments to the totals, each with the source the player gave for it.
+type SpellSlotState struct {
+	// Remaining is indexed like SpellSlots, index 0 being 1st level.
+	Remaining     [9]int           `json:"remaining"`
+	PactRemaining int              `json:"pact_remaining,omitempty"`
+	Adjustments   []SlotAdjustment `json:"adjustments,omitempty"`
+}
+
+// Totals returns the slots per level for base with the adjustments
+// applied. Totals never go below zero.
+func (s SpellSlotState) Totals(base SlotBase) [9]int {
+	slots := base.Slots
+	for _, a := range s.Adjustments {
+		if !a.Pact && a.Level >= 1 && a.Level <= 9 {
+			slots[a.Level-1] += a.Delta
+		}
+	}
+	for i := range slots {
+		slots[i] = max(slots[i], 0)
+	}
+	return slots
+}
+
+// PactTotal returns the Pact Magic slots for base with the adjustments
+// applied.
+func (s SpellSlotState) PactTotal(base SlotBase) int {
+	n := base.Pact
+	for _, a := range s.Adjustments {
+		if a.Pact {
+			n += a.Delta
+		}
+	}
+	return max(n, 0)
+}
+
+// AdjustmentsAt returns the adjustments to slots of a level, or to the
+// Pact Magic slots when pact is set.
+func (s SpellSlotState) AdjustmentsAt(level int, pact bool) []SlotAdjustment {
+	var out []SlotAdjustment
+	for _, a := range s.Adjustments {
+		if a.Pact == pact && (pact || a.Level == level) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Adjust adds delta slots of a level from source, on top of any earlier
+// adjustment from the same source; a net change of zero removes the
+// adjustment. Remaining slots follow the total, so a new slot is ready to
+// use and a removed one is taken from those left.
+func (s *SpellSlotState) Adjust(base SlotBase, level, delta int, source string) error {
+	if level < 1 || level > 9 {
+		return fmt.Errorf("spell slot level must be between 1 and 9")
+	}
+	return s.adjust(base, SlotAdjustment{Level: level, Delta: delta, Source: source})
+}
+
+// AdjustPact is Adjust for the Pact Magic slots, e.g. +1 from a Rod of
+// the Pact Keeper.
+func (s *SpellSlotState) AdjustPact(base SlotBase, delta int, source string) error {
+	return s.adjust(base, SlotAdjustment{Pact: true, Delta: delta, Source: source})
+}
+
+func (s *SpellSlotState) adjust(base SlotBase, adj SlotAdjustment) error {
+	adj.Source = strings.TrimSpace(adj.Source)
+	switch {
+	case adj.Source == "":
+		return errors.New("a slot adjustment needs a source")
+	case adj.Delta == 0:
+		return nil
+	}
+	remaining, total := s.pool(base, adj)
+	before := total()
+	i := slices.IndexFunc(s.Adjustments, func(a SlotAdjustment) bool {
+		return a.Pact == adj.Pact && a.Level == adj.Level && strings.EqualFold(a.Source, adj.Source)
+	})
+	switch {
+	case i < 0:
+		s.Adjustments = append(s.Adjustments, adj)
+	case s.Adjustments[i].Delta+adj.Delta == 0:
+		s.Adjustments = slices.Delete(s.Adjustments, i, i+1)
+	default:
+		s.Adjustments[i].Delta += adj.Delta
+	}
+	after := total()
+	*remaining = min(max(*remaining+after-before, 0), after)
+	return nil
+}
+
+// pool returns the remaining count an adjustment changes and a function
+// computing its total.
+func (s *SpellSlotState) pool(base SlotBase, adj SlotAdjustment) (*int, func() int) {
+	if adj.Pact {
+		return &s.PactRemaining, func() int { return s.PactTotal(base) }
+	}
+	return &s.Remaining[adj.Level-1], func() int { return s.Totals(base)[adj.Level-1] }
+}
+
+// RemoveAdjustment removes an adjustment by its index in Adjustments.
+func (s *SpellSlotState) RemoveAdjustment(base SlotBase, i int) {
+	if i < 0 || i >= len(s.Adjustments) {
+		return
+	}
+	a := s.Adjustments[i]
+	a.Delta = -a.Delta
+	s.adjust(base, a)
+}
+
+// SetRemaining edits the slots left of a level, for corrections by hand.
+func (s *SpellSlotState) SetRemaining(base SlotBase, level, n int) error {
+	if level < 1 || level > 9 {
+		return fmt.Errorf("spell slot level must be between 1 and 9")
+	}
+	if total := s.Totals(base)[level-1]; n < 0 || n > total {
+		return fmt.Errorf("level %d slots must be between 0 and %d", level, total)
+	}
+	s.Remaining[level-1] = n
+	return nil
+}
+
+// SetPactRemaining edits the Pact Magic slots left.
+func (s *SpellSlotState) SetPactRemaining(base SlotBase, n int) error {
+	if total := s.PactTotal(base); n < 0 || n > total {
+		return fmt.Errorf("pact slots must be between 0 and %d", total)
+	}
+	s.PactRemaining = n
+	return nil
+}
+
+// ShortRest regains the Pact Magic slots.
+func (s *SpellSlotState) ShortRest(base SlotBase) {
+	s.PactRemaining = s.PactTotal(base)
+}
+
+// LongRest regains every slot.
+func (s *SpellSlotState) LongRest(base SlotBase) {
+	s.Remaining = s.Totals(base)
+	s.ShortRest(base)
+}
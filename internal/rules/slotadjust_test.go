@@ -0,0 +1,76 @@
+package rules
+
+import "testing"
+
+func TestSlotBaseFor(t *testing.T) {
+	b := SlotBaseFor([]ClassLevel{{Class: "Warlock", Level: 5}, {Class: "Sorcerer", Level: 2}}, Edition2024)
+	if want := (SlotBase{Slots: [9]int{3}, Pact: 2, PactLevel: 3}); b != want {
+		t.Errorf("SlotBaseFor = %+v, want %+v", b, want)
+	}
+}
+
+func TestAdjustStandardSlots(t *testing.T) {
+	base := SlotBaseFor([]ClassLevel{{Class: "Wizard", Level: 3}}, Edition2024)
+	var s SpellSlotState
+	s.LongRest(base)
+	s.Remaining[0] = 2
+
+	if err := s.Adjust(base, 1, 1, "Boon"); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Totals(base)[0]; got != 5 {
+		t.Errorf("total = %d, want 5", got)
+	}
+	if s.Remaining[0] != 3 {
+		t.Errorf("remaining = %d, want 3: the new slot is ready", s.Remaining[0])
+	}
+	// The same source merges, and a net zero removes the adjustment.
+	s.Adjust(base, 1, 1, "boon")
+	s.Adjust(base, 1, -2, "Boon")
+	if len(s.Adjustments) != 0 || s.Remaining[0] != 2 {
+		t.Errorf("after cancelling: %+v", s)
+	}
+
+	for _, tt := range []struct {
+		level, delta int
+		source       string
+	}{
+		{0, 1, "Boon"},
+		{10, 1, "Boon"},
+		{1, 1, " "},
+	} {
+		if err := s.Adjust(base, tt.level, tt.delta, tt.source); err == nil {
+			t.Errorf("Adjust(%d, %d, %q) succeeded", tt.level, tt.delta, tt.source)
+		}
+	}
+}
+
+func TestAdjustPactSlots(t *testing.T) {
+	base := SlotBaseFor([]ClassLevel{{Class: "Warlock", Level: 3}}, Edition2024)
+	var s SpellSlotState
+	s.LongRest(base)
+	if err := s.AdjustPact(base, 1, "Rod of the Pact Keeper"); err != nil {
+		t.Fatal(err)
+	}
+	if s.Totals(base) != ([9]int{}) {
+		t.Errorf("pact adjustment changed standard slots: %v", s.Totals(base))
+	}
+	if got := s.PactTotal(base); got != 3 || s.PactRemaining != 3 {
+		t.Errorf("pact %d/%d, want 3/3", s.PactRemaining, got)
+	}
+
+	s.PactRemaining = 0
+	s.Remaining[0] = 0
+	s.ShortRest(base)
+	if s.PactRemaining != 3 {
+		t.Errorf("short rest regained %d pact slots, want 3", s.PactRemaining)
+	}
+
+	s.RemoveAdjustment(base, 0)
+	if got := s.PactTotal(base); got != 2 || s.PactRemaining != 2 {
+		t.Errorf("after removing: pact %d/%d, want 2/2", s.PactRemaining, got)
+	}
+	if err := s.SetPactRemaining(base, 3); err == nil {
+		t.Error("SetPactRemaining above the total succeeded")
+	}
+}
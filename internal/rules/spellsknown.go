@@ -0,0 +1,78 @@
+package rules
+
+import "strings"
+
+// cantripsKnown is how many cantrips each class knows: the count at 1st
+// level, then the class levels that add one more.
+var cantripsKnown = map[string]struct {
+	base int
+	more []int
+}{
+	"artificer": {2, []int{10, 14}},
+	"bard":      {2, []int{4, 10}},
+	"cleric":    {3, []int{4, 10}},
+	"druid":     {2, []int{4, 10}},
+	"sorcerer":  {4, []int{4, 10}},
+	"warlock":   {2, []int{4, 10}},
+	"wizard":    {3, []int{4, 10}},
+}
+
+// CantripsKnown returns how many cantrips a class knows at a class level,
+// zero for classes without cantrips.
+func CantripsKnown(class string, level int) int {
+	c, ok := cantripsKnown[strings.ToLower(class)]
+	if !ok || level < 1 {
+		return 0
+	}
+	n := c.base
+	for _, l := range c.more {
+		if level >= l {
+			n++
+		}
+	}
+	return n
+}
+
+// spellsKnown is the Spells Known column of the classes that learn a
+// fixed set of spells, indexed by class level - 1.
+var spellsKnown = map[string][20]int{
+	"bard":     {4, 5, 6, 7, 8, 9, 10, 11, 12, 14, 15, 15, 16, 18, 19, 19, 20, 22, 22, 22},
+	"ranger":   {0, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 7, 8, 8, 9, 9, 10, 10, 11, 11},
+	"sorcerer": {2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 12, 13, 13, 14, 14, 15, 15, 15, 15},
+	"warlock":  {2, 3, 4, 5, 6, 7, 8, 9, 10, 10, 11, 11, 12, 12, 13, 13, 14, 14, 15, 15},
+}
+
+// KnowsSpells reports whether a class learns a fixed number of spells
+// rather than preparing them or keeping a spellbook.
+func KnowsSpells(class string) bool {
+	_, ok := spellsKnown[strings.ToLower(class)]
+	return ok
+}
+
+// SpellsKnown returns how many leveled spells a known-spells class knows
+// at a class level. Other classes return zero.
+func SpellsKnown(class string, level int) int {
+	t, ok := spellsKnown[strings.ToLower(class)]
+	if !ok || level < 1 {
+		return 0
+	}
+	return t[min(level, 20)-1]
+}
+
+// MaxSpellLevel returns the highest level of spell a class can learn at
+// a class level: its own slot progression, or its Pact Magic slot level
+// for warlocks. It ignores the character's other classes; multiclassing
+// adds slots but not higher spells to learn.
+func MaxSpellLevel(c ClassLevel, e Edition) int {
+	if CasterTypeOf(c.Class, c.Subclass) == PactCaster {
+		_, level := PactSlots(c.Level)
+		return level
+	}
+	highest := 0
+	for i, n := range SpellSlots([]ClassLevel{c}, e) {
+		if n > 0 {
+			highest = i + 1
+		}
+	}
+	return highest
+}
@@ -0,0 +1,55 @@
+package rules
+
+import "testing"
+
+func TestMaxSpellLevel(t *testing.T) {
+	tests := []struct {
+		class   ClassLevel
+		edition Edition
+		want    int
+	}{
+		{ClassLevel{Class: "Fighter", Level: 20}, Edition2024, 0},
+		{ClassLevel{Class: "Sorcerer", Level: 1}, Edition2024, 1},
+		{ClassLevel{Class: "Wizard", Level: 5}, Edition2024, 3},
+		{ClassLevel{Class: "Bard", Level: 17}, Edition2024, 9},
+		{ClassLevel{Class: "Paladin", Level: 1}, Edition2024, 1},
+		{ClassLevel{Class: "Paladin", Level: 1}, Edition2014, 0},
+		{ClassLevel{Class: "Paladin", Level: 5}, Edition2014, 2},
+		{ClassLevel{Class: "Ranger", Level: 5}, Edition2024, 2},
+		{ClassLevel{Class: "Rogue", Subclass: "Arcane Trickster", Level: 7}, Edition2024, 2},
+		{ClassLevel{Class: "Warlock", Level: 5}, Edition2024, 3},
+		{ClassLevel{Class: "Warlock", Level: 20}, Edition2024, 5},
+	}
+	for _, tt := range tests {
+		if got := MaxSpellLevel(tt.class, tt.edition); got != tt.want {
+			t.Errorf("MaxSpellLevel(%s %d) = %d, want %d", tt.class.Class, tt.class.Level, got, tt.want)
+		}
+	}
+}
+
+func TestSpellsKnown(t *testing.T) {
+	tests := []struct {
+		class           string
+		level           int
+		cantrips, known int
+	}{
+		{"Sorcerer", 1, 4, 2},
+		{"Sorcerer", 4, 5, 5},
+		{"Bard", 10, 4, 14},
+		{"Ranger", 1, 0, 0},
+		{"Ranger", 2, 0, 2},
+		{"Warlock", 20, 4, 15},
+		{"Wizard", 10, 5, 0},
+		{"Cleric", 1, 3, 0},
+		{"Artificer", 14, 4, 0},
+		{"Fighter", 5, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := CantripsKnown(tt.class, tt.level); got != tt.cantrips {
+			t.Errorf("CantripsKnown(%s, %d) = %d, want %d", tt.class, tt.level, got, tt.cantrips)
+		}
+		if got := SpellsKnown(tt.class, tt.level); got != tt.known {
+			t.Errorf("SpellsKnown(%s, %d) = %d, want %d", tt.class, tt.level, got, tt.known)
+		}
+	}
+}
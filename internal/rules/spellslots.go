@@ -0,0 +1,161 @@
+package rules
+
+import "strings"
+
+// CasterType is how a class contributes to spellcaster level.
+type CasterType int
+
+const (
+	NonCaster CasterType = iota
+	FullCaster
+	HalfCaster
+	ThirdCaster
+	PactCaster
+)
+
+var fullCasters = map[string]bool{
+	"bard": true, "cleric": true, "druid": true, "sorcerer": true, "wizard": true,
+}
+
+// The Spellcaster sidekick's slot table is a half caster's, rounded up.
+var halfCasters = map[string]bool{
+	"artificer": true, "paladin": true, "ranger": true, "spellcaster": true,
+}
+
+// halfCastersRoundingUp are the half casters that round their caster
+// level up under the 2014 rules too.
+var halfCastersRoundingUp = map[string]bool{"artificer": true, "spellcaster": true}
+
+var thirdCasterSubclasses = map[string]bool{
+	"eldritch knight": true, "arcane trickster": true,
+}
+
+// CasterTypeOf returns the spellcasting progression of a class, taking
+// subclass into account for Eldritch Knights and Arcane Tricksters.
+func CasterTypeOf(class, subclass string) CasterType {
+	class = strings.ToLower(class)
+	switch {
+	case fullCasters[class]:
+		return FullCaster
+	case halfCasters[class]:
+		return HalfCaster
+	case class == "warlock":
+		return PactCaster
+	case thirdCasterSubclasses[strings.ToLower(subclass)]:
+		return ThirdCaster
+	}
+	return NonCaster
+}
+
+// slotTable is the Multiclass Spellcaster table, indexed by caster level.
+var slotTable = [21][9]int{
+	{},
+	{2},
+	{3},
+	{4, 2},
+	{4, 3},
+	{4, 3, 2},
+	{4, 3, 3},
+	{4, 3, 3, 1},
+	{4, 3, 3, 2},
+	{4, 3, 3, 3, 1},
+	{4, 3, 3, 3, 2},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 2, 1, 1},
+}
+
+// SlotsForCasterLevel returns slots per spell level (index 0 is 1st level)
+// for a spellcaster level on the shared table.
+func SlotsForCasterLevel(level int) [9]int {
+	level = min(max(level, 0), 20)
+	return slotTable[level]
+}
+
+// CasterLevel returns the spellcaster level used for the shared slot table.
+// A single-class caster uses their own class progression (third casters
+// round up); multiclass characters add full caster levels, half of half
+// caster levels (see halfCasterLevel), and a third of Eldritch Knight and
+// Arcane Trickster levels rounded down. Warlock levels are excluded; see
+// PactSlots.
+func CasterLevel(classes []ClassLevel, e Edition) int {
+	var casters []ClassLevel
+	for _, c := range classes {
+		if t := CasterTypeOf(c.Class, c.Subclass); t != NonCaster && t != PactCaster {
+			casters = append(casters, c)
+		}
+	}
+
+	if len(casters) == 1 {
+		c := casters[0]
+		switch CasterTypeOf(c.Class, c.Subclass) {
+		case HalfCaster:
+			return halfCasterLevel(c, e, false)
+		case ThirdCaster:
+			if c.Level < 3 {
+				return 0
+			}
+			return (c.Level + 2) / 3
+		}
+		return c.Level
+	}
+
+	level := 0
+	for _, c := range casters {
+		switch CasterTypeOf(c.Class, c.Subclass) {
+		case FullCaster:
+			level += c.Level
+		case HalfCaster:
+			level += halfCasterLevel(c, e, true)
+		case ThirdCaster:
+			level += c.Level / 3
+		}
+	}
+	return level
+}
+
+// halfCasterLevel is a half caster class's share of the caster level.
+// Under the 2024 rules it is half the class level rounded up, alone or
+// multiclassed. Under the 2014 rules only the artificer (and the
+// Spellcaster sidekick) does that; a paladin or ranger has no slots
+// before 2nd level and rounds down when multiclassing.
+func halfCasterLevel(c ClassLevel, e Edition, multiclass bool) int {
+	switch {
+	case e != Edition2014, halfCastersRoundingUp[strings.ToLower(c.Class)]:
+		return (c.Level + 1) / 2
+	case multiclass:
+		return c.Level / 2
+	case c.Level < 2:
+		return 0
+	}
+	return (c.Level + 1) / 2
+}
+
+// SpellSlots returns the total spell slots per level for a character's
+// classes, excluding Pact Magic.
+func SpellSlots(classes []ClassLevel, e Edition) [9]int {
+	return SlotsForCasterLevel(CasterLevel(classes, e))
+}
+
+// PactSlots returns the number of Pact Magic slots and their level for a
+// warlock level.
+func PactSlots(warlockLevel int) (count, level int) {
+	switch {
+	case warlockLevel < 1:
+		return 0, 0
+	case warlockLevel == 1:
+		return 1, 1
+	case warlockLevel < 11:
+		return 2, (warlockLevel + 1) / 2
+	case warlockLevel < 17:
+		return 3, 5
+	}
+	return 4, 5
+}
@@ -0,0 +1,70 @@
+package rules
+
+import "testing"
+
+func TestSpellSlots(t *testing.T) {
+	tests := []struct {
+		name    string
+		edition Edition
+		classes []ClassLevel
+		want    [9]int
+	}{
+		{"none", Edition2024, nil, [9]int{}},
+		{"fighter", Edition2024, []ClassLevel{{Class: "Fighter", Level: 5}}, [9]int{}},
+		{"wizard 1", Edition2024, []ClassLevel{{Class: "Wizard", Level: 1}}, [9]int{2}},
+		{"wizard 5", Edition2024, []ClassLevel{{Class: "Wizard", Level: 5}}, [9]int{4, 3, 2}},
+		{"wizard 20", Edition2024, []ClassLevel{{Class: "wizard", Level: 20}}, [9]int{4, 3, 3, 3, 3, 2, 2, 1, 1}},
+		{"eldritch knight 2", Edition2024, []ClassLevel{{Class: "Fighter", Subclass: "Eldritch Knight", Level: 2}}, [9]int{}},
+		{"eldritch knight 3", Edition2024, []ClassLevel{{Class: "Fighter", Subclass: "Eldritch Knight", Level: 3}}, [9]int{2}},
+		{"warlock only", Edition2024, []ClassLevel{{Class: "Warlock", Level: 5}}, [9]int{}},
+		{"wizard 3 cleric 2", Edition2024, []ClassLevel{{Class: "Wizard", Level: 3}, {Class: "Cleric", Level: 2}}, [9]int{4, 3, 2}},
+		{"wizard 4 arcane trickster 4", Edition2024, []ClassLevel{{Class: "Wizard", Level: 4}, {Class: "Rogue", Subclass: "Arcane Trickster", Level: 4}}, [9]int{4, 3, 2}},
+		{"sorcerer 1 warlock 5", Edition2024, []ClassLevel{{Class: "Sorcerer", Level: 1}, {Class: "Warlock", Level: 5}}, [9]int{2}},
+
+		// 2024: half casters round up, from 1st level and when multiclassed.
+		{"2024 paladin 1", Edition2024, []ClassLevel{{Class: "Paladin", Level: 1}}, [9]int{2}},
+		{"2024 paladin 5", Edition2024, []ClassLevel{{Class: "Paladin", Level: 5}}, [9]int{4, 2}},
+		{"2024 paladin 3 ranger 3", Edition2024, []ClassLevel{{Class: "Paladin", Level: 3}, {Class: "Ranger", Level: 3}}, [9]int{4, 3}},
+		{"2024 paladin 3 wizard 1", Edition2024, []ClassLevel{{Class: "Paladin", Level: 3}, {Class: "Wizard", Level: 1}}, [9]int{4, 2}},
+
+		// 2014: paladins and rangers start at 2nd level and round down when
+		// multiclassed; artificers always round up.
+		{"2014 paladin 1", Edition2014, []ClassLevel{{Class: "Paladin", Level: 1}}, [9]int{}},
+		{"2014 ranger 2", Edition2014, []ClassLevel{{Class: "Ranger", Level: 2}}, [9]int{2}},
+		{"2014 paladin 3", Edition2014, []ClassLevel{{Class: "Paladin", Level: 3}}, [9]int{3}},
+		{"2014 paladin 5", Edition2014, []ClassLevel{{Class: "Paladin", Level: 5}}, [9]int{4, 2}},
+		{"2014 artificer 1", Edition2014, []ClassLevel{{Class: "Artificer", Level: 1}}, [9]int{2}},
+		{"2014 paladin 3 ranger 3", Edition2014, []ClassLevel{{Class: "Paladin", Level: 3}, {Class: "Ranger", Level: 3}}, [9]int{3}},
+		{"2014 paladin 3 wizard 1", Edition2014, []ClassLevel{{Class: "Paladin", Level: 3}, {Class: "Wizard", Level: 1}}, [9]int{3}},
+		{"2014 artificer 3 wizard 1", Edition2014, []ClassLevel{{Class: "Artificer", Level: 3}, {Class: "Wizard", Level: 1}}, [9]int{4, 2}},
+	}
+	for _, tt := range tests {
+		if got := SpellSlots(tt.classes, tt.edition); got != tt.want {
+			t.Errorf("%s: SpellSlots = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPactSlots(t *testing.T) {
+	tests := []struct {
+		level, count, slotLevel int
+	}{
+		{0, 0, 0},
+		{1, 1, 1},
+		{2, 2, 1},
+		{3, 2, 2},
+		{5, 2, 3},
+		{9, 2, 5},
+		{10, 2, 5},
+		{11, 3, 5},
+		{16, 3, 5},
+		{17, 4, 5},
+		{20, 4, 5},
+	}
+	for _, tt := range tests {
+		count, level := PactSlots(tt.level)
+		if count != tt.count || level != tt.slotLevel {
+			t.Errorf("PactSlots(%d) = %d, %d; want %d, %d", tt.level, count, level, tt.count, tt.slotLevel)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package rules
+
+import "fmt"
+
+// TempHPExpiry is when temporary hit points run out if they aren't used
+// up first.
+type TempHPExpiry string
+
+const (
+	// TempHPLongRest is the default: temporary hit points without a
+	// stated duration last until the character finishes a long rest.
+	TempHPLongRest TempHPExpiry = ""
+	// TempHPShortRest ends at the end of the next short or long rest.
+	TempHPShortRest TempHPExpiry = "short_rest"
+)
+
+// TempHP is a character's temporary hit points and where they came from.
+type TempHP struct {
+	Amount int          `json:"amount"`
+	Source string       `json:"source,omitempty"`
+	Expiry TempHPExpiry `json:"expiry,omitempty"`
+}
+
+func (t TempHP) String() string {
+	if t.Source == "" {
+		return fmt.Sprintf("%d temp HP", t.Amount)
+	}
+	return fmt.Sprintf("%d temp HP (%s)", t.Amount, t.Source)
+}
+
+// Offer decides what gaining g means for t. Temporary hit points don't
+// stack: with none, g applies outright; a larger g may replace the
+// current ones if the player agrees; anything else is ignored.
+func (t TempHP) Offer(g TempHP) (apply, ask bool) {
+	switch {
+	case g.Amount <= 0:
+		return false, false
+	case t.Amount <= 0:
+		return true, false
+	}
+	return false, g.Amount > t.Amount
+}
+
+// Absorb takes damage from the temporary hit points and returns the
+// damage left over for real hit points.
+func (t *TempHP) Absorb(damage int) int {
+	n := min(max(damage, 0), max(t.Amount, 0))
+	t.Amount -= n
+	if t.Amount <= 0 {
+		*t = TempHP{}
+	}
+	return damage - n
+}
+
+// ShortRest ends temporary hit points that last until a short rest.
+func (t *TempHP) ShortRest() bool {
+	if t.Amount > 0 && t.Expiry == TempHPShortRest {
+		*t = TempHP{}
+		return true
+	}
+	return false
+}
+
+// LongRest ends any temporary hit points left.
+func (t *TempHP) LongRest() bool {
+	ended := t.Amount > 0
+	*t = TempHP{}
+	return ended
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Progression is how a character advances.
+type Progression string
+
+const (
+	ProgressionXP        Progression = "xp"
+	ProgressionMilestone Progression = "milestone"
+)
+
+// MaxLevel is the highest character level in the standard XP table.
+const MaxLevel = 20
+
+// XPTable is the XP needed to reach each level, indexed by level. The nil
+// table is the PHB's, which ends at MaxLevel; the epic levels house rule
+// extends it.
+type XPTable []int
+
+// standardXP is the PHB's table.
+var standardXP = XPTable{
+	0, 0, 300, 900, 2700, 6500, 14000, 23000, 34000, 48000, 64000,
+	85000, 100000, 120000, 140000, 165000, 195000, 225000, 265000, 305000, 355000,
+}
+
+// ExtendXPTable returns the standard table followed by the thresholds of
+// levels 21 onwards, which must keep increasing.
+func ExtendXPTable(thresholds []int) (XPTable, error) {
+	t := append(slices.Clone(standardXP), thresholds...)
+	for l := MaxLevel + 1; l < len(t); l++ {
+		if t[l] <= t[l-1] {
+			return nil, fmt.Errorf("level %d needs more XP than level %d", l, l-1)
+		}
+	}
+	return t, nil
+}
+
+func (t XPTable) levels() XPTable {
+	if t == nil {
+		return standardXP
+	}
+	return t
+}
+
+// MaxLevel returns the highest level in the table.
+func (t XPTable) MaxLevel() int {
+	return len(t.levels()) - 1
+}
+
+// ForLevel returns the XP needed to reach a level.
+func (t XPTable) ForLevel(level int) int {
+	return t.levels()[min(max(level, 1), t.MaxLevel())]
+}
+
+// LevelFor returns the character level an XP total earns.
+func (t XPTable) LevelFor(xp int) int {
+	levels := t.levels()
+	level := 1
+	for l := 2; l < len(levels) && xp >= levels[l]; l++ {
+		level = l
+	}
+	return level
+}
+
+// CanLevelUp reports whether a character may take their next level:
+// milestone characters at any time below the table's last level, XP
+// characters once they have banked enough XP.
+func (t XPTable) CanLevelUp(p Progression, level, xp int) bool {
+	if level >= t.MaxLevel() {
+		return false
+	}
+	if p == ProgressionMilestone {
+		return true
+	}
+	return xp >= t.ForLevel(level+1)
+}
+
+// XPForLevel returns the XP needed to reach a level on the standard table.
+func XPForLevel(level int) int {
+	return XPTable(nil).ForLevel(level)
+}
+
+// LevelForXP returns the character level an XP total earns on the
+// standard table.
+func LevelForXP(xp int) int {
+	return XPTable(nil).LevelFor(xp)
+}
+
+// CanLevelUp is XPTable.CanLevelUp on the standard table.
+func CanLevelUp(p Progression, level, xp int) bool {
+	return XPTable(nil).CanLevelUp(p, level, xp)
+}
+
+// XPGain is the result of adding experience.
+type XPGain struct {
+	From, To int
+	// Levels are the character levels newly earned, in order; one
+	// level-up runs for each.
+	Levels []int
+}
+
+// AddXP adds amount to xp for a character currently at level. Levels
+// already taken aren't offered again, so a character who is behind on
+// level-ups catches up one level at a time.
+func (t XPTable) AddXP(xp, amount, level int) (XPGain, error) {
+	if amount < 0 && xp+amount < 0 {
+		return XPGain{}, fmt.Errorf("XP cannot go below 0")
+	}
+	g := XPGain{From: xp, To: xp + amount}
+	for l := level + 1; l <= t.LevelFor(g.To); l++ {
+		g.Levels = append(g.Levels, l)
+	}
+	return g, nil
+}
+
+// AddXP is XPTable.AddXP on the standard table.
+func AddXP(xp, amount, level int) (XPGain, error) {
+	return XPTable(nil).AddXP(xp, amount, level)
+}
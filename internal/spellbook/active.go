@@ -0,0 +1,152 @@
+package spellbook
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sheet/internal/data"
+)
+
+// RoundLength is the game time a combat round covers.
+const RoundLength = 6 * time.Second
+
+// durationText matches the length in a duration like "Concentration, up
+// to 10 minutes" or "8 hours".
+var durationText = regexp.MustCompile(`(?i)(\d+)\s+(round|minute|hour|day)s?`)
+
+// ParseDuration returns a spell duration in rounds. Instantaneous spells,
+// and spells that last until dispelled or triggered, report false.
+func ParseDuration(s string) (int, bool) {
+	m := durationText.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(m[1])
+	per := map[string]int{"round": 1, "minute": 10, "hour": 600, "day": 14400}[strings.ToLower(m[2])]
+	return n * per, n > 0
+}
+
+// ActiveSpell is a spell whose duration is running.
+type ActiveSpell struct {
+	Name          string `json:"name"`
+	Concentration bool   `json:"concentration,omitempty"`
+	// Rounds is how many rounds of the duration are left.
+	Rounds int `json:"rounds"`
+}
+
+// Remaining returns the time left, for display between combats.
+func (a ActiveSpell) Remaining() time.Duration {
+	return time.Duration(a.Rounds) * RoundLength
+}
+
+func (a ActiveSpell) String() string {
+	if a.Rounds <= 10 {
+		return fmt.Sprintf("%s (%d rounds)", a.Name, a.Rounds)
+	}
+	mins := a.Rounds / 10
+	h, m := mins/60, mins%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%s (%d min)", a.Name, m)
+	case m == 0:
+		return fmt.Sprintf("%s (%d h)", a.Name, h)
+	}
+	return fmt.Sprintf("%s (%d h %d min)", a.Name, h, m)
+}
+
+// ActiveSpells are the character's running spells. Durations count down
+// with combat rounds (Tick) or the campaign clock (Pass), and only one
+// concentration spell runs at a time.
+type ActiveSpells struct {
+	Spells []ActiveSpell `json:"spells,omitempty"`
+}
+
+// Concentrating returns the concentration spell being maintained.
+func (a *ActiveSpells) Concentrating() (ActiveSpell, bool) {
+	i := slices.IndexFunc(a.Spells, func(s ActiveSpell) bool { return s.Concentration })
+	if i < 0 {
+		return ActiveSpell{}, false
+	}
+	return a.Spells[i], true
+}
+
+// Cast starts a spell's duration. Casting a concentration spell ends the
+// previous one, which is returned so the caller can say so. Spells with
+// no running duration are ignored.
+func (a *ActiveSpells) Cast(s data.Spell) (dropped *ActiveSpell) {
+	rounds, ok := ParseDuration(s.Duration)
+	if !ok {
+		return nil
+	}
+	if s.Concentration {
+		if prev, ok := a.EndConcentration(); ok {
+			dropped = &prev
+		}
+	}
+	a.End(s.Name)
+	a.Spells = append(a.Spells, ActiveSpell{Name: s.Name, Concentration: s.Concentration, Rounds: rounds})
+	return dropped
+}
+
+// End stops the named spell early, reporting whether it was running.
+func (a *ActiveSpells) End(name string) bool {
+	before := len(a.Spells)
+	a.Spells = slices.DeleteFunc(a.Spells, func(s ActiveSpell) bool {
+		return strings.EqualFold(s.Name, name)
+	})
+	return len(a.Spells) != before
+}
+
+// EndConcentration drops the concentration spell, as when a
+// Constitution save fails or the caster is incapacitated.
+func (a *ActiveSpells) EndConcentration() (ActiveSpell, bool) {
+	s, ok := a.Concentrating()
+	if ok {
+		a.End(s.Name)
+	}
+	return s, ok
+}
+
+// Tick counts rounds down and returns the spells that expired.
+func (a *ActiveSpells) Tick(rounds int) []ActiveSpell {
+	var expired []ActiveSpell
+	kept := a.Spells[:0]
+	for _, s := range a.Spells {
+		s.Rounds -= rounds
+		if s.Rounds <= 0 {
+			s.Rounds = 0
+			expired = append(expired, s)
+			continue
+		}
+		kept = append(kept, s)
+	}
+	a.Spells = kept
+	return expired
+}
+
+// Pass advances the campaign clock by d, rounded down to whole rounds, and
+// returns the spells that expired.
+func (a *ActiveSpells) Pass(d time.Duration) []ActiveSpell {
+	return a.Tick(int(d / RoundLength))
+}
+
+// Expired describes expired spells for a notification, e.g. "Bless has
+// ended".
+func Expired(spells []ActiveSpell) string {
+	if len(spells) == 0 {
+		return ""
+	}
+	names := make([]string, len(spells))
+	for i, s := range spells {
+		names[i] = s.Name
+	}
+	verb := "has"
+	if len(names) > 1 {
+		verb = "have"
+	}
+	return fmt.Sprintf("%s %s ended", strings.Join(names, ", "), verb)
+}
@@ -0,0 +1,117 @@
+package spellbook
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+)
+
+// Budget is how many spells a character can know, for the spellbook
+// header and the Add Spell overlay.
+type Budget struct {
+	MaxCantrips int
+	// MaxSpells limits the leveled spells known; zero means no limit, as
+	// for classes that prepare spells or keep a spellbook.
+	MaxSpells int
+	// MaxLevel is the highest spell level the class can learn.
+	MaxLevel int
+}
+
+// BudgetFor returns the budget for the spells a character learns from
+// one of their classes, from that class's level alone, under the
+// edition's rules.
+func BudgetFor(c rules.ClassLevel, e rules.Edition) Budget {
+	return Budget{
+		MaxCantrips: rules.CantripsKnown(c.Class, c.Level),
+		MaxSpells:   rules.SpellsKnown(c.Class, c.Level),
+		MaxLevel:    rules.MaxSpellLevel(c, e),
+	}
+}
+
+// Usage is how much of a budget the known spells take up.
+type Usage struct {
+	Budget
+	Cantrips, Spells int
+	// TooHigh are the known spells above MaxLevel.
+	TooHigh []string
+}
+
+// Usage counts the known spells against budget. Spells missing from the
+// data and always-prepared spells aren't counted.
+func (b *Spellbook) Usage(spells []data.Spell, budget Budget) Usage {
+	u := Usage{Budget: budget}
+	for _, name := range b.Known {
+		s, ok := data.FindSpell(spells, name)
+		switch {
+		case !ok, b.IsAlwaysPrepared(name):
+		case s.IsCantrip():
+			u.Cantrips++
+		default:
+			u.Spells++
+			if s.Level > budget.MaxLevel {
+				u.TooHigh = append(u.TooHigh, s.Name)
+			}
+		}
+	}
+	return u
+}
+
+// View returns the header line, e.g. "Cantrips 3/4 · Spells 6/7 · up
+// to level 3".
+func (u Usage) View(g glyphs.Set) string {
+	parts := []string{fmt.Sprintf("Cantrips %d/%d", u.Cantrips, u.MaxCantrips)}
+	if u.MaxSpells > 0 {
+		parts = append(parts, fmt.Sprintf("Spells %d/%d", u.Spells, u.MaxSpells))
+	}
+	if u.MaxLevel > 0 {
+		parts = append(parts, fmt.Sprintf("up to level %d", u.MaxLevel))
+	}
+	return strings.Join(parts, " "+g.Dot+" ")
+}
+
+// Warnings describes every way the known spells go over budget.
+func (u Usage) Warnings() []string {
+	var out []string
+	if u.Cantrips > u.MaxCantrips {
+		out = append(out, fmt.Sprintf("%d cantrips known; the limit is %d", u.Cantrips, u.MaxCantrips))
+	}
+	if u.MaxSpells > 0 && u.Spells > u.MaxSpells {
+		out = append(out, fmt.Sprintf("%d spells known; the limit is %d", u.Spells, u.MaxSpells))
+	}
+	if len(u.TooHigh) > 0 {
+		out = append(out, fmt.Sprintf("above level %d: %s", u.MaxLevel, strings.Join(u.TooHigh, ", ")))
+	}
+	return out
+}
+
+// CanLearn reports why s can't be added to the known spells under
+// budget: its level is too high for the class, the cantrip or spell
+// limit is reached, or it's already known.
+func (b *Spellbook) CanLearn(spells []data.Spell, s data.Spell, budget Budget) error {
+	u := b.Usage(spells, budget)
+	switch {
+	case b.Knows(s.Name):
+		return fmt.Errorf("%s is already known", s.Name)
+	case s.IsCantrip() && u.Cantrips >= budget.MaxCantrips:
+		return fmt.Errorf("already know %d of %d cantrips", u.Cantrips, budget.MaxCantrips)
+	case s.IsCantrip():
+		return nil
+	case s.Level > budget.MaxLevel:
+		return fmt.Errorf("%s is level %d; you can only learn spells up to level %d", s.Name, s.Level, budget.MaxLevel)
+	case budget.MaxSpells > 0 && u.Spells >= budget.MaxSpells:
+		return fmt.Errorf("already know %d of %d spells", u.Spells, budget.MaxSpells)
+	}
+	return nil
+}
+
+// Learn adds s to the known spells if CanLearn allows it.
+func (b *Spellbook) Learn(spells []data.Spell, s data.Spell, budget Budget) error {
+	if err := b.CanLearn(spells, s, budget); err != nil {
+		return err
+	}
+	b.Known = append(b.Known, s.Name)
+	return nil
+}
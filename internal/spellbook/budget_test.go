@@ -0,0 +1,76 @@
+package spellbook
+
+import (
+	"testing"
+
+	"sheet/internal/data"
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+)
+
+var testSpells = []data.Spell{
+	{Name: "Fire Bolt", Level: 0},
+	{Name: "Light", Level: 0},
+	{Name: "Mage Hand", Level: 0},
+	{Name: "Prestidigitation", Level: 0},
+	{Name: "Shocking Grasp", Level: 0},
+	{Name: "Magic Missile", Level: 1},
+	{Name: "Shield", Level: 1},
+	{Name: "Misty Step", Level: 2},
+	{Name: "Fireball", Level: 3},
+}
+
+func spell(name string) data.Spell {
+	s, _ := data.FindSpell(testSpells, name)
+	return s
+}
+
+func TestBudgetUsesClassLevel(t *testing.T) {
+	// A Wizard 5/Sorcerer 1 has 3rd-level slots, but learns sorcerer
+	// spells as a 1st-level sorcerer.
+	budget := BudgetFor(rules.ClassLevel{Class: "Sorcerer", Level: 1}, rules.Edition2024)
+	if want := (Budget{MaxCantrips: 4, MaxSpells: 2, MaxLevel: 1}); budget != want {
+		t.Fatalf("BudgetFor = %+v, want %+v", budget, want)
+	}
+	b := &Spellbook{}
+	if err := b.Learn(testSpells, spell("Fireball"), budget); err == nil {
+		t.Error("learned Fireball as a 1st-level sorcerer")
+	}
+	for _, name := range []string{"Magic Missile", "Shield"} {
+		if err := b.Learn(testSpells, spell(name), budget); err != nil {
+			t.Errorf("Learn(%s): %v", name, err)
+		}
+	}
+	if err := b.CanLearn(testSpells, spell("Shield"), budget); err == nil {
+		t.Error("learned Shield twice")
+	}
+}
+
+func TestCantripLimit(t *testing.T) {
+	budget := BudgetFor(rules.ClassLevel{Class: "Wizard", Level: 1}, rules.Edition2024)
+	b := &Spellbook{}
+	for _, name := range []string{"Fire Bolt", "Light", "Mage Hand"} {
+		if err := b.Learn(testSpells, spell(name), budget); err != nil {
+			t.Fatalf("Learn(%s): %v", name, err)
+		}
+	}
+	if err := b.Learn(testSpells, spell("Shocking Grasp"), budget); err == nil {
+		t.Error("learned a fourth cantrip as a 1st-level wizard")
+	}
+	// Wizards have no spells known limit.
+	if err := b.Learn(testSpells, spell("Shield"), budget); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUsageWarnings(t *testing.T) {
+	budget := BudgetFor(rules.ClassLevel{Class: "Sorcerer", Level: 1}, rules.Edition2024)
+	b := &Spellbook{Known: []string{"Fire Bolt", "Magic Missile", "Shield", "Fireball"}}
+	u := b.Usage(testSpells, budget)
+	if got, want := u.View(glyphs.Unicode), "Cantrips 1/4 · Spells 3/2 · up to level 1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := u.Warnings(); len(got) != 2 {
+		t.Errorf("Warnings() = %q, want spells over and Fireball too high", got)
+	}
+}
@@ -0,0 +1,66 @@
+package spellbook
+
+import (
+	"fmt"
+	"time"
+
+	"sheet/internal/currency"
+	"sheet/internal/data"
+)
+
+// FreeSpellsPerLevel is how many wizard spells are added to the spellbook
+// for free on each wizard level.
+const FreeSpellsPerLevel = 2
+
+// Copying a spell into a spellbook takes 2 hours and 50 gp per spell
+// level.
+const (
+	scribeHoursPerLevel = 2
+	scribeGoldPerLevel  = 50
+)
+
+// ScribeCost returns the gold a spell of the given level costs to copy.
+func ScribeCost(level int) currency.Coins {
+	return currency.Amount(scribeGoldPerLevel*level, currency.Gold)
+}
+
+// ScribeTime returns how long a spell of the given level takes to copy.
+func ScribeTime(level int) time.Duration {
+	return time.Duration(scribeHoursPerLevel*level) * time.Hour
+}
+
+// canAdd checks that s can go into the spellbook of a wizard who can cast
+// spells up to maxLevel.
+func (b *Spellbook) canAdd(s data.Spell, maxLevel int) error {
+	switch {
+	case s.IsCantrip():
+		return fmt.Errorf("cantrips aren't kept in a spellbook")
+	case s.Level > maxLevel:
+		return fmt.Errorf("%s is level %d; you can only add spells up to level %d", s.Name, s.Level, maxLevel)
+	case b.Knows(s.Name):
+		return fmt.Errorf("%s is already in the spellbook", s.Name)
+	}
+	return nil
+}
+
+// LearnFree adds one of a level-up's free spells to the spellbook.
+func (b *Spellbook) LearnFree(s data.Spell, maxLevel int) error {
+	if err := b.canAdd(s, maxLevel); err != nil {
+		return err
+	}
+	b.Known = append(b.Known, s.Name)
+	return nil
+}
+
+// Scribe copies a spell into the spellbook, paying for it from w. It
+// returns the time the copying takes, for the journal.
+func (b *Spellbook) Scribe(w *currency.Wallet, s data.Spell, maxLevel int) (time.Duration, error) {
+	if err := b.canAdd(s, maxLevel); err != nil {
+		return 0, err
+	}
+	if err := w.Spend(ScribeCost(s.Level), "scribed "+s.Name); err != nil {
+		return 0, err
+	}
+	b.Known = append(b.Known, s.Name)
+	return ScribeTime(s.Level), nil
+}
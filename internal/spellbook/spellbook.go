@@ -0,0 +1,241 @@
+// Package spellbook tracks the spells a character knows and has prepared,
+// and the named preparation presets they switch between.
+package spellbook
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/data"
+)
+
+// Spellbook is a character's spells. Prepared is a subset of Known.
+type Spellbook struct {
+	Known    []string `json:"known"`
+	Prepared []string `json:"prepared,omitempty"`
+	// AlwaysPrepared are known spells that are prepared without counting
+	// against the limit, like a subclass's; see GrantAlwaysPrepared.
+	AlwaysPrepared []string `json:"always_prepared,omitempty"`
+	Presets        []Preset `json:"presets,omitempty"`
+}
+
+// Preset is a named set of prepared spells, e.g. "Dungeon" or "Social".
+type Preset struct {
+	Name   string   `json:"name"`
+	Spells []string `json:"spells"`
+}
+
+func contains(names []string, name string) bool {
+	return slices.ContainsFunc(names, func(n string) bool {
+		return strings.EqualFold(n, name)
+	})
+}
+
+// Knows reports whether the spell is in the spellbook.
+func (b *Spellbook) Knows(name string) bool {
+	return contains(b.Known, name)
+}
+
+// IsPrepared reports whether the spell is prepared.
+func (b *Spellbook) IsPrepared(name string) bool {
+	return contains(b.Prepared, name) || b.IsAlwaysPrepared(name)
+}
+
+// IsAlwaysPrepared reports whether the spell is always prepared.
+func (b *Spellbook) IsAlwaysPrepared(name string) bool {
+	return contains(b.AlwaysPrepared, name)
+}
+
+// GrantAlwaysPrepared learns the spells, such as a subclass's at a new
+// level, and keeps them prepared. It returns the spells that weren't
+// already always prepared.
+func (b *Spellbook) GrantAlwaysPrepared(names []string) []string {
+	var granted []string
+	for _, name := range names {
+		if b.IsAlwaysPrepared(name) {
+			continue
+		}
+		if !b.Knows(name) {
+			b.Known = append(b.Known, name)
+		}
+		b.Prepared = slices.DeleteFunc(b.Prepared, func(n string) bool { return strings.EqualFold(n, name) })
+		b.AlwaysPrepared = append(b.AlwaysPrepared, name)
+		granted = append(granted, name)
+	}
+	return granted
+}
+
+// TogglePrepared prepares or unprepares a known spell. Preparing fails
+// once max spells are prepared, and always-prepared spells can't be
+// unprepared.
+func (b *Spellbook) TogglePrepared(name string, max int) error {
+	if b.IsAlwaysPrepared(name) {
+		return fmt.Errorf("%s is always prepared", name)
+	}
+	if i := slices.IndexFunc(b.Prepared, func(n string) bool { return strings.EqualFold(n, name) }); i >= 0 {
+		b.Prepared = slices.Delete(b.Prepared, i, i+1)
+		return nil
+	}
+	if !b.Knows(name) {
+		return fmt.Errorf("%s is not in the spellbook", name)
+	}
+	if len(b.Prepared) >= max {
+		return fmt.Errorf("already preparing %d of %d spells", len(b.Prepared), max)
+	}
+	b.Prepared = append(b.Prepared, name)
+	return nil
+}
+
+// Preset returns the named preset.
+func (b *Spellbook) Preset(name string) (Preset, bool) {
+	i := b.presetIndex(name)
+	if i < 0 {
+		return Preset{}, false
+	}
+	return b.Presets[i], true
+}
+
+func (b *Spellbook) presetIndex(name string) int {
+	return slices.IndexFunc(b.Presets, func(p Preset) bool {
+		return strings.EqualFold(p.Name, name)
+	})
+}
+
+// SavePreset stores the current prepared spells under name, replacing a
+// preset of the same name.
+func (b *Spellbook) SavePreset(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("preset needs a name")
+	}
+	p := Preset{Name: name, Spells: slices.Clone(b.Prepared)}
+	if i := b.presetIndex(name); i >= 0 {
+		b.Presets[i] = p
+		return nil
+	}
+	b.Presets = append(b.Presets, p)
+	return nil
+}
+
+// DeletePreset removes the named preset.
+func (b *Spellbook) DeletePreset(name string) error {
+	i := b.presetIndex(name)
+	if i < 0 {
+		return fmt.Errorf("no preset named %q", name)
+	}
+	b.Presets = slices.Delete(b.Presets, i, i+1)
+	return nil
+}
+
+// CheckPreset reports why the named preset can't be applied: it holds
+// more than max spells or names spells no longer in the spellbook. Max
+// changes with level and ability scores, so a preset saved earlier can
+// stop fitting.
+func (b *Spellbook) CheckPreset(name string, max int) error {
+	p, ok := b.Preset(name)
+	if !ok {
+		return fmt.Errorf("no preset named %q", name)
+	}
+	if len(p.Spells) > max {
+		return fmt.Errorf("%s prepares %d spells, but only %d can be prepared", p.Name, len(p.Spells), max)
+	}
+	var missing []string
+	for _, s := range p.Spells {
+		if !b.Knows(s) {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s includes spells not in the spellbook: %s", p.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ApplyPreset replaces the prepared spells with the named preset's. It
+// fails without changing anything if CheckPreset does.
+func (b *Spellbook) ApplyPreset(name string, max int) error {
+	if err := b.CheckPreset(name, max); err != nil {
+		return err
+	}
+	p, _ := b.Preset(name)
+	b.Prepared = slices.Clone(p.Spells)
+	return nil
+}
+
+// ActivePreset returns the preset whose spells are exactly the ones
+// prepared now, in any order.
+func (b *Spellbook) ActivePreset() (Preset, bool) {
+	for _, p := range b.Presets {
+		if len(p.Spells) != len(b.Prepared) {
+			continue
+		}
+		if !slices.ContainsFunc(p.Spells, func(s string) bool { return !b.IsPrepared(s) }) {
+			return p, true
+		}
+	}
+	return Preset{}, false
+}
+
+// knownSpells returns the spell data of the known spells, leaving out
+// cantrips, which are never prepared, and spells missing from the data.
+func (b *Spellbook) knownSpells(spells []data.Spell) []data.Spell {
+	var out []data.Spell
+	for _, name := range b.Known {
+		if s, ok := data.FindSpell(spells, name); ok && !s.IsCantrip() {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// PrepareMatching prepares every known spell that passes f, in level and
+// name order, until max spells are prepared. It returns the spells it
+// prepared and the ones left out for lack of room.
+func (b *Spellbook) PrepareMatching(spells []data.Spell, f data.SpellFilter, max int) (prepared, skipped []string) {
+	for _, s := range data.FilterSpells(b.knownSpells(spells), f) {
+		switch {
+		case b.IsPrepared(s.Name):
+		case len(b.Prepared) >= max:
+			skipped = append(skipped, s.Name)
+		default:
+			b.Prepared = append(b.Prepared, s.Name)
+			prepared = append(prepared, s.Name)
+		}
+	}
+	return prepared, skipped
+}
+
+// UnprepareMatching unprepares every prepared spell that passes f and
+// returns them. Prepared spells missing from the data are kept.
+func (b *Spellbook) UnprepareMatching(spells []data.Spell, f data.SpellFilter) []string {
+	var removed []string
+	b.Prepared = slices.DeleteFunc(b.Prepared, func(name string) bool {
+		s, ok := data.FindSpell(spells, name)
+		if ok && f.Matches(s) {
+			removed = append(removed, s.Name)
+			return true
+		}
+		return false
+	})
+	return removed
+}
+
+// PrepareTop prepares up to n unprepared known spells, highest level
+// first, without going over max. It returns the spells prepared.
+func (b *Spellbook) PrepareTop(spells []data.Spell, n, max int) []string {
+	known := b.knownSpells(spells)
+	slices.SortStableFunc(known, func(x, y data.Spell) int { return cmp.Compare(y.Level, x.Level) })
+	var prepared []string
+	for _, s := range known {
+		if len(prepared) == n || len(b.Prepared) >= max {
+			break
+		}
+		if !b.IsPrepared(s.Name) {
+			b.Prepared = append(b.Prepared, s.Name)
+			prepared = append(prepared, s.Name)
+		}
+	}
+	return prepared
+}
@@ -0,0 +1,49 @@
+package spellbook
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGrantAlwaysPrepared(t *testing.T) {
+	b := Spellbook{Known: []string{"Shield", "Magic Missile"}, Prepared: []string{"Shield"}}
+	granted := b.GrantAlwaysPrepared([]string{"Shield", "Misty Step"})
+	if want := []string{"Shield", "Misty Step"}; !slices.Equal(granted, want) {
+		t.Errorf("granted = %v, want %v", granted, want)
+	}
+	if again := b.GrantAlwaysPrepared([]string{"Misty Step"}); again != nil {
+		t.Errorf("granting again = %v, want nothing", again)
+	}
+	tests := []struct {
+		spell          string
+		known, prepped bool
+	}{
+		{"Shield", true, true},
+		{"Misty Step", true, true},
+		{"Magic Missile", true, false},
+	}
+	for _, tt := range tests {
+		if b.Knows(tt.spell) != tt.known || b.IsPrepared(tt.spell) != tt.prepped {
+			t.Errorf("%s: known %v, prepared %v; want %v, %v", tt.spell, b.Knows(tt.spell), b.IsPrepared(tt.spell), tt.known, tt.prepped)
+		}
+	}
+	// Shield moved out of the counted prepared spells.
+	if len(b.Prepared) != 0 {
+		t.Errorf("Prepared = %v, want none", b.Prepared)
+	}
+	if err := b.TogglePrepared("Misty Step", 5); err == nil {
+		t.Error("unpreparing an always-prepared spell succeeded")
+	}
+	if err := b.TogglePrepared("Magic Missile", 1); err != nil {
+		t.Errorf("preparing with room for one: %v", err)
+	}
+}
+
+func TestUsageSkipsAlwaysPrepared(t *testing.T) {
+	b := Spellbook{Known: []string{"Magic Missile"}}
+	b.GrantAlwaysPrepared([]string{"Shield", "Misty Step"})
+	u := b.Usage(testSpells, Budget{MaxSpells: 1, MaxLevel: 1})
+	if u.Spells != 1 || len(u.Warnings()) != 0 {
+		t.Errorf("Usage = %+v, warnings %v; want 1 spell and no warnings", u, u.Warnings())
+	}
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sheet/internal/rules"
+)
+
+// ArchiveDirName is the subdirectory of the characters directory that
+// archived characters are moved to. List does not include them.
+const ArchiveDirName = "archive"
+
+// deathField is the key the death record is stored under in the character
+// file.
+const deathField = "death"
+
+// Death records when and how a character died.
+type Death struct {
+	Date  time.Time `json:"date"`
+	Cause string    `json:"cause"`
+}
+
+// ArchiveDir returns the archive directory for a characters directory.
+func ArchiveDir(dir string) string {
+	return filepath.Join(dir, ArchiveDirName)
+}
+
+// ListArchived returns the paths of the archived character files in dir.
+func ListArchived(dir string) ([]string, error) {
+	return List(ArchiveDir(dir))
+}
+
+// MarkDeceased writes the death record into the character file. The rest
+// of the file is left as it is.
+func MarkDeceased(path string, d Death) error {
+	fields, err := readFields(path)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to marshal death record: %w", err)
+	}
+	fields[deathField] = raw
+	return writeFields(path, fields)
+}
+
+// ReadDeath returns the character's death record, if it has one.
+func ReadDeath(path string) (Death, bool, error) {
+	fields, err := readFields(path)
+	if err != nil {
+		return Death{}, false, err
+	}
+	raw, ok := fields[deathField]
+	if !ok || string(raw) == "null" {
+		return Death{}, false, nil
+	}
+	var d Death
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return Death{}, false, fmt.Errorf("failed to parse death record: %w", err)
+	}
+	return d, true, nil
+}
+
+// Archive moves the character, with its sidecars, into the archive
+// directory and marks it as deceased there. If it can't be marked, it is
+// moved back. It returns the new path.
+func Archive(path string, d Death) (string, error) {
+	dest, err := moveWithSidecars(path, ArchiveDir(filepath.Dir(path)))
+	if err != nil {
+		return dest, err
+	}
+	if err := MarkDeceased(dest, d); err != nil {
+		if _, undo := moveWithSidecars(dest, filepath.Dir(path)); undo != nil {
+			return dest, fmt.Errorf("%w (and failed to move it back: %v)", err, undo)
+		}
+		return "", err
+	}
+	return dest, nil
+}
+
+// Restore moves an archived character back into the characters directory
+// and clears its death record, for a raise dead or a mistaken archive.
+func Restore(path string) (string, error) {
+	fields, err := readFields(path)
+	if err != nil {
+		return "", err
+	}
+	delete(fields, deathField)
+	if err := writeFields(path, fields); err != nil {
+		return "", err
+	}
+	return moveWithSidecars(path, filepath.Dir(filepath.Dir(path)))
+}
+
+func readFields(path string) (Fields, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read character: %w", err)
+	}
+	var fields Fields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return fields, nil
+}
+
+func writeFields(path string, fields Fields) error {
+	data, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal character: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write character: %w", err)
+	}
+	return nil
+}
+
+// Memorial is the summary written when a character is laid to rest.
+type Memorial struct {
+	Name    string
+	Race    string
+	Classes []rules.ClassLevel
+	Death   Death
+	// Deeds are notable moments, one per line in the export.
+	Deeds   []string
+	Epitaph string
+}
+
+// Markdown renders the memorial.
+func (m Memorial) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# In Memory of %s\n\n", m.Name)
+
+	var classes []string
+	for _, c := range m.Classes {
+		name := c.Class
+		if c.Subclass != "" {
+			name = c.Subclass + " " + c.Class
+		}
+		classes = append(classes, fmt.Sprintf("%s %d", name, c.Level))
+	}
+	if m.Race != "" || len(classes) > 0 {
+		line := strings.TrimSpace(m.Race + " " + strings.Join(classes, " / "))
+		fmt.Fprintf(&b, "%s (level %d)\n\n", line, rules.TotalLevel(m.Classes))
+	}
+
+	fmt.Fprintf(&b, "Died %s", m.Death.Date.Format("January 2, 2006"))
+	if m.Death.Cause != "" {
+		fmt.Fprintf(&b, ": %s", m.Death.Cause)
+	}
+	b.WriteString("\n")
+
+	if len(m.Deeds) > 0 {
+		b.WriteString("\n## Deeds\n\n")
+		for _, d := range m.Deeds {
+			fmt.Fprintf(&b, "- %s\n", d)
+		}
+	}
+	if m.Epitaph != "" {
+		fmt.Fprintf(&b, "\n> %s\n", m.Epitaph)
+	}
+	return b.String()
+}
+
+// WriteMemorial writes the memorial next to the character file as
+// "name.memorial.md" and returns its path.
+func WriteMemorial(path string, m Memorial) (string, error) {
+	out := strings.TrimSuffix(path, Ext) + ".memorial.md"
+	if err := os.WriteFile(out, []byte(m.Markdown()), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write memorial: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BackupsDirName is the subdirectory of the characters directory that
+// holds rolling backups, one folder per character.
+const BackupsDirName = "backups"
+
+// DefaultBackupRetention is how many backups are kept per character.
+const DefaultBackupRetention = 10
+
+// backupStamp names backup files; it sorts chronologically.
+const backupStamp = "20060102-150405.000"
+
+// Backup is one rolling backup of a character file.
+type Backup struct {
+	Path string
+	Time time.Time
+}
+
+// BackupDir returns the folder holding a character's backups.
+func BackupDir(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), Ext)
+	return filepath.Join(filepath.Dir(path), BackupsDirName, name)
+}
+
+// Backups lists a character's backups, newest first.
+func Backups(path string) ([]Backup, error) {
+	entries, err := os.ReadDir(BackupDir(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	var backups []Backup
+	for _, e := range entries {
+		stamp, ok := strings.CutSuffix(e.Name(), Ext)
+		if !ok || e.IsDir() {
+			continue
+		}
+		t, err := time.ParseInLocation(backupStamp, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{Path: filepath.Join(BackupDir(path), e.Name()), Time: t})
+	}
+	slices.SortFunc(backups, func(a, b Backup) int { return b.Time.Compare(a.Time) })
+	return backups, nil
+}
+
+// BackUp copies the character file into its backup folder and deletes the
+// oldest backups beyond retain. Call it before each save overwrites the
+// file. A retain of zero or less keeps every backup.
+func BackUp(path string, now time.Time, retain int) (Backup, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Backup{}, nil
+	}
+	if err != nil {
+		return Backup{}, fmt.Errorf("failed to read character: %w", err)
+	}
+	dir := BackupDir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Backup{}, fmt.Errorf("failed to create backup folder: %w", err)
+	}
+	b := Backup{Path: filepath.Join(dir, now.Format(backupStamp)+Ext), Time: now}
+	if err := os.WriteFile(b.Path, data, 0o644); err != nil {
+		return Backup{}, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if retain > 0 {
+		if err := prune(path, retain); err != nil {
+			return b, err
+		}
+	}
+	return b, nil
+}
+
+func prune(path string, retain int) error {
+	backups, err := Backups(path)
+	if err != nil {
+		return err
+	}
+	for _, b := range backups[min(retain, len(backups)):] {
+		if err := os.Remove(b.Path); err != nil {
+			return fmt.Errorf("failed to prune backup: %w", err)
+		}
+	}
+	return nil
+}
+
+// RestoreBackup replaces the character file with a backup. The current
+// file is backed up first, so a restore can itself be undone.
+func RestoreBackup(path string, b Backup, now time.Time, retain int) error {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	// Keep one more than usual so the restore doesn't push out the oldest
+	// backup; zero or less still keeps every backup.
+	if retain > 0 {
+		retain++
+	}
+	if _, err := BackUp(path, now, retain); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+	return nil
+}
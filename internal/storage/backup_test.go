@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRestoreBackupRetention(t *testing.T) {
+	tests := []struct {
+		name   string
+		retain int
+		want   int
+	}{
+		{"keep every backup", 0, 6},
+		{"negative keeps every backup", -1, 6},
+		{"one extra for the restore", 3, 4},
+		{"room to spare", 10, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "aragorn"+Ext)
+			if err := os.WriteFile(path, []byte(`{"hp": 1}`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+			for i := range 5 {
+				if _, err := BackUp(path, start.Add(time.Duration(i)*time.Minute), 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+			backups, err := Backups(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := RestoreBackup(path, backups[len(backups)-1], start.Add(time.Hour), tt.retain); err != nil {
+				t.Fatal(err)
+			}
+			if backups, _ = Backups(path); len(backups) != tt.want {
+				t.Errorf("%d backups after restoring, want %d", len(backups), tt.want)
+			}
+		})
+	}
+}
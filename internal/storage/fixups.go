@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// FixupKind is the kind of choice a legacy character file is missing.
+type FixupKind string
+
+const (
+	FixupSubclass FixupKind = "subclass"
+	FixupFeat     FixupKind = "feat"
+	FixupSkill    FixupKind = "skill"
+)
+
+// Fixup is a choice missing from a character saved by an older version,
+// from before the choice was recorded. Rather than defaulting it, the
+// fixup wizard asks the player.
+type Fixup struct {
+	Kind FixupKind
+	// Class is the class the subclass or feat is owed by.
+	Class string
+	// Prompt is the question shown for the fixup.
+	Prompt string
+	// Options are the valid answers; empty means any answer, typed in.
+	Options []string
+}
+
+// FixupBackupPath returns where a character file is copied before its
+// fixups are saved, e.g. "aragorn.pre-fixup.json".
+func FixupBackupPath(path string) string {
+	return SidecarPath(path, PreFixupSidecar)
+}
+
+// Fixups returns the choices missing from the character file at path:
+// subclasses for classes past their subclass level, a feat or Ability
+// Score Improvement for each one the class levels have granted, and the
+// skill proficiencies of the starting class and background. Run it after
+// Migrate, so the file is in the current schema.
+func Fixups(path string) ([]Fixup, error) {
+	fields, err := readFields(path)
+	if err != nil {
+		return nil, err
+	}
+	return findFixups(fields)
+}
+
+func findFixups(f Fields) ([]Fixup, error) {
+	var classes []rules.ClassLevel
+	if _, err := f.Get("classes", &classes); err != nil {
+		return nil, err
+	}
+	edition := rules.Edition2024
+	if _, err := f.Get("edition", &edition); err != nil {
+		return nil, err
+	}
+	var feats, skills []string
+	if _, err := f.Get("feats", &feats); err != nil {
+		return nil, err
+	}
+	if _, err := f.Get("skills", &skills); err != nil {
+		return nil, err
+	}
+
+	var fixups []Fixup
+	asis := 0
+	for _, c := range classes {
+		if c.Subclass == "" && c.Level >= rules.SubclassLevel(c.Class, edition) {
+			fixups = append(fixups, Fixup{
+				Kind:   FixupSubclass,
+				Class:  c.Class,
+				Prompt: fmt.Sprintf("%s %d has no subclass. Which did they choose?", c.Class, c.Level),
+			})
+		}
+		asis += rules.ASIsAt(c.Class, c.Level)
+	}
+	// Feats can't be traced to the class that granted them, so the missing
+	// ones are owed by the classes in order.
+	owed := asis - len(feats)
+	for _, c := range classes {
+		for range min(rules.ASIsAt(c.Class, c.Level), owed) {
+			fixups = append(fixups, Fixup{
+				Kind:   FixupFeat,
+				Class:  c.Class,
+				Prompt: fmt.Sprintf("%s levels grant a feat or Ability Score Improvement that wasn't recorded. Which was taken?", c.Class),
+			})
+			owed--
+		}
+	}
+	if len(classes) > 0 {
+		// Two skills come from the background.
+		missing := rules.ClassSkillChoices(classes[0].Class) + 2 - len(skills)
+		var options []string
+		for _, s := range rules.Skills {
+			if !slices.ContainsFunc(skills, func(k string) bool { return strings.EqualFold(k, s.Name) }) {
+				options = append(options, s.Name)
+			}
+		}
+		for range max(missing, 0) {
+			fixups = append(fixups, Fixup{
+				Kind:    FixupSkill,
+				Prompt:  "Choose a skill proficiency from your class or background",
+				Options: options,
+			})
+		}
+	}
+	return fixups, nil
+}
+
+// ApplyFixups saves the answers to fixups, in the same order, to the
+// character file at path. A copy of the original is written first with
+// FixupBackupPath.
+func ApplyFixups(path string, fixups []Fixup, answers []string) error {
+	if len(answers) != len(fixups) {
+		return fmt.Errorf("%d answers for %d fixups", len(answers), len(fixups))
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read character: %w", err)
+	}
+	fields, err := readFields(path)
+	if err != nil {
+		return err
+	}
+	for i, fx := range fixups {
+		if err := applyFixup(fields, fx, strings.TrimSpace(answers[i])); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(FixupBackupPath(path), original, 0o644); err != nil {
+		return fmt.Errorf("failed to back up character before fixups: %w", err)
+	}
+	return writeFields(path, fields)
+}
+
+func applyFixup(f Fields, fx Fixup, answer string) error {
+	if answer == "" {
+		return fmt.Errorf("no answer for %q", fx.Prompt)
+	}
+	if len(fx.Options) > 0 && !slices.Contains(fx.Options, answer) {
+		return fmt.Errorf("%q is not an option for %q", answer, fx.Prompt)
+	}
+	switch fx.Kind {
+	case FixupSubclass:
+		var classes []rules.ClassLevel
+		if _, err := f.Get("classes", &classes); err != nil {
+			return err
+		}
+		i := slices.IndexFunc(classes, func(c rules.ClassLevel) bool { return strings.EqualFold(c.Class, fx.Class) })
+		if i < 0 {
+			return fmt.Errorf("no %s levels", fx.Class)
+		}
+		classes[i].Subclass = answer
+		return f.Set("classes", classes)
+	case FixupFeat:
+		return appendField(f, "feats", answer)
+	case FixupSkill:
+		var skills []string
+		if _, err := f.Get("skills", &skills); err != nil {
+			return err
+		}
+		if slices.ContainsFunc(skills, func(s string) bool { return strings.EqualFold(s, answer) }) {
+			return fmt.Errorf("already proficient in %s", answer)
+		}
+		return appendField(f, "skills", answer)
+	}
+	return fmt.Errorf("unknown fixup %q", fx.Kind)
+}
+
+// appendField adds v to the string list in the named field.
+func appendField(f Fields, name, v string) error {
+	var list []string
+	if _, err := f.Get(name, &list); err != nil {
+		return err
+	}
+	return f.Set(name, append(list, v))
+}
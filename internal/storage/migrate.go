@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sheet/internal/rules"
+)
+
+// SchemaField is the key the schema version is stored under in the
+// character file. Files without it are version 0.
+const SchemaField = "schema_version"
+
+// Fields is a character file decoded one level deep, so migrations can
+// rewrite parts of it without depending on the current model.
+type Fields map[string]json.RawMessage
+
+// Get decodes the named field into v, reporting whether it was present.
+func (f Fields) Get(name string, v any) (bool, error) {
+	raw, ok := f[name]
+	if !ok || string(raw) == "null" {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return true, fmt.Errorf("field %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// Set encodes v into the named field.
+func (f Fields) Set(name string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", name, err)
+	}
+	f[name] = raw
+	return nil
+}
+
+// Migration upgrades a character file to Version from the version before
+// it.
+type Migration struct {
+	Version int
+	// Summary is shown in the what's-new overlay after the file is
+	// upgraded.
+	Summary string
+	Apply   func(Fields) error
+}
+
+var migrations []Migration
+
+// Register adds a migration. Versions must be unique; they are applied in
+// increasing order.
+func Register(m Migration) {
+	if slices.ContainsFunc(migrations, func(o Migration) bool { return o.Version == m.Version }) {
+		panic(fmt.Sprintf("storage: migration %d registered twice", m.Version))
+	}
+	migrations = append(migrations, m)
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+}
+
+// SchemaVersion is the version new character files are written with.
+func SchemaVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// BackupPath returns where the pre-migration copy of a file at version is
+// written, in the character's backup folder, e.g.
+// "backups/aragorn/pre-v2.json". There List doesn't take it for a
+// character, Archive moves it along, and rolling backups never prune it.
+func BackupPath(path string, version int) string {
+	return filepath.Join(BackupDir(path), fmt.Sprintf("pre-v%d%s", version+1, Ext))
+}
+
+// Migrate upgrades the character file at path to the current schema. A
+// copy of the original is written first with BackupPath. It returns the
+// migrations applied, none if the file was already current.
+func Migrate(path string) ([]Migration, error) {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read character: %w", err)
+	}
+	fields, err := readFields(path)
+	if err != nil {
+		return nil, err
+	}
+	version := 0
+	if _, err := fields.Get(SchemaField, &version); err != nil {
+		return nil, err
+	}
+	if version > SchemaVersion() {
+		return nil, fmt.Errorf("character was saved by a newer version (schema %d, this build supports %d)", version, SchemaVersion())
+	}
+
+	var applied []Migration
+	for _, m := range migrations {
+		if m.Version <= version {
+			continue
+		}
+		if err := m.Apply(fields); err != nil {
+			return nil, fmt.Errorf("migration to schema %d: %w", m.Version, err)
+		}
+		applied = append(applied, m)
+	}
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(BackupDir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup folder: %w", err)
+	}
+	if err := os.WriteFile(BackupPath(path, version), original, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to back up character before migrating: %w", err)
+	}
+	if err := fields.Set(SchemaField, SchemaVersion()); err != nil {
+		return nil, err
+	}
+	if err := writeFields(path, fields); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// Load reads the character file at path into v. It is the hook the
+// character loader must call rather than decoding the file itself: the
+// file is upgraded with Migrate first, so v never sees an old schema. It
+// returns the migrations applied, for the what's-new notice.
+func Load(path string, v any) ([]Migration, error) {
+	applied, err := Migrate(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read character: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return applied, nil
+}
+
+// Save writes v, which must encode as a JSON object, as the character file
+// at path. The file is stamped with the current SchemaVersion, so the
+// next Load applies no migrations to it.
+func Save(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal character: %w", err)
+	}
+	var fields Fields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("character is not a JSON object: %w", err)
+	}
+	if err := fields.Set(SchemaField, SchemaVersion()); err != nil {
+		return err
+	}
+	return writeFields(path, fields)
+}
+
+func init() {
+	Register(Migration{
+		Version: 1,
+		Summary: "Exhaustion conditions converted to an exhaustion level",
+		Apply:   migrateExhaustion,
+	})
+	Register(Migration{
+		Version: 2,
+		Summary: "Hit dice split into one pool per die size for multiclass characters",
+		Apply:   migrateHitDice,
+	})
+}
+
+// migrateExhaustion replaces the "Exhaustion" entries in
+// combat_stats.conditions with combat_stats.exhaustion_level.
+func migrateExhaustion(f Fields) error {
+	var stats Fields
+	if ok, err := f.Get("combat_stats", &stats); !ok || err != nil {
+		return err
+	}
+	var conditions []string
+	if _, err := stats.Get("conditions", &conditions); err != nil {
+		return err
+	}
+	level, rest := rules.ExhaustionFromConditions(conditions)
+	if level == 0 {
+		return nil
+	}
+	if err := stats.Set("conditions", rest); err != nil {
+		return err
+	}
+	if err := stats.Set("exhaustion_level", level); err != nil {
+		return err
+	}
+	return f.Set("combat_stats", stats)
+}
+
+// migrateHitDice replaces the single combat_stats.hit_dice pool with one
+// pool per die size of the character's classes. The dice already spent
+// come out of the old die's pool first, then the largest.
+func migrateHitDice(f Fields) error {
+	var stats Fields
+	if ok, err := f.Get("combat_stats", &stats); !ok || err != nil {
+		return err
+	}
+	raw, ok := stats["hit_dice"]
+	if !ok || !strings.HasPrefix(strings.TrimSpace(string(raw)), "{") {
+		return nil
+	}
+	var old rules.HitDiePool
+	if _, err := stats.Get("hit_dice", &old); err != nil {
+		return err
+	}
+	var classes []rules.ClassLevel
+	if _, err := f.Get("classes", &classes); err != nil {
+		return err
+	}
+	pools := rules.PoolsFor(classes)
+	if len(pools) == 0 {
+		// Without classes there is nothing to split, and the old pool
+		// already has its spent dice taken off.
+		if err := stats.Set("hit_dice", rules.HitDicePools{old}); err != nil {
+			return err
+		}
+		return f.Set("combat_stats", stats)
+	}
+	spent := max(old.Max-old.Remaining, 0)
+	if p, ok := pools.Pool(old.Die); ok {
+		n := min(spent, p.Remaining)
+		p.Remaining -= n
+		spent -= n
+	}
+	for i := range pools {
+		n := min(spent, pools[i].Remaining)
+		pools[i].Remaining -= n
+		spent -= n
+	}
+	if err := stats.Set("hit_dice", pools); err != nil {
+		return err
+	}
+	return f.Set("combat_stats", stats)
+}
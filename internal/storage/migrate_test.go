@@ -0,0 +1,216 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"sheet/internal/rules"
+)
+
+// writeCharacter writes a character file for a migration test and
+// returns its path.
+func writeCharacter(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "aragorn"+Ext)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readCharacter reads a migrated file's combat stats and schema version.
+func readCharacter(t *testing.T, path string) (version int, stats Fields) {
+	t.Helper()
+	fields, err := readFields(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fields.Get(SchemaField, &version); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fields.Get("combat_stats", &stats); err != nil {
+		t.Fatal(err)
+	}
+	return version, stats
+}
+
+func TestMigrateExhaustion(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		level      int
+		rest       []string
+	}{
+		{"none", []string{"Poisoned"}, 0, []string{"Poisoned"}},
+		{"two levels", []string{"Exhaustion", "Poisoned", "exhaustion"}, 2, []string{"Poisoned"}},
+		{"clamped", []string{"Exhaustion", "Exhaustion", "Exhaustion", "Exhaustion", "Exhaustion", "Exhaustion", "Exhaustion"}, 6, nil},
+	}
+	for _, tt := range tests {
+		raw, _ := json.Marshal(tt.conditions)
+		path := writeCharacter(t, `{"name": "Aragorn", "combat_stats": {"conditions": `+string(raw)+`}}`)
+		applied, err := Migrate(path)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(applied) != SchemaVersion() {
+			t.Errorf("%s: applied %d migrations, want %d", tt.name, len(applied), SchemaVersion())
+		}
+		version, stats := readCharacter(t, path)
+		if version != SchemaVersion() {
+			t.Errorf("%s: schema %d, want %d", tt.name, version, SchemaVersion())
+		}
+		var level int
+		var rest []string
+		stats.Get("exhaustion_level", &level)
+		stats.Get("conditions", &rest)
+		if level != tt.level || !reflect.DeepEqual(rest, tt.rest) {
+			t.Errorf("%s: level %d, conditions %v; want %d, %v", tt.name, level, rest, tt.level, tt.rest)
+		}
+	}
+}
+
+func TestMigrateHitDice(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want rules.HitDicePools
+	}{
+		{
+			"spent dice from the old die first",
+			`{"classes": [{"class": "Fighter", "level": 3}, {"class": "Wizard", "level": 2}],
+			  "combat_stats": {"hit_dice": {"die": 10, "remaining": 1, "max": 5}}}`,
+			rules.HitDicePools{{Die: 10, Remaining: 0, Max: 3}, {Die: 6, Remaining: 1, Max: 2}},
+		},
+		{
+			"nothing spent",
+			`{"classes": [{"class": "Rogue", "level": 2}, {"class": "Barbarian", "level": 1}],
+			  "combat_stats": {"hit_dice": {"die": 8, "remaining": 3, "max": 3}}}`,
+			rules.HitDicePools{{Die: 12, Remaining: 1, Max: 1}, {Die: 8, Remaining: 2, Max: 2}},
+		},
+		{
+			"no classes",
+			`{"combat_stats": {"hit_dice": {"die": 8, "remaining": 3, "max": 5}}}`,
+			rules.HitDicePools{{Die: 8, Remaining: 3, Max: 5}},
+		},
+		{
+			"already pools",
+			`{"classes": [{"class": "Wizard", "level": 2}],
+			  "combat_stats": {"hit_dice": [{"die": 6, "remaining": 1, "max": 2}]}}`,
+			rules.HitDicePools{{Die: 6, Remaining: 1, Max: 2}},
+		},
+	}
+	for _, tt := range tests {
+		path := writeCharacter(t, tt.file)
+		if _, err := Migrate(path); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		_, stats := readCharacter(t, path)
+		var got rules.HitDicePools
+		if _, err := stats.Get("hit_dice", &got); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: hit dice %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMigrateBackup(t *testing.T) {
+	original := `{"name": "Aragorn", "combat_stats": {"conditions": ["Exhaustion"]}}`
+	path := writeCharacter(t, original)
+	if _, err := Migrate(path); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := os.ReadFile(BackupPath(path, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(backup) != original {
+		t.Errorf("backup = %s, want the original file", backup)
+	}
+
+	// A second run finds the file current and changes nothing.
+	applied, err := Migrate(path)
+	if err != nil || len(applied) != 0 {
+		t.Errorf("second Migrate applied %d migrations, err %v", len(applied), err)
+	}
+}
+
+func TestListAfterMigrate(t *testing.T) {
+	path := writeCharacter(t, `{"name": "Aragorn", "combat_stats": {"conditions": ["Exhaustion"]}}`)
+	if _, err := Migrate(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := List(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{path}; !slices.Equal(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+	backups, err := Backups(path)
+	if err != nil || len(backups) != 0 {
+		t.Errorf("Backups = %v, %v; the pre-migration copy isn't a rolling backup", backups, err)
+	}
+}
+
+func TestLoadAndSave(t *testing.T) {
+	type character struct {
+		Name          string `json:"name"`
+		SchemaVersion int    `json:"schema_version"`
+		CombatStats   struct {
+			ExhaustionLevel int `json:"exhaustion_level"`
+		} `json:"combat_stats"`
+	}
+	tests := []struct {
+		name        string
+		file        string
+		wantApplied int
+		wantLevel   int
+	}{
+		{"old file", `{"name": "Aragorn", "combat_stats": {"conditions": ["Exhaustion", "Exhaustion"]}}`, 2, 2},
+		{"current file", `{"name": "Aragorn", "schema_version": 2, "combat_stats": {"exhaustion_level": 1}}`, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeCharacter(t, tt.file)
+			var c character
+			applied, err := Load(path, &c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(applied) != tt.wantApplied {
+				t.Errorf("applied %d migrations, want %d", len(applied), tt.wantApplied)
+			}
+			if c.SchemaVersion != SchemaVersion() || c.CombatStats.ExhaustionLevel != tt.wantLevel {
+				t.Errorf("loaded %+v, want schema %d and exhaustion %d", c, SchemaVersion(), tt.wantLevel)
+			}
+
+			// A saved character loads again without migrating.
+			c.SchemaVersion = 0
+			if err := Save(path, c); err != nil {
+				t.Fatal(err)
+			}
+			if applied, err := Load(path, &c); err != nil || len(applied) != 0 {
+				t.Errorf("Load after Save applied %d migrations, err %v", len(applied), err)
+			}
+		})
+	}
+}
+
+func TestMigrateNewerSchema(t *testing.T) {
+	path := writeCharacter(t, `{"schema_version": 999}`)
+	if _, err := Migrate(path); err == nil {
+		t.Error("migrating a newer file succeeded")
+	}
+}
+
+func TestBackupPath(t *testing.T) {
+	if got, want := BackupPath("/chars/aragorn.json", 1), filepath.FromSlash("/chars/backups/aragorn/pre-v2.json"); got != want {
+		t.Errorf("BackupPath = %q, want %q", got, want)
+	}
+}
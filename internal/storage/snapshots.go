@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SnapshotsDirName is the subdirectory of the characters directory that
+// holds milestone snapshots, one folder per character.
+const SnapshotsDirName = "snapshots"
+
+// snapshotIndex lists a character's snapshots in its snapshot folder.
+const snapshotIndex = "index.json"
+
+// Milestone is the event that triggered a snapshot.
+type Milestone string
+
+const (
+	MilestoneLevelUp    Milestone = "level-up"
+	MilestoneSessionEnd Milestone = "session-end"
+	MilestoneManual     Milestone = "manual"
+)
+
+// SnapshotPolicy chooses which milestones snapshot automatically. Manual
+// snapshots are always allowed.
+type SnapshotPolicy struct {
+	LevelUp    bool `json:"level_up"`
+	SessionEnd bool `json:"session_end"`
+}
+
+// DefaultSnapshotPolicy snapshots on every milestone.
+var DefaultSnapshotPolicy = SnapshotPolicy{LevelUp: true, SessionEnd: true}
+
+// Allows reports whether the policy takes a snapshot at m.
+func (p SnapshotPolicy) Allows(m Milestone) bool {
+	switch m {
+	case MilestoneLevelUp:
+		return p.LevelUp
+	case MilestoneSessionEnd:
+		return p.SessionEnd
+	}
+	return true
+}
+
+// Snapshot is a saved copy of a character file. Unlike backups, snapshots
+// are never pruned.
+type Snapshot struct {
+	Name      string    `json:"name"`
+	Milestone Milestone `json:"milestone"`
+	Time      time.Time `json:"time"`
+	// File is relative to the character's snapshot folder.
+	File string `json:"file"`
+}
+
+// SnapshotDir returns the folder holding a character's snapshots.
+func SnapshotDir(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), Ext)
+	return filepath.Join(filepath.Dir(path), SnapshotsDirName, name)
+}
+
+// Snapshots lists a character's snapshots, oldest first.
+func Snapshots(path string) ([]Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(SnapshotDir(path), snapshotIndex))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	var snaps []Snapshot
+	if err := json.Unmarshal(data, &snaps); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshots: %w", err)
+	}
+	return snaps, nil
+}
+
+// TakeSnapshot copies the character file into its snapshot folder under
+// name, e.g. "Level 5". It does nothing and returns false if the policy
+// skips the milestone.
+func TakeSnapshot(path, name string, m Milestone, p SnapshotPolicy, now time.Time) (Snapshot, bool, error) {
+	if !p.Allows(m) {
+		return Snapshot{}, false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, false, fmt.Errorf("failed to read character: %w", err)
+	}
+	snaps, err := Snapshots(path)
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+
+	dir := SnapshotDir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Snapshot{}, false, fmt.Errorf("failed to create snapshot folder: %w", err)
+	}
+	s := Snapshot{
+		Name:      name,
+		Milestone: m,
+		Time:      now,
+		File:      now.Format("20060102-150405") + "-" + cmp.Or(Slug(name), "snapshot") + Ext,
+	}
+	if err := os.WriteFile(filepath.Join(dir, s.File), data, 0o644); err != nil {
+		return Snapshot{}, false, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := writeSnapshotIndex(dir, append(snaps, s)); err != nil {
+		return Snapshot{}, false, err
+	}
+	return s, true, nil
+}
+
+// ReadSnapshot returns the character file saved in a snapshot.
+func ReadSnapshot(path string, s Snapshot) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(SnapshotDir(path), s.File))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %q: %w", s.Name, err)
+	}
+	return data, nil
+}
+
+// DeleteSnapshot removes a snapshot and its file.
+func DeleteSnapshot(path string, s Snapshot) error {
+	snaps, err := Snapshots(path)
+	if err != nil {
+		return err
+	}
+	dir := SnapshotDir(path)
+	kept := snaps[:0]
+	for _, other := range snaps {
+		if other.File != s.File {
+			kept = append(kept, other)
+		}
+	}
+	if err := writeSnapshotIndex(dir, kept); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, s.File)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+	return nil
+}
+
+func writeSnapshotIndex(dir string, snaps []Snapshot) error {
+	data, err := json.MarshalIndent(snaps, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshots: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, snapshotIndex), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	return nil
+}
+
+// Slug lowercases name and replaces anything but letters and digits with
+// dashes, for use in file names. It is empty if name has neither.
+func Slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
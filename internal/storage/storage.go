@@ -0,0 +1,124 @@
+// Package storage manages character files on disk: listing, archiving,
+// backups, snapshots, schema migrations and the sidecar files stored next
+// to each character.
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Ext is the extension of character files.
+const Ext = ".json"
+
+// The kinds of sidecar file stored next to a character, as in
+// "name.rolls.json".
+const (
+	RollsSidecar    = "rolls"
+	JournalSidecar  = "journal"
+	PreFixupSidecar = "pre-fixup"
+)
+
+var sidecarKinds = []string{RollsSidecar, JournalSidecar, PreFixupSidecar}
+
+// SidecarPath returns where the sidecar of the given kind is stored next
+// to a character file, e.g. "aragorn.json" → "aragorn.rolls.json".
+func SidecarPath(path, kind string) string {
+	return strings.TrimSuffix(path, Ext) + "." + kind + Ext
+}
+
+// isCharacterFile reports whether name is a character file rather than a
+// sidecar like "name.rolls.json". Other dots are part of the name, as in
+// "Dr. Who.json".
+func isCharacterFile(name string) bool {
+	base, ok := strings.CutSuffix(name, Ext)
+	if !ok || base == "" {
+		return false
+	}
+	for _, kind := range sidecarKinds {
+		if strings.HasSuffix(base, "."+kind) {
+			return false
+		}
+	}
+	return true
+}
+
+// List returns the paths of the character files in dir, sorted by name.
+// Subdirectories such as the archive are not searched.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list characters: %w", err)
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && isCharacterFile(e.Name()) {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// Sidecars returns the existing files stored alongside a character, such as
+// its roll history ("name.rolls.json").
+func Sidecars(path string) ([]string, error) {
+	var out []string
+	for _, kind := range sidecarKinds {
+		p := SidecarPath(path, kind)
+		if _, err := os.Stat(p); err == nil {
+			out = append(out, p)
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// moveWithSidecars renames a character file, its sidecars, snapshots and
+// backups into dir.
+func moveWithSidecars(path, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	sidecars, err := Sidecars(path)
+	if err != nil {
+		return "", err
+	}
+	dest := filepath.Join(dir, filepath.Base(path))
+	if _, err := os.Stat(dest); err == nil {
+		return "", fmt.Errorf("%s already exists", dest)
+	}
+	if err := os.Rename(path, dest); err != nil {
+		return "", fmt.Errorf("failed to move character: %w", err)
+	}
+	for _, s := range sidecars {
+		if err := os.Rename(s, filepath.Join(dir, filepath.Base(s))); err != nil {
+			return dest, fmt.Errorf("failed to move %s: %w", filepath.Base(s), err)
+		}
+	}
+	for _, folder := range []func(string) string{SnapshotDir, BackupDir} {
+		from, to := folder(path), folder(dest)
+		if !dirExists(from) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+			return dest, fmt.Errorf("failed to move %s: %w", from, err)
+		}
+		if err := os.Rename(from, to); err != nil {
+			return dest, fmt.Errorf("failed to move %s: %w", from, err)
+		}
+	}
+	return dest, nil
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
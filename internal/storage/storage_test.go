@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestIsCharacterFile(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"aragorn.json", true},
+		{"Dr. Who.json", true},
+		{"J.R.R. Tolkien.json", true},
+		{"aragorn.rolls.json", false},
+		{"aragorn.journal.json", false},
+		{"aragorn.pre-fixup.json", false},
+		{"aragorn.journal.md", false},
+		{".json", false},
+		{"notes.txt", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCharacterFile(tt.name); got != tt.want {
+				t.Errorf("isCharacterFile(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+// touch creates empty files in dir.
+func touch(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, n := range names {
+		if err := os.WriteFile(filepath.Join(dir, n), []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "Dr. Who.json", "Dr. Who.rolls.json", "aragorn.json", "aragorn.journal.json")
+	got, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "Dr. Who.json"), filepath.Join(dir, "aragorn.json")}
+	if !slices.Equal(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+}
+
+func TestSidecarsIgnoreSimilarNames(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "Dr.json", "Dr.rolls.json", "Dr. Who.json")
+	got, err := Sidecars(filepath.Join(dir, "Dr.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "Dr.rolls.json")}
+	if !slices.Equal(got, want) {
+		t.Errorf("Sidecars = %v, want %v", got, want)
+	}
+}
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "Dr. Who.json", "Dr. Who.journal.json")
+	d := Death{Date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Cause: "Dalek"}
+	dest, err := Archive(filepath.Join(dir, "Dr. Who.json"), d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(ArchiveDir(dir), "Dr. Who.json"); dest != want {
+		t.Errorf("Archive = %q, want %q", dest, want)
+	}
+	if _, err := os.Stat(filepath.Join(ArchiveDir(dir), "Dr. Who.journal.json")); err != nil {
+		t.Errorf("journal not archived: %v", err)
+	}
+	got, ok, err := ReadDeath(dest)
+	if err != nil || !ok || got != d {
+		t.Errorf("ReadDeath = %v, %v, %v; want %v", got, ok, err, d)
+	}
+}
+
+func TestArchiveLeavesUnmovedCharacterAlive(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "aragorn.json")
+	// A file where the archive directory should be stops the move.
+	touch(t, dir, ArchiveDirName)
+	path := filepath.Join(dir, "aragorn.json")
+	if _, err := Archive(path, Death{Cause: "Orcs"}); err == nil {
+		t.Fatal("Archive succeeded without an archive directory")
+	}
+	if _, ok, err := ReadDeath(path); err != nil || ok {
+		t.Errorf("ReadDeath = %v, %v; want no death record", ok, err)
+	}
+}
@@ -0,0 +1,73 @@
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/events"
+)
+
+// WildMagicSurge is the name of the surge table in tables.json.
+const WildMagicSurge = "Wild Magic Surge"
+
+// ShouldCheckSurge reports whether casting a spell of the given level
+// should offer a surge check: Wild Magic sorcerers surge on leveled spells
+// only.
+func ShouldCheckSurge(subclass string, spellLevel int) bool {
+	return strings.EqualFold(subclass, "Wild Magic") && spellLevel > 0
+}
+
+// Surge is the outcome of a surge check and, if it triggered, the roll on
+// the surge table.
+type Surge struct {
+	// Check is the d20 rolled; a 1 surges.
+	Check  int
+	Surged bool
+	Result Result
+	// Healing is set when the surge result heals the sorcerer.
+	Healing *dice.Result
+}
+
+func (s Surge) String() string {
+	if !s.Surged {
+		return fmt.Sprintf("No surge (rolled %d)", s.Check)
+	}
+	msg := "Wild Magic Surge! " + s.Result.Entry.Text()
+	if s.Healing != nil {
+		msg += fmt.Sprintf(" (regained %d HP)", s.Healing.Total)
+	}
+	return msg
+}
+
+// RollSurge makes the d20 surge check and, on a 1, rolls on the surge table
+// and publishes the result for the session log. Healing results are rolled
+// so the caller can apply them.
+func RollSurge(ts []Table, r *dice.Roller, bus *events.Bus, character string) (Surge, error) {
+	table, ok := Find(ts, WildMagicSurge)
+	if !ok {
+		return Surge{}, fmt.Errorf("no %s table loaded", WildMagicSurge)
+	}
+
+	s := Surge{Check: r.Roll(dice.MustParse("1d20")).Total}
+	if s.Check != 1 {
+		return s, nil
+	}
+	s.Surged = true
+
+	res, err := RollAndPublish(table, r, bus, character)
+	if err != nil {
+		return Surge{}, err
+	}
+	s.Result = res
+
+	if res.Entry.Heal != "" {
+		expr, err := dice.Parse(res.Entry.Heal)
+		if err != nil {
+			return Surge{}, fmt.Errorf("surge result %d: %w", res.Roll, err)
+		}
+		heal := r.Roll(expr)
+		s.Healing = &heal
+	}
+	return s, nil
+}
@@ -0,0 +1,181 @@
+// Package tables rolls on random tables: encounters, trinkets, wild magic
+// surges and anything else a data file describes.
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/dice"
+	"sheet/internal/events"
+)
+
+// File is the data file tables are loaded from.
+const File = "tables.json"
+
+// BrutalCriticalHits is the name of the table the brutal crit house rule
+// rolls on.
+const BrutalCriticalHits = "Brutal Critical Hits"
+
+// Entry is one row of a table. Rows of dice tables cover the faces Min to
+// Max; rows of weighted tables are picked in proportion to Weight.
+type Entry struct {
+	Min    int    `json:"min,omitempty"`
+	Max    int    `json:"max,omitempty"`
+	Weight int    `json:"weight,omitempty"`
+	Result string `json:"result"`
+	// Heal is healing the result grants the roller, in dice notation, for
+	// results the app can apply itself.
+	Heal string `json:"heal,omitempty"`
+}
+
+// Text is the entry's result. Tables whose text isn't ours to ship, like
+// the Wild Magic Surge table, come with their rows but no results; a user
+// adds the text by copying the table into a homebrew tables.json. Until
+// then Text names the row instead.
+func (e Entry) Text() string {
+	switch {
+	case e.Result != "":
+		return e.Result
+	case e.Min == e.Max:
+		return fmt.Sprintf("row %d (add its text in a homebrew tables.json)", e.Min)
+	}
+	return fmt.Sprintf("row %d-%d (add its text in a homebrew tables.json)", e.Min, e.Max)
+}
+
+// Table is a random table. If Dice is set (e.g. "1d100") rows are matched
+// by range; otherwise the table is weighted and each row's weight defaults
+// to 1.
+type Table struct {
+	Name     string  `json:"name"`
+	Category string  `json:"category,omitempty"`
+	Dice     string  `json:"dice,omitempty"`
+	Entries  []Entry `json:"entries"`
+}
+
+// Result is the outcome of rolling on a table.
+type Result struct {
+	Table string
+	Roll  int
+	Entry Entry
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s (%d): %s", r.Table, r.Roll, r.Entry.Text())
+}
+
+// Validate checks that the table can be rolled: dice tables must cover
+// every possible roll exactly once.
+func (t Table) Validate() error {
+	if len(t.Entries) == 0 {
+		return fmt.Errorf("table %s has no entries", t.Name)
+	}
+	if t.Dice == "" {
+		for i, e := range t.Entries {
+			if e.Weight < 0 {
+				return fmt.Errorf("table %s: entry %d has negative weight", t.Name, i+1)
+			}
+		}
+		return nil
+	}
+
+	expr, err := dice.Parse(t.Dice)
+	if err != nil {
+		return fmt.Errorf("table %s: %w", t.Name, err)
+	}
+	for v := expr.Min(); v <= expr.Max(); v++ {
+		matches := 0
+		for _, e := range t.Entries {
+			if v >= e.Min && v <= e.Max {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fmt.Errorf("table %s: roll %d matches %d entries", t.Name, v, matches)
+		}
+	}
+	return nil
+}
+
+// Roll rolls on the table.
+func (t Table) Roll(r *dice.Roller) (Result, error) {
+	if err := t.Validate(); err != nil {
+		return Result{}, err
+	}
+	if t.Dice != "" {
+		roll := r.Roll(dice.MustParse(t.Dice)).Total
+		for _, e := range t.Entries {
+			if roll >= e.Min && roll <= e.Max {
+				return Result{Table: t.Name, Roll: roll, Entry: e}, nil
+			}
+		}
+		return Result{}, fmt.Errorf("table %s: no entry for %d", t.Name, roll)
+	}
+
+	total := 0
+	for _, e := range t.Entries {
+		total += weight(e)
+	}
+	if total == 0 {
+		return Result{}, fmt.Errorf("table %s has no weight", t.Name)
+	}
+	roll := r.Roll(dice.MustParse(fmt.Sprintf("1d%d", total))).Total
+	n := roll
+	for _, e := range t.Entries {
+		n -= weight(e)
+		if n <= 0 {
+			return Result{Table: t.Name, Roll: roll, Entry: e}, nil
+		}
+	}
+	return Result{}, fmt.Errorf("table %s: no entry for %d", t.Name, roll)
+}
+
+func weight(e Entry) int {
+	if e.Weight == 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+// Load reads every table from the data directories.
+func Load(o *data.Overlay) ([]Table, error) {
+	var ts []Table
+	if err := o.Load(File, &ts); err != nil {
+		return nil, err
+	}
+	for _, t := range ts {
+		if err := t.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return ts, nil
+}
+
+// Find returns the named table.
+func Find(ts []Table, name string) (Table, bool) {
+	for _, t := range ts {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return Table{}, false
+}
+
+// RollAndPublish rolls on the table and publishes the result to the bus
+// for the session log.
+func RollAndPublish(t Table, r *dice.Roller, bus *events.Bus, character string) (Result, error) {
+	res, err := t.Roll(r)
+	if err != nil {
+		return Result{}, err
+	}
+	if bus != nil {
+		bus.Publish(events.TableRolled{
+			Character: character,
+			Table:     res.Table,
+			Roll:      res.Roll,
+			Result:    res.Entry.Text(),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,112 @@
+package tables
+
+import (
+	"testing"
+
+	"sheet/internal/data"
+	"sheet/internal/dice"
+	"sheet/internal/events"
+)
+
+func loadShipped(t *testing.T) []Table {
+	t.Helper()
+	ts, err := Load(data.NewOverlay("../../data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestShippedTables(t *testing.T) {
+	ts := loadShipped(t)
+	categories := map[string]int{}
+	for _, tb := range ts {
+		categories[tb.Category]++
+	}
+	for _, c := range []string{"encounter", "trinket", "class", "house rule"} {
+		if categories[c] == 0 {
+			t.Errorf("no %s tables in tables.json", c)
+		}
+	}
+	for _, name := range []string{WildMagicSurge, BrutalCriticalHits, "Trinkets"} {
+		if _, ok := Find(ts, name); !ok {
+			t.Errorf("no %s table", name)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   Table
+		wantErr bool
+	}{
+		{"weighted", Table{Entries: []Entry{{Weight: 2}, {}}}, false},
+		{"covered dice", Table{Dice: "1d4", Entries: []Entry{{Min: 1, Max: 2}, {Min: 3, Max: 4}}}, false},
+		{"empty", Table{}, true},
+		{"negative weight", Table{Entries: []Entry{{Weight: -1}}}, true},
+		{"gap", Table{Dice: "1d4", Entries: []Entry{{Min: 1, Max: 2}, {Min: 4, Max: 4}}}, true},
+		{"overlap", Table{Dice: "1d4", Entries: []Entry{{Min: 1, Max: 3}, {Min: 3, Max: 4}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.table.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRollAndPublish(t *testing.T) {
+	ts := loadShipped(t)
+	bus := events.NewBus()
+	var logged []events.TableRolled
+	bus.Subscribe(events.TypeTableRolled, func(e events.Event) {
+		logged = append(logged, e.(events.TableRolled))
+	})
+	r := dice.NewRoller(7)
+	for _, name := range []string{"Forest Encounters", "Dungeon Encounters", "Trinkets"} {
+		tb, _ := Find(ts, name)
+		res, err := RollAndPublish(tb, r, bus, "Tordek")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if res.Entry.Result == "" {
+			t.Errorf("%s rolled %d with no result", name, res.Roll)
+		}
+	}
+	if len(logged) != 3 || logged[0].Character != "Tordek" || logged[0].Table != "Forest Encounters" {
+		t.Errorf("logged %+v", logged)
+	}
+}
+
+func TestEntryText(t *testing.T) {
+	tests := []struct {
+		entry Entry
+		want  string
+	}{
+		{Entry{Min: 1, Max: 2, Result: "A modron appears."}, "A modron appears."},
+		{Entry{Min: 7, Max: 8}, "row 7-8 (add its text in a homebrew tables.json)"},
+		{Entry{Min: 100, Max: 100}, "row 100 (add its text in a homebrew tables.json)"},
+	}
+	for _, tt := range tests {
+		if got := tt.entry.Text(); got != tt.want {
+			t.Errorf("Text() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestShippedSurgeTableHasNoText(t *testing.T) {
+	tb, ok := Find(loadShipped(t), WildMagicSurge)
+	if !ok {
+		t.Fatal("no surge table")
+	}
+	if err := tb.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range tb.Entries {
+		if e.Result != "" {
+			t.Errorf("row %d-%d ships text; the surge table isn't SRD content", e.Min, e.Max)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package templates saves characters as reusable templates (race, class,
+// ability scores, skills and starting equipment) for the creation wizard's
+// quick-create path, when the table needs a replacement character fast.
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sheet/internal/inventory"
+	"sheet/internal/rules"
+	"sheet/internal/storage"
+)
+
+// DirName is the directory in the config directory templates are stored
+// in, one file per template.
+const DirName = "templates"
+
+// Template is a character's creation choices without its identity or
+// progress.
+type Template struct {
+	Name       string `json:"name"`
+	Race       string `json:"race"`
+	Subrace    string `json:"subrace,omitempty"`
+	Class      string `json:"class"`
+	Background string `json:"background,omitempty"`
+
+	AbilityMethod rules.AbilityMethod `json:"ability_method"`
+	// Scores are the final scores, including racial increases.
+	Scores rules.Scores `json:"scores"`
+	Skills []string     `json:"skills,omitempty"`
+
+	Equipment []inventory.Item `json:"equipment,omitempty"`
+}
+
+// Target is the character a template is applied to. The creation wizard
+// implements it over the character being created.
+type Target interface {
+	SetIdentity(name, player string)
+	SetRace(race, subrace string)
+	SetClass(class string)
+	SetBackground(background string)
+	SetScores(method rules.AbilityMethod, scores rules.Scores)
+	AddSkillProficiency(skill string)
+	AddItem(it inventory.Item)
+}
+
+// Apply fills in t from the template. Only the name and player come from
+// the player.
+func (tp Template) Apply(t Target, name, player string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("a character needs a name")
+	}
+	t.SetIdentity(name, strings.TrimSpace(player))
+	t.SetRace(tp.Race, tp.Subrace)
+	t.SetClass(tp.Class)
+	if tp.Background != "" {
+		t.SetBackground(tp.Background)
+	}
+	t.SetScores(tp.AbilityMethod, tp.Scores)
+	for _, s := range tp.Skills {
+		t.AddSkillProficiency(s)
+	}
+	for _, it := range tp.Equipment {
+		t.AddItem(it)
+	}
+	return nil
+}
+
+// Path returns where the named template is stored in dir.
+func Path(dir, name string) string {
+	return filepath.Join(dir, storage.Slug(name)+storage.Ext)
+}
+
+// Save writes the template to dir, replacing any template with the same
+// name.
+func Save(dir string, t Template) error {
+	if storage.Slug(t.Name) == "" {
+		return fmt.Errorf("a template needs a name")
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal template: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+	if err := os.WriteFile(Path(dir, t.Name), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+	return nil
+}
+
+// List reads every template in dir, sorted by name. A missing directory
+// has no templates.
+func List(dir string) ([]Template, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	var ts []Template
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != storage.Ext {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		var t Template
+		if err := json.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", e.Name(), err)
+		}
+		ts = append(ts, t)
+	}
+	slices.SortFunc(ts, func(a, b Template) int { return strings.Compare(a.Name, b.Name) })
+	return ts, nil
+}
+
+// Delete removes the named template from dir.
+func Delete(dir, name string) error {
+	if err := os.Remove(Path(dir, name)); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	return nil
+}
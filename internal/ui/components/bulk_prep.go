@@ -0,0 +1,128 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/spellbook"
+	"sheet/internal/ui/keys"
+)
+
+// bulkOp is a bulk preparation operation waiting for its argument.
+type bulkOp int
+
+const (
+	bulkNone bulkOp = iota
+	bulkPrepareLevel
+	bulkUnprepareLevel
+	bulkTop
+)
+
+// BulkPrep is the bulk operations of the spellbook's preparation mode,
+// for big loadout changes:
+//
+//	R         prepare every known ritual
+//	A then n  prepare every known spell of level n
+//	U then n  unprepare every spell of level n; U then 0 unprepares all
+//	T then n  prepare the n highest-level unprepared spells
+//
+// Preparing stops at the maximum; the spells that didn't fit are listed.
+type BulkPrep struct {
+	book    *spellbook.Spellbook
+	spells  []data.Spell
+	max     int
+	pending bulkOp
+	status  string
+	dirty   bool
+}
+
+// NewBulkPrep returns the bulk operations for book, described by spells,
+// where at most max spells can be prepared.
+func NewBulkPrep(book *spellbook.Spellbook, spells []data.Spell, max int) *BulkPrep {
+	return &BulkPrep{book: book, spells: spells, max: max}
+}
+
+// Dirty reports whether the spellbook changed since the last save.
+func (p *BulkPrep) Dirty() bool {
+	return p.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the character.
+func (p *BulkPrep) MarkSaved() {
+	p.dirty = false
+}
+
+// Status returns the result of the last operation, or the prompt for the
+// pending one.
+func (p *BulkPrep) Status() string {
+	return p.status
+}
+
+// HandleKey reports whether the key was used.
+func (p *BulkPrep) HandleKey(key string) bool {
+	k := keys.Normalize(key)
+	if p.pending != bulkNone {
+		return p.handleArg(k)
+	}
+	switch k {
+	case "R":
+		prepared, skipped := p.book.PrepareMatching(p.spells, data.SpellFilter{RitualOnly: true}, p.max)
+		p.report("rituals", prepared, skipped)
+	case "A":
+		p.pending, p.status = bulkPrepareLevel, "Prepare all spells of level (1-9)?"
+	case "U":
+		p.pending, p.status = bulkUnprepareLevel, "Unprepare all spells of level (1-9, 0 for every level)?"
+	case "T":
+		p.pending, p.status = bulkTop, "Prepare how many of the highest-level spells (1-9)?"
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *BulkPrep) handleArg(k string) bool {
+	op := p.pending
+	p.pending = bulkNone
+	if k == "esc" {
+		p.status = ""
+		return true
+	}
+	if len(k) != 1 || k < "0" || k > "9" {
+		p.status = "Cancelled"
+		return true
+	}
+	n := int(k[0] - '0')
+	switch op {
+	case bulkPrepareLevel:
+		prepared, skipped := p.book.PrepareMatching(p.spells, levelFilter(n), p.max)
+		p.report(fmt.Sprintf("level %d spells", n), prepared, skipped)
+	case bulkUnprepareLevel:
+		f := levelFilter(n)
+		what := fmt.Sprintf("level %d spells", n)
+		if n == 0 {
+			f, what = data.SpellFilter{}, "spells"
+		}
+		removed := p.book.UnprepareMatching(p.spells, f)
+		p.dirty = p.dirty || len(removed) > 0
+		p.status = fmt.Sprintf("Unprepared %d %s", len(removed), what)
+	case bulkTop:
+		prepared := p.book.PrepareTop(p.spells, n, p.max)
+		p.report("spells", prepared, nil)
+	}
+	return true
+}
+
+func levelFilter(level int) data.SpellFilter {
+	return data.SpellFilter{LevelSet: true, MinLevel: level, MaxLevel: level}
+}
+
+// report sets the status after preparing spells, e.g. "Prepared 3
+// rituals; no room for Alarm".
+func (p *BulkPrep) report(what string, prepared, skipped []string) {
+	p.dirty = p.dirty || len(prepared) > 0
+	p.status = fmt.Sprintf("Prepared %d %s (%d/%d)", len(prepared), what, len(p.book.Prepared), p.max)
+	if len(skipped) > 0 {
+		p.status += "; no room for " + strings.Join(skipped, ", ")
+	}
+}
@@ -0,0 +1,202 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"sheet/internal/models"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// infoField is one editable entry in the character info view. Enter
+// finishes editing a one-line field instead of starting a new line.
+type infoField struct {
+	label   string
+	value   *string
+	oneLine bool
+}
+
+// CharacterInfo is the character info view's state: a cursor over the
+// text fields and, while editing, a buffer for the selected field. Edits
+// are written back to the details on Esc and the view is marked dirty so
+// the caller can autosave.
+type CharacterInfo struct {
+	details *models.CharacterDetails
+	cursor  int
+	editing bool
+	buffer  string
+	dirty   bool
+}
+
+// NewCharacterInfo returns a view over d. Edits modify d in place.
+func NewCharacterInfo(d *models.CharacterDetails) *CharacterInfo {
+	return &CharacterInfo{details: d}
+}
+
+// descriptionFields are the descriptive fields, also asked for by the
+// creation wizard's DescriptionStep.
+func descriptionFields(d *models.CharacterDetails) []infoField {
+	var fields []infoField
+	for _, f := range d.DescriptiveFields() {
+		fields = append(fields, infoField{f.Label, f.Value, f.OneLine})
+	}
+	return fields
+}
+
+func (c *CharacterInfo) fields() []infoField {
+	d := c.details
+	fields := append(descriptionFields(d),
+		infoField{"Personality Traits", &d.PersonalityTraits, false},
+		infoField{"Ideals", &d.Ideals, false},
+		infoField{"Bonds", &d.Bonds, false},
+		infoField{"Flaws", &d.Flaws, false},
+		infoField{"Backstory", &d.Backstory, false},
+		infoField{"Allies", &d.Allies, false},
+	)
+	for i := range d.Features {
+		f := &d.Features[i]
+		label := f.Name
+		if f.Source != "" {
+			label = fmt.Sprintf("%s (%s)", f.Name, f.Source)
+		}
+		fields = append(fields, infoField{label, &f.Description, false})
+	}
+	return fields
+}
+
+// Editing reports whether a field is being edited; the parent view should
+// then pass every key through instead of handling its own bindings.
+func (c *CharacterInfo) Editing() bool {
+	return c.editing
+}
+
+// Dirty reports whether there are edits that haven't been saved.
+func (c *CharacterInfo) Dirty() bool {
+	return c.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the character.
+func (c *CharacterInfo) MarkSaved() {
+	c.dirty = false
+}
+
+// HandleKey moves the cursor and starts editing on Enter. While editing,
+// printable keys are typed into the field, Enter inserts a newline (or
+// keeps the edit of a one-line field), Esc keeps the edit and ctrl+c
+// discards it. It reports whether the key was used.
+func (c *CharacterInfo) HandleKey(key string) bool {
+	if c.editing {
+		return c.handleEditKey(key)
+	}
+	n := len(c.fields())
+	switch keys.Normalize(key) {
+	case "up", "k":
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case "down", "j":
+		if c.cursor < n-1 {
+			c.cursor++
+		}
+	case "enter", "e":
+		c.editing = true
+		c.buffer = *c.fields()[c.cursor].value
+	default:
+		return false
+	}
+	return true
+}
+
+func (c *CharacterInfo) handleEditKey(key string) bool {
+	field := c.fields()[c.cursor]
+	switch k := keys.Normalize(key); {
+	case k == "esc", k == "enter" && field.oneLine:
+		if *field.value != c.buffer {
+			*field.value = c.buffer
+			c.dirty = true
+		}
+		c.editing = false
+	case k == "ctrl+c":
+		c.editing = false
+	case k == "enter":
+		c.buffer += "\n"
+	default:
+		return typeInto(&c.buffer, key)
+	}
+	return true
+}
+
+// View renders the fields, wrapping text to width; one-line fields share
+// a line with their label. The selected field is marked, and while
+// editing shows the buffer with a cursor.
+func (c *CharacterInfo) View(width int, g glyphs.Set) string {
+	var b strings.Builder
+	langs := g.None
+	if len(c.details.Languages) > 0 {
+		langs = strings.Join(c.details.Languages, ", ")
+	}
+	for _, line := range wrap("Languages: "+langs+" (L to edit)", width) {
+		b.WriteString(line + "\n")
+	}
+	orgs := g.None
+	if len(c.details.Organizations) > 0 {
+		labels := make([]string, len(c.details.Organizations))
+		for i, o := range c.details.Organizations {
+			labels[i] = o.Label()
+		}
+		orgs = strings.Join(labels, "; ")
+	}
+	for _, line := range wrap("Organizations: "+orgs+" (O to edit)", width) {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+	for i, f := range c.fields() {
+		marker := "  "
+		if i == c.cursor {
+			marker = "> "
+		}
+		text := *f.value
+		if i == c.cursor && c.editing {
+			text = c.buffer + g.TextCursor
+		}
+		if text == "" {
+			text = g.None
+		}
+		if f.oneLine {
+			b.WriteString(marker + f.label + ": " + text + "\n")
+			continue
+		}
+		b.WriteString(marker + f.label + "\n")
+		for _, line := range wrap(text, width-4) {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// wrap splits text into lines no wider than width, breaking on spaces and
+// keeping the text's own line breaks.
+func wrap(text string, width int) []string {
+	if width <= 0 {
+		return strings.Split(text, "\n")
+	}
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			switch {
+			case line == "":
+				line = word
+			case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
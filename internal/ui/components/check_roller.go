@@ -0,0 +1,204 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/effects"
+	"sheet/internal/rules"
+	"sheet/internal/ui/keys"
+)
+
+// SkillRoller is the interactive state of the Skills panel: a cursor over
+// the skill list and an advantage toggle. Enter rolls the selected skill.
+type SkillRoller struct {
+	cursor int
+	mode   dice.Mode
+	last   *RollEntry
+	optOut
+}
+
+// NewSkillRoller returns a roller with the cursor on the first skill.
+func NewSkillRoller() *SkillRoller {
+	return &SkillRoller{}
+}
+
+// Selected returns the skill under the cursor.
+func (s *SkillRoller) Selected() rules.Skill {
+	return rules.Skills[s.cursor]
+}
+
+// Cursor returns the index of the selected skill in rules.Skills.
+func (s *SkillRoller) Cursor() int {
+	return s.cursor
+}
+
+// Mode returns the advantage state for the next roll.
+func (s *SkillRoller) Mode() dice.Mode {
+	return s.mode
+}
+
+// Last returns the most recent roll, shown inline next to the skill.
+func (s *SkillRoller) Last() *RollEntry {
+	return s.last
+}
+
+// HandleKey moves the cursor or toggles advantage ('a') and disadvantage
+// ('d'). It reports whether the key was used and whether a roll was asked
+// for.
+func (s *SkillRoller) HandleKey(key string) (handled, roll bool) {
+	return handleRollerKey(key, &s.cursor, len(rules.Skills), &s.mode)
+}
+
+// Roll rolls a check with the selected skill. profs maps skill names to
+// proficiency; active effects such as Guidance are folded in. The
+// advantage toggle resets after each roll.
+func (s *SkillRoller) Roll(r *dice.Roller, scores rules.Scores, profs map[string]rules.Proficiency, profBonus int, active []effects.Effect) (RollEntry, []effects.Effect, error) {
+	skill := s.Selected()
+	mod := rules.CheckModifier(scores[skill.Ability], profs[skill.Name], profBonus)
+	roll := effects.Roll{Type: effects.CheckRoll, Ability: skill.Ability, Skill: skill.Name}
+	active = s.optOut.apply(active)
+
+	expr, applied, err := effects.D20Roll(roll, mod, s.mode, active)
+	if err != nil {
+		return RollEntry{}, nil, err
+	}
+
+	entry := NewRollEntry(RollCheck, fmt.Sprintf("%s check", skill.Name), r.Roll(expr))
+	if s.mode != dice.Normal {
+		entry.Note = s.mode.String()
+	}
+	s.last = &entry
+	s.mode = dice.Normal
+	return entry, applied, nil
+}
+
+// SaveRoller is the interactive state of the Abilities & Saving Throws
+// panel: a cursor over the six abilities and an advantage toggle.
+type SaveRoller struct {
+	cursor int
+	mode   dice.Mode
+	last   *RollEntry
+	optOut
+}
+
+// NewSaveRoller returns a roller with the cursor on Strength.
+func NewSaveRoller() *SaveRoller {
+	return &SaveRoller{}
+}
+
+// Selected returns the ability under the cursor.
+func (s *SaveRoller) Selected() rules.Ability {
+	return rules.Abilities[s.cursor]
+}
+
+// Mode returns the advantage state chosen for the next roll.
+func (s *SaveRoller) Mode() dice.Mode {
+	return s.mode
+}
+
+// Last returns the most recent save.
+func (s *SaveRoller) Last() *RollEntry {
+	return s.last
+}
+
+// HandleKey behaves like SkillRoller.HandleKey over the abilities.
+func (s *SaveRoller) HandleKey(key string) (handled, roll bool) {
+	return handleRollerKey(key, &s.cursor, len(rules.Abilities), &s.mode)
+}
+
+// Suggest reports what the character's active effects and conditions imply
+// for the selected save, e.g. disadvantage on DEX saves while Restrained.
+func (s *SaveRoller) Suggest(active []effects.Effect) effects.Suggestion {
+	return effects.Suggest(active, effects.Roll{Type: effects.SavingRoll, Ability: s.Selected()})
+}
+
+// Roll rolls a saving throw with the selected ability, applying
+// proficiency and active effects. Saves that conditions make fail
+// automatically are still rolled but marked as failed in the entry note.
+func (s *SaveRoller) Roll(r *dice.Roller, scores rules.Scores, saves rules.SavingThrows, profBonus int, active []effects.Effect) (RollEntry, []effects.Effect, error) {
+	ability := s.Selected()
+	prof := rules.NotProficient
+	if saves.Proficient(ability) {
+		prof = rules.Proficient
+	}
+	mod := rules.CheckModifier(scores[ability], prof, profBonus)
+	roll := effects.Roll{Type: effects.SavingRoll, Ability: ability}
+	active = s.optOut.apply(active)
+
+	expr, applied, err := effects.D20Roll(roll, mod, s.mode, active)
+	if err != nil {
+		return RollEntry{}, nil, err
+	}
+
+	entry := NewRollEntry(RollSave, fmt.Sprintf("%s save", ability), r.Roll(expr))
+	suggestion := effects.Suggest(active, roll)
+	switch {
+	case suggestion.AutoFail:
+		entry.Note = "automatic failure: " + strings.Join(suggestion.Reasons, ", ")
+	case s.mode != dice.Normal:
+		entry.Note = s.mode.String()
+	}
+	s.last = &entry
+	s.mode = dice.Normal
+	return entry, applied, nil
+}
+
+// handleRollerKey implements the shared cursor and advantage keys of the
+// rolling panels.
+func handleRollerKey(key string, cursor *int, n int, mode *dice.Mode) (handled, roll bool) {
+	switch {
+	case keys.Matches(key, "up", "k"):
+		*cursor = (*cursor - 1 + n) % n
+	case keys.Matches(key, "down", "j"):
+		*cursor = (*cursor + 1) % n
+	case keys.Matches(key, "a"):
+		*mode = toggleMode(*mode, dice.Advantage)
+	case keys.Matches(key, "d"):
+		*mode = toggleMode(*mode, dice.Disadvantage)
+	case keys.Matches(key, "enter"):
+		return true, true
+	default:
+		return false, false
+	}
+	return true, false
+}
+
+// optOut is the set of effects the player declined for the next roll.
+// Rollers embed it, so the roll prompt can list effects.DiceModifiers and
+// toggle them off one roll at a time.
+type optOut []string
+
+// ToggleOptOut declines the named effect for the next roll, or accepts it
+// again.
+func (o *optOut) ToggleOptOut(name string) {
+	if i := slices.IndexFunc(*o, func(n string) bool { return strings.EqualFold(n, name) }); i >= 0 {
+		*o = slices.Delete(*o, i, i+1)
+		return
+	}
+	*o = append(*o, name)
+}
+
+// OptedOut reports whether the named effect is declined for the next
+// roll.
+func (o optOut) OptedOut(name string) bool {
+	return slices.ContainsFunc(o, func(n string) bool { return strings.EqualFold(n, name) })
+}
+
+// apply drops the declined effects from active and clears the set, since
+// opting out lasts one roll.
+func (o *optOut) apply(active []effects.Effect) []effects.Effect {
+	active = effects.Without(active, (*o)...)
+	*o = nil
+	return active
+}
+
+// toggleMode switches to m, or back to normal if m is already active.
+func toggleMode(current, m dice.Mode) dice.Mode {
+	if current == m {
+		return dice.Normal
+	}
+	return m
+}
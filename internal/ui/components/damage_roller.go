@@ -0,0 +1,46 @@
+package components
+
+import (
+	"sheet/internal/dice"
+	"sheet/internal/rules"
+	"sheet/internal/tables"
+)
+
+// DamageRoller rolls damage for the Actions panel and cast modal, applying
+// the table's critical hit house rule.
+type DamageRoller struct {
+	Crit rules.CritVariant
+	// Table is the brutal critical hit table, rolled on crits when Crit is
+	// rules.CritBrutal.
+	Table *tables.Table
+}
+
+// CritExpr returns expr as rolled on a critical hit under v. Only the dice
+// are doubled or maximized; flat modifiers are added once. The extra dice
+// are labeled "crit" in the breakdown.
+func CritExpr(expr dice.Expr, v rules.CritVariant) dice.Expr {
+	extra := expr.Dice()
+	if v == rules.CritMaxPlusRoll {
+		return expr.Add(dice.Expr{}.Plus(extra.Max()).Labeled("crit"))
+	}
+	return expr.Add(extra.Labeled("crit"))
+}
+
+// Roll rolls expr as damage, which should already carry the character's
+// reroll rules from effects.DamageRerolls. On a crit the entry's note
+// names the variant used and, for brutal crits, the table result.
+func (d DamageRoller) Roll(r *dice.Roller, label string, expr dice.Expr, crit bool) (RollEntry, error) {
+	if !crit {
+		return NewRollEntry(RollDamage, label, r.Roll(expr)), nil
+	}
+	entry := NewRollEntry(RollDamage, label, r.Roll(CritExpr(expr, d.Crit)))
+	entry.Note = "crit, " + d.Crit.String()
+	if d.Crit == rules.CritBrutal && d.Table != nil {
+		res, err := d.Table.Roll(r)
+		if err != nil {
+			return RollEntry{}, err
+		}
+		entry.Note += ": " + res.Entry.Text()
+	}
+	return entry, nil
+}
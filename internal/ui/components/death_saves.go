@@ -0,0 +1,80 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/effects"
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// DeathSaveRoller is the death save tracker on the Combat panel. 'r' rolls
+// a death save and applies the result; '1', '2' and '0' still mark a
+// success, mark a failure and reset by hand, for saves rolled at the
+// table.
+type DeathSaveRoller struct {
+	saves *rules.DeathSaves
+	last  *RollEntry
+}
+
+// NewDeathSaveRoller returns a tracker over saves. Rolls and marks modify
+// saves in place.
+func NewDeathSaveRoller(saves *rules.DeathSaves) *DeathSaveRoller {
+	return &DeathSaveRoller{saves: saves}
+}
+
+// Last returns the most recent death save roll.
+func (d *DeathSaveRoller) Last() *RollEntry {
+	return d.last
+}
+
+// HandleKey handles the manual marks. It reports whether the key was used
+// and whether a roll was asked for.
+func (d *DeathSaveRoller) HandleKey(key string) (handled, roll bool) {
+	switch keys.Normalize(key) {
+	case "r":
+		return true, true
+	case "1":
+		d.saves.Succeed()
+	case "2":
+		d.saves.Fail(1)
+	case "0":
+		d.saves.Reset()
+	default:
+		return false, false
+	}
+	return true, false
+}
+
+// Roll makes a death saving throw. Effects that add to saving throws, like
+// Bless, count towards the DC. The caller records the entry in the roll
+// history and, when the outcome is Revived, sets HP to 1.
+func (d *DeathSaveRoller) Roll(r *dice.Roller, active []effects.Effect) (RollEntry, rules.DeathSaveOutcome, []effects.Effect, error) {
+	roll := effects.Roll{Type: effects.SavingRoll}
+	expr, applied, err := effects.D20Roll(roll, 0, dice.Normal, active)
+	if err != nil {
+		return RollEntry{}, rules.DeathSaveOutcome{}, nil, err
+	}
+	res := r.Roll(expr)
+	outcome := d.saves.Apply(res.Natural(), res.Total)
+
+	entry := NewRollEntry(RollSave, "Death save", res)
+	entry.Note = outcome.String()
+	d.last = &entry
+	return entry, outcome, applied, nil
+}
+
+// View renders the tallies as pips, e.g. "Successes ●●○  Failures ●○○".
+func (d *DeathSaveRoller) View(g glyphs.Set) string {
+	pips := func(n int) string {
+		return strings.Repeat(g.Filled, n) + strings.Repeat(g.Empty, 3-n)
+	}
+	s := fmt.Sprintf("Successes %s  Failures %s", pips(d.saves.Successes), pips(d.saves.Failures))
+	if d.last != nil {
+		s += fmt.Sprintf("\nLast: %d (%s)", d.last.Total, d.last.Note)
+	}
+	return s
+}
@@ -0,0 +1,119 @@
+package components
+
+import (
+	"strings"
+
+	"sheet/internal/models"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// Alignments are the standard alignments, offered by tab in the
+// description step.
+var Alignments = []string{
+	"Lawful Good", "Neutral Good", "Chaotic Good",
+	"Lawful Neutral", "Neutral", "Chaotic Neutral",
+	"Lawful Evil", "Neutral Evil", "Chaotic Evil",
+	"Unaligned",
+}
+
+// DescriptionStep is the creation wizard's optional description step:
+// alignment, age, height, weight, faith and appearance. Every field may be
+// left blank.
+//
+//	enter down  next field (enter on the last one finishes)
+//	up          previous field
+//	tab         complete or cycle the alignment
+//	esc         skip the step, keeping nothing typed here
+type DescriptionStep struct {
+	fields  []infoField
+	values  []string
+	cursor  int
+	done    bool
+	skipped bool
+}
+
+// NewDescriptionStep returns the step over d. The typed values are only
+// written to d when the step is finished.
+func NewDescriptionStep(d *models.CharacterDetails) *DescriptionStep {
+	fields := descriptionFields(d)
+	values := make([]string, len(fields))
+	for i, f := range fields {
+		values[i] = *f.value
+	}
+	return &DescriptionStep{fields: fields, values: values}
+}
+
+// Done reports whether the wizard should move on.
+func (s *DescriptionStep) Done() bool {
+	return s.done
+}
+
+// Skipped reports whether the player skipped the step.
+func (s *DescriptionStep) Skipped() bool {
+	return s.skipped
+}
+
+// HandleKey reports whether the key was used.
+func (s *DescriptionStep) HandleKey(key string) bool {
+	switch keys.Normalize(key) {
+	case "enter":
+		if s.cursor == len(s.fields)-1 {
+			s.finish()
+		} else {
+			s.cursor++
+		}
+	case "down":
+		s.cursor = min(s.cursor+1, len(s.fields)-1)
+	case "up":
+		s.cursor = max(s.cursor-1, 0)
+	case "tab":
+		// Alignment is the first field.
+		if s.cursor == 0 {
+			s.values[0] = completeAlignment(s.values[0])
+		}
+	case "esc":
+		s.done, s.skipped = true, true
+	default:
+		return typeInto(&s.values[s.cursor], key)
+	}
+	return true
+}
+
+func (s *DescriptionStep) finish() {
+	for i, f := range s.fields {
+		*f.value = strings.TrimSpace(s.values[i])
+	}
+	s.done = true
+}
+
+// completeAlignment returns the first alignment starting with typed, or
+// the one after typed when it already is an alignment, wrapping around.
+func completeAlignment(typed string) string {
+	for i, a := range Alignments {
+		if strings.EqualFold(a, typed) {
+			return Alignments[(i+1)%len(Alignments)]
+		}
+	}
+	for _, a := range Alignments {
+		if strings.HasPrefix(strings.ToLower(a), strings.ToLower(typed)) {
+			return a
+		}
+	}
+	return typed
+}
+
+// View renders the fields with the selected one's cursor.
+func (s *DescriptionStep) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString(title("Description (optional)") + "\n\n")
+	for i, f := range s.fields {
+		marker, cursor := "  ", ""
+		if i == s.cursor {
+			marker, cursor = "> ", g.TextCursor
+		}
+		b.WriteString(marker + f.label + ": " + s.values[i] + cursor + "\n")
+	}
+	b.WriteString("\n" + hint("enter: next  tab: alignment  esc: skip"))
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package components
+
+import (
+	"testing"
+
+	"sheet/internal/models"
+)
+
+// typeKeys sends each rune of s as a key.
+func typeKeys(h interface{ HandleKey(string) bool }, s string) {
+	for _, r := range s {
+		key := string(r)
+		if r == ' ' {
+			key = "space"
+		}
+		h.HandleKey(key)
+	}
+}
+
+func TestDescriptionStep(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     func(s *DescriptionStep)
+		want     models.CharacterDetails
+		wantSkip bool
+		wantDone bool
+	}{
+		{
+			name: "fill and finish",
+			keys: func(s *DescriptionStep) {
+				typeKeys(s, "chaotic g")
+				s.HandleKey("tab")
+				s.HandleKey("enter")
+				typeKeys(s, "87")
+				for range 4 {
+					s.HandleKey("enter")
+				}
+				typeKeys(s, "Tall")
+				s.HandleKey("enter")
+			},
+			want:     models.CharacterDetails{Alignment: "Chaotic Good", Age: "87", Appearance: "Tall"},
+			wantDone: true,
+		},
+		{
+			name: "tab cycles alignments",
+			keys: func(s *DescriptionStep) {
+				typeKeys(s, "Chaotic Evil")
+				s.HandleKey("tab")
+				for range 5 {
+					s.HandleKey("down")
+				}
+				s.HandleKey("enter")
+			},
+			want:     models.CharacterDetails{Alignment: "Unaligned"},
+			wantDone: true,
+		},
+		{
+			name: "skip keeps nothing",
+			keys: func(s *DescriptionStep) {
+				typeKeys(s, "Neutral")
+				s.HandleKey("esc")
+			},
+			wantDone: true,
+			wantSkip: true,
+		},
+		{
+			name: "unfinished writes nothing",
+			keys: func(s *DescriptionStep) {
+				typeKeys(s, "Neutral")
+				s.HandleKey("enter")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d models.CharacterDetails
+			s := NewDescriptionStep(&d)
+			tt.keys(s)
+			if d.Alignment != tt.want.Alignment || d.Age != tt.want.Age || d.Appearance != tt.want.Appearance {
+				t.Errorf("details = %+v, want %+v", d, tt.want)
+			}
+			if s.Done() != tt.wantDone || s.Skipped() != tt.wantSkip {
+				t.Errorf("Done, Skipped = %v, %v; want %v, %v", s.Done(), s.Skipped(), tt.wantDone, tt.wantSkip)
+			}
+		})
+	}
+}
+
+func TestCharacterInfoOneLineEnter(t *testing.T) {
+	d := models.CharacterDetails{Backstory: "Born"}
+	c := NewCharacterInfo(&d)
+	c.HandleKey("enter")
+	typeKeys(c, "Neutral")
+	c.HandleKey("enter")
+	if c.Editing() || d.Alignment != "Neutral" || !c.Dirty() {
+		t.Errorf("after enter on alignment: editing %v, alignment %q, dirty %v", c.Editing(), d.Alignment, c.Dirty())
+	}
+
+	for c.fields()[c.cursor].label != "Backstory" {
+		c.HandleKey("down")
+	}
+	c.HandleKey("enter")
+	c.HandleKey("enter")
+	typeKeys(c, "Raised")
+	c.HandleKey("esc")
+	if d.Backstory != "Born\nRaised" {
+		t.Errorf("Backstory = %q, want a second line", d.Backstory)
+	}
+}
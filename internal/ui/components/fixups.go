@@ -0,0 +1,171 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/storage"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// FixupWizard asks for the choices missing from a character saved by an
+// older version, one fixup at a time, before the character opens. Fixups
+// with options are picked from a list with up/down and Enter; the rest
+// are typed. Subclasses are picked from the class's subclasses, or typed
+// after choosing "Other". Esc goes back to the previous fixup (or the
+// list, when typing another subclass), or cancels from the first. Once
+// Complete, the caller saves Answers with storage.ApplyFixups.
+type FixupWizard struct {
+	fixups     []storage.Fixup
+	feats      []string
+	subclasses []data.Subclass
+	answers    []string
+	cursor     int
+	buffer     string
+	// other is set while typing a subclass missing from the list.
+	other  bool
+	status string
+	done   bool
+}
+
+// otherSubclass is the subclass option for typing one in.
+const otherSubclass = "Other (type it in)"
+
+// NewFixupWizard returns a wizard over fixups. Missing feats are chosen
+// from feats, and missing subclasses from subclasses.
+func NewFixupWizard(fixups []storage.Fixup, feats []string, subclasses []data.Subclass) *FixupWizard {
+	return &FixupWizard{fixups: fixups, feats: feats, subclasses: subclasses}
+}
+
+// Done reports whether the wizard should close: every fixup answered, or
+// cancelled.
+func (w *FixupWizard) Done() bool {
+	return w.done || w.Complete()
+}
+
+// Complete reports whether every fixup has an answer.
+func (w *FixupWizard) Complete() bool {
+	return len(w.answers) == len(w.fixups)
+}
+
+// Answers returns the answers, in the order of the fixups.
+func (w *FixupWizard) Answers() []string {
+	return w.answers
+}
+
+// Typing reports whether the current fixup is typed in; the parent view
+// should then pass every key through.
+func (w *FixupWizard) Typing() bool {
+	return !w.Complete() && (w.other || len(w.options()) == 0)
+}
+
+// options returns the choices for the current fixup, leaving out skills
+// already picked by earlier fixups.
+func (w *FixupWizard) options() []string {
+	fx := w.fixups[len(w.answers)]
+	switch fx.Kind {
+	case storage.FixupFeat:
+		return w.feats
+	case storage.FixupSubclass:
+		subs := data.SubclassesOf(w.subclasses, fx.Class)
+		if len(subs) == 0 {
+			return nil
+		}
+		opts := make([]string, 0, len(subs)+1)
+		for _, s := range subs {
+			opts = append(opts, s.Name)
+		}
+		return append(opts, otherSubclass)
+	case storage.FixupSkill:
+		return slices.DeleteFunc(slices.Clone(fx.Options), func(o string) bool {
+			return slices.Contains(w.answers, o)
+		})
+	}
+	return fx.Options
+}
+
+// HandleKey reports whether the key was used.
+func (w *FixupWizard) HandleKey(key string) bool {
+	if w.Done() {
+		return false
+	}
+	k := keys.Normalize(key)
+	if k == "esc" {
+		w.back()
+		return true
+	}
+	opts := w.options()
+	if w.other || len(opts) == 0 {
+		if k == "enter" {
+			w.answer(strings.TrimSpace(w.buffer))
+			return true
+		}
+		return typeInto(&w.buffer, key)
+	}
+	switch k {
+	case "up", "k":
+		w.cursor = max(w.cursor-1, 0)
+	case "down", "j":
+		w.cursor = min(w.cursor+1, len(opts)-1)
+	case "enter":
+		if opts[w.cursor] == otherSubclass {
+			w.other = true
+			return true
+		}
+		w.answer(opts[w.cursor])
+	default:
+		return false
+	}
+	return true
+}
+
+func (w *FixupWizard) answer(a string) {
+	if a == "" {
+		w.status = "An answer is needed"
+		return
+	}
+	w.answers = append(w.answers, a)
+	w.cursor, w.buffer, w.status, w.other = 0, "", "", false
+}
+
+func (w *FixupWizard) back() {
+	if w.other {
+		w.other, w.buffer = false, ""
+		return
+	}
+	if len(w.answers) == 0 {
+		w.done = true
+		return
+	}
+	w.answers = w.answers[:len(w.answers)-1]
+	w.cursor, w.buffer, w.status = 0, "", ""
+}
+
+// View renders the current fixup.
+func (w *FixupWizard) View(g glyphs.Set) string {
+	if w.Complete() {
+		return "All choices recorded"
+	}
+	var b strings.Builder
+	b.WriteString(title(fmt.Sprintf("Update character (%d/%d)", len(w.answers)+1, len(w.fixups))) + "\n")
+	b.WriteString(w.fixups[len(w.answers)].Prompt + "\n")
+	if w.status != "" {
+		b.WriteString(w.status + "\n")
+	}
+	opts := w.options()
+	if w.other || len(opts) == 0 {
+		b.WriteString("> " + w.buffer + g.TextCursor)
+		return b.String()
+	}
+	for i, o := range opts {
+		marker := "  "
+		if i == w.cursor {
+			marker = "> "
+		}
+		b.WriteString(marker + o + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,78 @@
+package components
+
+import (
+	"slices"
+	"testing"
+
+	"sheet/internal/data"
+	"sheet/internal/storage"
+)
+
+var testSubclasses = []data.Subclass{
+	{Name: "Life Domain", Class: "Cleric"},
+	{Name: "Light Domain", Class: "Cleric"},
+	{Name: "Champion", Class: "Fighter"},
+}
+
+func TestFixupWizardSubclass(t *testing.T) {
+	tests := []struct {
+		name  string
+		class string
+		keys  func(w *FixupWizard)
+		want  []string
+	}{
+		{
+			name:  "pick from the list",
+			class: "Cleric",
+			keys: func(w *FixupWizard) {
+				w.HandleKey("down")
+				w.HandleKey("enter")
+			},
+			want: []string{"Light Domain"},
+		},
+		{
+			name:  "type another",
+			class: "Cleric",
+			keys: func(w *FixupWizard) {
+				w.HandleKey("down")
+				w.HandleKey("down")
+				w.HandleKey("enter")
+				typeKeys(w, "Grave Domain")
+				w.HandleKey("enter")
+			},
+			want: []string{"Grave Domain"},
+		},
+		{
+			name:  "esc returns to the list",
+			class: "Cleric",
+			keys: func(w *FixupWizard) {
+				w.HandleKey("down")
+				w.HandleKey("down")
+				w.HandleKey("enter")
+				w.HandleKey("esc")
+				w.HandleKey("up")
+				w.HandleKey("enter")
+			},
+			want: []string{"Light Domain"},
+		},
+		{
+			name:  "typed without data",
+			class: "Artificer",
+			keys: func(w *FixupWizard) {
+				typeKeys(w, "Alchemist")
+				w.HandleKey("enter")
+			},
+			want: []string{"Alchemist"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixups := []storage.Fixup{{Kind: storage.FixupSubclass, Class: tt.class}}
+			w := NewFixupWizard(fixups, nil, testSubclasses)
+			tt.keys(w)
+			if !w.Complete() || !slices.Equal(w.Answers(), tt.want) {
+				t.Errorf("Answers = %v (complete %v), want %v", w.Answers(), w.Complete(), tt.want)
+			}
+		})
+	}
+}
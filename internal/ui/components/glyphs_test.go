@@ -0,0 +1,62 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"sheet/internal/models"
+	"sheet/internal/ui/glyphs"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s        string
+		width    int
+		ellipsis string
+		want     string
+	}{
+		{"Fireball", 20, "…", "Fireball"},
+		{"Fireball", 5, "…", "Fire…"},
+		{"Fireball", 5, "...", "Fi..."},
+		{"Fireball", 2, "...", ".."},
+		{"Fireball", 0, "…", "Fireball"},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.s, tt.width, tt.ellipsis); got != tt.want {
+			t.Errorf("truncate(%q, %d, %q) = %q, want %q", tt.s, tt.width, tt.ellipsis, got, tt.want)
+		}
+	}
+}
+
+// isASCII reports whether s has only ASCII characters.
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func TestViewsDrawWithASCIIGlyphs(t *testing.T) {
+	d := models.CharacterDetails{Organizations: []models.Organization{{Name: "Harpers"}}}
+	info := NewCharacterInfo(&d)
+	info.HandleKey("enter")
+	orgs := NewOrganizationEditor(&d.Organizations)
+	orgs.HandleKey("a")
+	step := NewDescriptionStep(&d)
+	views := map[string]func(glyphs.Set) string{
+		"character info": func(g glyphs.Set) string { return info.View(40, g) },
+		"organizations":  orgs.View,
+		"description":    step.View,
+	}
+	for name, view := range views {
+		if got := view(glyphs.ASCII); !isASCII(got) {
+			t.Errorf("%s view with ASCII glyphs:\n%s", name, got)
+		}
+		if got := view(glyphs.Unicode); !strings.Contains(got, glyphs.Unicode.TextCursor) {
+			t.Errorf("%s view lacks the text cursor:\n%s", name, got)
+		}
+	}
+}
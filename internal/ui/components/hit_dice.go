@@ -0,0 +1,69 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/rest"
+	"sheet/internal/rules"
+	"sheet/internal/ui/keys"
+)
+
+// HitDiceSpender is the hit dice section of the short rest screen: a
+// cursor over the character's pools, largest die first. Enter spends a
+// die from the selected pool.
+type HitDiceSpender struct {
+	pools  rules.HitDicePools
+	rest   *rest.ShortRest
+	cursor int
+}
+
+// NewHitDiceSpender returns a spender over pools for r, which must have
+// been given the same pools with SetHitDice.
+func NewHitDiceSpender(pools rules.HitDicePools, r *rest.ShortRest) *HitDiceSpender {
+	return &HitDiceSpender{pools: pools, rest: r}
+}
+
+// Selected returns the die size under the cursor, or 0 with no pools.
+func (h *HitDiceSpender) Selected() int {
+	if len(h.pools) == 0 {
+		return 0
+	}
+	return h.pools[h.cursor].Die
+}
+
+// HandleKey moves the cursor. It reports whether the key was used and
+// whether a die should be spent from Selected.
+func (h *HitDiceSpender) HandleKey(key string) (handled, spend bool) {
+	n := len(h.pools)
+	if n == 0 {
+		return false, false
+	}
+	switch keys.Normalize(key) {
+	case "up", "k":
+		h.cursor = (h.cursor - 1 + n) % n
+	case "down", "j":
+		h.cursor = (h.cursor + 1) % n
+	case "enter":
+		return true, h.rest.Available(h.Selected()) > 0
+	default:
+		return false, false
+	}
+	return true, false
+}
+
+// View lists the pools with the dice left after this rest's spending and
+// the healing so far.
+func (h *HitDiceSpender) View() string {
+	var b strings.Builder
+	b.WriteString(title("Hit Dice") + "\n")
+	for i, p := range h.pools {
+		marker := "  "
+		if i == h.cursor {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%sd%d  %d/%d\n", marker, p.Die, h.rest.Available(p.Die), p.Max)
+	}
+	fmt.Fprintf(&b, "Healing: +%d HP", h.rest.Healing())
+	return b.String()
+}
@@ -0,0 +1,255 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sheet/internal/dice"
+	"sheet/internal/inventory"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// itemFields are the item editor's rows, in display order.
+var itemFields = []string{
+	"Name", "Type", "Damage", "Damage type", "Properties",
+	"Weight", "AC", "Magic bonus", "Charges", "Recharge", "Description",
+}
+
+// itemTypes are the types the Type row cycles through.
+var itemTypes = []inventory.ItemType{
+	inventory.Gear, inventory.Weapon, inventory.Armor, inventory.Shield,
+	inventory.Tool, inventory.Consumable, inventory.Treasure,
+}
+
+// ItemEditor is the inventory view's overlay for creating custom items
+// that aren't in the equipment data. Typing edits the selected row;
+// left/right cycle the type.
+//
+//	up down tab   move between rows
+//	ctrl+l        fill in the library item whose name starts with Name
+//	ctrl+k        keep a copy in the personal item library (on by default)
+//	enter         add the item to the character
+//	esc           cancel
+type ItemEditor struct {
+	inv *inventory.Inventory
+	lib *inventory.Library
+
+	values   map[string]string
+	itemType int
+	cursor   int
+	keep     bool
+	status   string
+	done     bool
+	dirty    bool
+	libDirty bool
+}
+
+// NewItemEditor returns an empty editor that adds to inv and, when kept,
+// to lib.
+func NewItemEditor(inv *inventory.Inventory, lib *inventory.Library) *ItemEditor {
+	return &ItemEditor{inv: inv, lib: lib, values: make(map[string]string), keep: true}
+}
+
+// Done reports whether the overlay should close.
+func (e *ItemEditor) Done() bool {
+	return e.done
+}
+
+// Dirty reports whether an item was added to the character since the last
+// save.
+func (e *ItemEditor) Dirty() bool {
+	return e.dirty
+}
+
+// LibraryDirty reports whether the library needs saving.
+func (e *ItemEditor) LibraryDirty() bool {
+	return e.libDirty
+}
+
+// MarkSaved clears both dirty flags after the caller saves the character
+// and the library.
+func (e *ItemEditor) MarkSaved() {
+	e.dirty, e.libDirty = false, false
+}
+
+// HandleKey reports whether the key was used.
+func (e *ItemEditor) HandleKey(key string) bool {
+	row := itemFields[e.cursor]
+	switch keys.Normalize(key) {
+	case "up", "shift+tab":
+		e.cursor = max(e.cursor-1, 0)
+	case "down", "tab":
+		e.cursor = min(e.cursor+1, len(itemFields)-1)
+	case "left":
+		if row != "Type" {
+			return false
+		}
+		e.itemType = (e.itemType + len(itemTypes) - 1) % len(itemTypes)
+	case "right":
+		if row != "Type" {
+			return false
+		}
+		e.itemType = (e.itemType + 1) % len(itemTypes)
+	case "ctrl+l":
+		e.fromLibrary()
+	case "ctrl+k":
+		e.keep = !e.keep
+	case "enter":
+		e.submit()
+	case "esc":
+		e.done = true
+	default:
+		if row == "Type" {
+			return false
+		}
+		buf := e.values[row]
+		if !typeInto(&buf, key) {
+			return false
+		}
+		e.values[row] = buf
+		e.status = ""
+	}
+	return true
+}
+
+// fromLibrary fills every row from the library item matching Name.
+func (e *ItemEditor) fromLibrary() {
+	it, ok := e.lib.Complete(strings.TrimSpace(e.values["Name"]))
+	if !ok {
+		e.status = "No library item matches"
+		return
+	}
+	e.values = map[string]string{
+		"Name":        it.Name,
+		"Damage":      it.Damage,
+		"Damage type": it.DamageType,
+		"Properties":  strings.Join(it.Properties, ", "),
+		"Recharge":    it.Recharge,
+		"Description": it.Description,
+	}
+	for row, n := range map[string]int{"AC": it.ArmorClass, "Magic bonus": it.MagicBonus, "Charges": it.MaxCharges} {
+		if n != 0 {
+			e.values[row] = strconv.Itoa(n)
+		}
+	}
+	if it.Weight != 0 {
+		e.values["Weight"] = strconv.FormatFloat(it.Weight, 'f', -1, 64)
+	}
+	e.itemType = 0
+	for i, t := range itemTypes {
+		if t == it.Type {
+			e.itemType = i
+		}
+	}
+	e.status = "Filled in from the library"
+}
+
+// Item builds the item from the rows, reporting the first invalid one.
+func (e *ItemEditor) Item() (inventory.Item, error) {
+	it := inventory.Item{
+		Name:        strings.TrimSpace(e.values["Name"]),
+		Type:        itemTypes[e.itemType],
+		Quantity:    1,
+		Damage:      strings.TrimSpace(e.values["Damage"]),
+		DamageType:  strings.ToLower(strings.TrimSpace(e.values["Damage type"])),
+		Recharge:    strings.TrimSpace(e.values["Recharge"]),
+		Description: strings.TrimSpace(e.values["Description"]),
+	}
+	if it.Name == "" {
+		return inventory.Item{}, fmt.Errorf("name is required")
+	}
+	if it.Damage != "" {
+		if _, err := dice.Parse(it.Damage); err != nil {
+			return inventory.Item{}, fmt.Errorf("damage: %w", err)
+		}
+	}
+	for _, p := range strings.Split(e.values["Properties"], ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			it.Properties = append(it.Properties, p)
+		}
+	}
+	if s := strings.TrimSpace(e.values["Weight"]); s != "" {
+		w, err := strconv.ParseFloat(s, 64)
+		if err != nil || w < 0 {
+			return inventory.Item{}, fmt.Errorf("weight must be a number of pounds")
+		}
+		it.Weight = w
+	}
+	numbers := []struct {
+		row string
+		dst *int
+	}{{"AC", &it.ArmorClass}, {"Magic bonus", &it.MagicBonus}, {"Charges", &it.MaxCharges}}
+	for _, f := range numbers {
+		row, dst := f.row, f.dst
+		s := strings.TrimSpace(e.values[row])
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || (n < 0 && row != "Magic bonus") {
+			return inventory.Item{}, fmt.Errorf("%s must be a whole number", strings.ToLower(row))
+		}
+		*dst = n
+	}
+	it.Charges = it.MaxCharges
+	if it.Recharge != "" {
+		if it.MaxCharges == 0 {
+			return inventory.Item{}, fmt.Errorf("recharge needs a number of charges")
+		}
+		if _, err := inventory.ParseRecharge(it.Recharge); err != nil {
+			return inventory.Item{}, fmt.Errorf("recharge: %w", err)
+		}
+	}
+	return it, nil
+}
+
+func (e *ItemEditor) submit() {
+	it, err := e.Item()
+	if err != nil {
+		e.status = err.Error()
+		return
+	}
+	if _, ok := e.inv.Find(it.Name); ok {
+		e.status = fmt.Sprintf("An item named %q already exists", it.Name)
+		return
+	}
+	e.inv.Add(it)
+	e.dirty = true
+	if e.keep {
+		e.lib.Put(it)
+		e.libDirty = true
+	}
+	e.done = true
+}
+
+// View renders the rows with the cursor and any error.
+func (e *ItemEditor) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString(title("New item") + "\n\n")
+	for i, row := range itemFields {
+		value := e.values[row]
+		if row == "Type" {
+			value = g.ArrowLeft + " " + string(itemTypes[e.itemType]) + " " + g.ArrowRight
+		}
+		marker := "  "
+		if i == e.cursor {
+			marker = "> "
+			if row != "Type" {
+				value += g.TextCursor
+			}
+		}
+		fmt.Fprintf(&b, "%s%-12s %s\n", marker, row, value)
+	}
+	keep := "[ ]"
+	if e.keep {
+		keep = "[x]"
+	}
+	fmt.Fprintf(&b, "\n%s keep in item library (ctrl+k)\n", keep)
+	if e.status != "" {
+		b.WriteString(e.status + "\n")
+	}
+	b.WriteString(hint("enter: add  ctrl+l: fill from library  esc: cancel"))
+	return b.String()
+}
@@ -0,0 +1,207 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"sheet/internal/journal"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// journalMode is what keys do in the journal view.
+type journalMode int
+
+const (
+	journalBrowse journalMode = iota
+	journalEdit
+	journalSearch
+)
+
+// JournalEditor is the journal view's state: a cursor over the entries,
+// newest first, a search query, and a buffer while a note is written.
+//
+//	n        new note
+//	e enter  edit the selected entry
+//	x        delete the selected entry
+//	/        search; Esc clears the search
+//
+// While editing, Esc keeps the text and ctrl+c discards it.
+type JournalEditor struct {
+	journal *journal.Journal
+	now     func() time.Time
+
+	mode    journalMode
+	cursor  int
+	query   string
+	buffer  string
+	editing int // index into the journal, or -1 for a new note
+	dirty   bool
+}
+
+// NewJournalEditor returns an editor over j. now stamps new notes.
+func NewJournalEditor(j *journal.Journal, now func() time.Time) *JournalEditor {
+	return &JournalEditor{journal: j, now: now}
+}
+
+// Editing reports whether text is being typed; the parent view should
+// then pass every key through.
+func (e *JournalEditor) Editing() bool {
+	return e.mode != journalBrowse
+}
+
+// Dirty reports whether the journal changed since the last save.
+func (e *JournalEditor) Dirty() bool {
+	return e.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the journal.
+func (e *JournalEditor) MarkSaved() {
+	e.dirty = false
+}
+
+func (e *JournalEditor) shown() []int {
+	return e.journal.Matches(e.query)
+}
+
+// HandleKey reports whether the key was used.
+func (e *JournalEditor) HandleKey(key string) bool {
+	switch e.mode {
+	case journalEdit:
+		return e.handleEditKey(key)
+	case journalSearch:
+		return e.handleSearchKey(key)
+	}
+	shown := e.shown()
+	switch keys.Normalize(key) {
+	case "up", "k":
+		e.cursor = max(e.cursor-1, 0)
+	case "down", "j":
+		e.cursor = min(e.cursor+1, max(len(shown)-1, 0))
+	case "n":
+		e.mode, e.editing, e.buffer = journalEdit, -1, ""
+	case "e", "enter":
+		if len(shown) == 0 {
+			return true
+		}
+		e.mode, e.editing = journalEdit, shown[e.cursor]
+		e.buffer = e.journal.Entries[e.editing].Text
+	case "x":
+		if len(shown) == 0 {
+			return true
+		}
+		i := shown[e.cursor]
+		e.journal.Entries = slices.Delete(e.journal.Entries, i, i+1)
+		e.cursor = min(e.cursor, max(len(shown)-2, 0))
+		e.dirty = true
+	case "/":
+		e.mode = journalSearch
+	case "esc":
+		if e.query == "" {
+			return false
+		}
+		e.query, e.cursor = "", 0
+	default:
+		return false
+	}
+	return true
+}
+
+func (e *JournalEditor) handleEditKey(key string) bool {
+	switch keys.Normalize(key) {
+	case "esc":
+		e.mode = journalBrowse
+		text := strings.TrimSpace(e.buffer)
+		switch {
+		case e.editing < 0 && text != "":
+			e.journal.Add(journal.Entry{Time: e.now(), Kind: journal.Note, Text: text})
+			e.query, e.cursor = "", 0
+			e.dirty = true
+		case e.editing >= 0 && e.journal.Entries[e.editing].Text != text:
+			e.journal.Entries[e.editing].Text = text
+			e.dirty = true
+		}
+	case "ctrl+c":
+		e.mode = journalBrowse
+	case "enter":
+		e.buffer += "\n"
+	default:
+		return typeInto(&e.buffer, key)
+	}
+	return true
+}
+
+func (e *JournalEditor) handleSearchKey(key string) bool {
+	switch keys.Normalize(key) {
+	case "enter":
+		e.mode = journalBrowse
+	case "esc":
+		e.mode, e.query = journalBrowse, ""
+	default:
+		if !typeInto(&e.query, key) {
+			return false
+		}
+	}
+	e.cursor = 0
+	return true
+}
+
+// typeInto applies a typing key to buf: printable characters, space and
+// backspace. It reports whether the key was one of those.
+func typeInto(buf *string, key string) bool {
+	switch keys.Normalize(key) {
+	case "backspace":
+		if _, size := utf8.DecodeLastRuneInString(*buf); size > 0 {
+			*buf = (*buf)[:len(*buf)-size]
+		}
+	case keys.Space:
+		*buf += " "
+	default:
+		if utf8.RuneCountInString(key) != 1 {
+			return false
+		}
+		*buf += key
+	}
+	return true
+}
+
+// View renders the entries, newest first, wrapped to width.
+func (e *JournalEditor) View(width int, g glyphs.Set) string {
+	var b strings.Builder
+	if e.mode == journalSearch || e.query != "" {
+		fmt.Fprintf(&b, "Search: %s\n", e.query)
+	}
+	if e.mode == journalEdit && e.editing < 0 {
+		b.WriteString("> New note\n")
+		for _, line := range wrap(e.buffer+g.TextCursor, width-4) {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	shown := e.shown()
+	if len(shown) == 0 && e.mode != journalEdit {
+		b.WriteString("  No entries\n")
+	}
+	for n, i := range shown {
+		entry := e.journal.Entries[i]
+		marker := "  "
+		if n == e.cursor && !(e.mode == journalEdit && e.editing < 0) {
+			marker = "> "
+		}
+		header := entry.Time.Format("2006-01-02 15:04")
+		if entry.Kind != journal.Note {
+			header += " " + g.Dot + " " + strings.ReplaceAll(string(entry.Kind), "_", " ")
+		}
+		b.WriteString(marker + header + "\n")
+		text := entry.Text
+		if e.mode == journalEdit && e.editing == i {
+			text = e.buffer + g.TextCursor
+		}
+		for _, line := range wrap(text, width-4) {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
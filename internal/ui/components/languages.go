@@ -0,0 +1,163 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// LanguageEditor is the character info view's languages editor, opened
+// with 'L'. It edits the character's languages in place:
+//
+//	up down   move
+//	a         add a language; tab completes from the languages list
+//	d         remove the highlighted language
+//	esc       close (or stop adding)
+//
+// Languages not in the list can still be added, for homebrew settings.
+type LanguageEditor struct {
+	known  *[]string
+	langs  []data.Language
+	cursor int
+	adding bool
+	buffer string
+	status string
+	dirty  bool
+	done   bool
+}
+
+// NewLanguageEditor returns an editor over known, completing from langs.
+func NewLanguageEditor(known *[]string, langs []data.Language) *LanguageEditor {
+	return &LanguageEditor{known: known, langs: langs}
+}
+
+// Done reports whether the editor should close.
+func (e *LanguageEditor) Done() bool {
+	return e.done
+}
+
+// Adding reports whether a language name is being typed; the parent view
+// should then pass every key through.
+func (e *LanguageEditor) Adding() bool {
+	return e.adding
+}
+
+// Dirty reports whether there are edits that haven't been saved.
+func (e *LanguageEditor) Dirty() bool {
+	return e.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the character.
+func (e *LanguageEditor) MarkSaved() {
+	e.dirty = false
+}
+
+// HandleKey reports whether the key was used.
+func (e *LanguageEditor) HandleKey(key string) bool {
+	k := keys.Normalize(key)
+	if e.adding {
+		switch k {
+		case "tab":
+			if l, ok := data.CompleteLanguage(e.langs, e.buffer); ok && e.buffer != "" {
+				e.buffer = l.Name
+			}
+		case "enter":
+			e.add()
+		case "esc":
+			e.adding, e.buffer = false, ""
+		default:
+			return typeInto(&e.buffer, key)
+		}
+		return true
+	}
+	switch k {
+	case "up", "k":
+		e.cursor = max(e.cursor-1, 0)
+	case "down", "j":
+		e.cursor = min(e.cursor+1, max(len(*e.known)-1, 0))
+	case "a":
+		e.adding, e.status = true, ""
+	case "d":
+		e.remove()
+	case "esc":
+		e.done = true
+	default:
+		return false
+	}
+	return true
+}
+
+func (e *LanguageEditor) add() {
+	name := strings.TrimSpace(e.buffer)
+	if name == "" {
+		e.adding = false
+		return
+	}
+	if l, ok := data.FindLanguage(e.langs, name); ok {
+		name = l.Name
+	}
+	if slices.ContainsFunc(*e.known, func(k string) bool { return strings.EqualFold(k, name) }) {
+		e.status = "Already speaks " + name
+		return
+	}
+	*e.known = append(*e.known, name)
+	e.cursor = len(*e.known) - 1
+	e.adding, e.buffer, e.status = false, "", "Added "+name
+	e.dirty = true
+}
+
+func (e *LanguageEditor) remove() {
+	if len(*e.known) == 0 {
+		return
+	}
+	name := (*e.known)[e.cursor]
+	*e.known = slices.Delete(*e.known, e.cursor, e.cursor+1)
+	e.cursor = min(e.cursor, max(len(*e.known)-1, 0))
+	e.status = "Removed " + name
+	e.dirty = true
+}
+
+// View lists the languages with their scripts, and the name being added.
+func (e *LanguageEditor) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString(title("Languages") + "\n\n")
+	if len(*e.known) == 0 {
+		b.WriteString("  None\n")
+	}
+	for i, name := range *e.known {
+		marker := "  "
+		if i == e.cursor && !e.adding {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%s  %s\n", marker, name, e.describe(name))
+	}
+	if e.adding {
+		hint := ""
+		if l, ok := data.CompleteLanguage(e.langs, e.buffer); ok && e.buffer != "" && !strings.EqualFold(l.Name, e.buffer) {
+			hint = "  (tab: " + l.Name + ")"
+		}
+		fmt.Fprintf(&b, "\nAdd: %s%s%s\n", e.buffer, g.TextCursor, hint)
+	}
+	if e.status != "" {
+		b.WriteString("\n" + e.status + "\n")
+	}
+	b.WriteString("\n" + hint("a: add  d: remove  esc: close"))
+	return b.String()
+}
+
+// describe returns a language's type and script, e.g. "exotic, Infernal
+// script", or a note when it isn't in the languages list.
+func (e *LanguageEditor) describe(name string) string {
+	l, ok := data.FindLanguage(e.langs, name)
+	if !ok {
+		return "(homebrew)"
+	}
+	if l.Script == "" {
+		return fmt.Sprintf("%s, no script", l.Type)
+	}
+	return fmt.Sprintf("%s, %s script", l.Type, l.Script)
+}
@@ -0,0 +1,229 @@
+package components
+
+import (
+	"slices"
+	"strings"
+
+	"sheet/internal/ui/keys"
+)
+
+// Panel is one panel of the main sheet as the layout sees it.
+type Panel struct {
+	ID    string
+	Title string
+	// Priority decides which panels are dropped first on short terminals;
+	// lower numbers are kept longest.
+	Priority int
+	// MinHeight is the fewest rows the panel is useful in.
+	MinHeight int
+}
+
+// MainSheetPanels are the main sheet's panels in display order.
+var MainSheetPanels = []Panel{
+	{ID: "abilities", Title: "Abilities & Saving Throws", Priority: 0, MinHeight: 8},
+	{ID: "combat", Title: "Combat", Priority: 1, MinHeight: 8},
+	{ID: "actions", Title: "Actions", Priority: 2, MinHeight: 6},
+	{ID: "skills", Title: "Skills", Priority: 3, MinHeight: 10},
+}
+
+// Layout decides which panels are drawn and which has focus. Players can
+// hide panels; panels that don't fit the terminal are dropped by priority.
+// Panels that aren't drawn stay reachable with Tab in full-screen mode,
+// which shows one panel at a time.
+type Layout struct {
+	panels     []Panel
+	hidden     map[string]bool
+	focus      string
+	fullscreen bool
+}
+
+// NewLayout returns a layout over panels with the first focused.
+func NewLayout(panels []Panel) *Layout {
+	l := &Layout{panels: panels, hidden: make(map[string]bool)}
+	if len(panels) > 0 {
+		l.focus = panels[0].ID
+	}
+	return l
+}
+
+// Toggle hides or shows a panel. Hiding the focused panel moves focus to
+// the next visible one.
+func (l *Layout) Toggle(id string) {
+	l.hidden[id] = !l.hidden[id]
+	if !l.hidden[id] || id != l.focus || l.fullscreen {
+		return
+	}
+	i := slices.IndexFunc(l.panels, func(p Panel) bool { return p.ID == id })
+	for n := 1; n < len(l.panels); n++ {
+		next := l.panels[(i+n)%len(l.panels)]
+		if !l.hidden[next.ID] {
+			l.focus = next.ID
+			return
+		}
+	}
+}
+
+// Hidden reports whether the player hid the panel.
+func (l *Layout) Hidden(id string) bool {
+	return l.hidden[id]
+}
+
+// HiddenIDs returns the IDs of the panels the player hid, in display
+// order, for restoring them from settings.
+func (l *Layout) HiddenIDs() []string {
+	var ids []string
+	for _, p := range l.panels {
+		if l.hidden[p.ID] {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+// Arrange returns the panels to draw in height rows, in display order.
+// Hidden panels are left out, then the lowest-priority panels are dropped
+// until the rest fit. At least one panel is always returned.
+func (l *Layout) Arrange(height int) []Panel {
+	var shown []Panel
+	for _, p := range l.panels {
+		if !l.hidden[p.ID] {
+			shown = append(shown, p)
+		}
+	}
+	for len(shown) > 1 && totalHeight(shown) > height {
+		worst := 0
+		for i, p := range shown {
+			if p.Priority > shown[worst].Priority {
+				worst = i
+			}
+		}
+		shown = slices.Delete(shown, worst, worst+1)
+	}
+	return shown
+}
+
+func totalHeight(ps []Panel) int {
+	h := 0
+	for _, p := range ps {
+		h += p.MinHeight
+	}
+	return h
+}
+
+// Offscreen returns the panels not drawn at height, whether hidden or
+// squeezed out, so the view can list them in its footer.
+func (l *Layout) Offscreen(height int) []Panel {
+	shown := l.Arrange(height)
+	var out []Panel
+	for _, p := range l.panels {
+		if !slices.ContainsFunc(shown, func(s Panel) bool { return s.ID == p.ID }) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Focus returns the ID of the focused panel.
+func (l *Layout) Focus() string {
+	return l.focus
+}
+
+// Fullscreen reports whether one panel fills the content area.
+func (l *Layout) Fullscreen() bool {
+	return l.fullscreen
+}
+
+// SetFullscreen enters or leaves full-screen mode.
+func (l *Layout) SetFullscreen(on bool) {
+	l.fullscreen = on
+}
+
+// HandleKey handles the layout keys: 'z' expands the focused panel to fill
+// the content area (or shrinks it back), Esc returns to the multi-panel
+// layout, and Tab/Shift+Tab move focus. It reports whether the key was
+// used; Esc is only used while full-screen.
+func (l *Layout) HandleKey(key string, height int) bool {
+	switch keys.Normalize(key) {
+	case "z":
+		l.fullscreen = !l.fullscreen
+		if !l.fullscreen {
+			l.ensureFocusDrawn(height)
+		}
+	case "esc":
+		if !l.fullscreen {
+			return false
+		}
+		l.fullscreen = false
+		l.ensureFocusDrawn(height)
+	case "tab":
+		l.Cycle(1, height)
+	case "shift+tab":
+		l.Cycle(-1, height)
+	default:
+		return false
+	}
+	return true
+}
+
+// ensureFocusDrawn moves focus onto a drawn panel after leaving
+// full-screen on a panel that is hidden or doesn't fit.
+func (l *Layout) ensureFocusDrawn(height int) {
+	shown := l.Arrange(height)
+	if !slices.ContainsFunc(shown, func(p Panel) bool { return p.ID == l.focus }) {
+		l.focus = shown[0].ID
+	}
+}
+
+// Heights returns the rows given to each drawn panel. In full-screen mode
+// the focused panel gets everything; otherwise each panel gets its
+// minimum and the spare rows are shared out in display order.
+func (l *Layout) Heights(height int) map[string]int {
+	if l.fullscreen {
+		return map[string]int{l.focus: height}
+	}
+	shown := l.Arrange(height)
+	out := make(map[string]int, len(shown))
+	spare := max(height-totalHeight(shown), 0)
+	for i, p := range shown {
+		extra := spare / len(shown)
+		if i < spare%len(shown) {
+			extra++
+		}
+		out[p.ID] = p.MinHeight + extra
+	}
+	return out
+}
+
+// Cycle moves focus by dir (1 for Tab, -1 for Shift+Tab). In full-screen
+// mode it visits every panel, including hidden ones; otherwise only the
+// panels drawn at height.
+func (l *Layout) Cycle(dir, height int) {
+	candidates := l.panels
+	if !l.fullscreen {
+		candidates = l.Arrange(height)
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	i := slices.IndexFunc(candidates, func(p Panel) bool { return p.ID == l.focus })
+	if i < 0 {
+		i = 0
+	} else {
+		i = ((i+dir)%len(candidates) + len(candidates)) % len(candidates)
+	}
+	l.focus = candidates[i].ID
+}
+
+// Footer lists the offscreen panels, e.g. "Hidden: Skills (tab in
+// full-screen)", or is empty when everything is drawn.
+func (l *Layout) Footer(height int) string {
+	off := l.Offscreen(height)
+	if len(off) == 0 || l.fullscreen {
+		return ""
+	}
+	titles := make([]string, len(off))
+	for i, p := range off {
+		titles[i] = p.Title
+	}
+	return "Hidden: " + strings.Join(titles, ", ") + " (tab in full-screen)"
+}
@@ -0,0 +1,39 @@
+package components
+
+import (
+	"sheet/internal/rules"
+	"sheet/internal/ui/keys"
+)
+
+// LevelUpBinding is the main sheet's entry point to the level-up wizard:
+// 'L' opens it, and the header shows an indicator while XP characters
+// have banked enough for their next level.
+type LevelUpBinding struct {
+	Progression rules.Progression
+	Level, XP   int
+	// Table is the XP table in effect; nil is the standard one.
+	Table rules.XPTable
+}
+
+// Available reports whether the level-up wizard can be opened.
+func (l LevelUpBinding) Available() bool {
+	return l.Table.CanLevelUp(l.Progression, l.Level, l.XP)
+}
+
+// Indicator returns the header text. Milestone characters can level up at
+// any time, so they get no indicator; the DM says when.
+func (l LevelUpBinding) Indicator() string {
+	if l.Progression == rules.ProgressionMilestone || !l.Available() {
+		return ""
+	}
+	return "Level Up Available!"
+}
+
+// HandleKey reports whether the key was used and whether the wizard should
+// open.
+func (l LevelUpBinding) HandleKey(key string) (handled, open bool) {
+	if keys.Normalize(key) != "L" {
+		return false, false
+	}
+	return true, l.Available()
+}
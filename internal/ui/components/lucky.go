@@ -0,0 +1,120 @@
+package components
+
+import (
+	"fmt"
+
+	"sheet/internal/dice"
+	"sheet/internal/rest"
+	"sheet/internal/ui/keys"
+)
+
+// luckyState is where the Lucky prompt is.
+type luckyState int
+
+const (
+	luckyOffer luckyState = iota
+	luckyChoose
+	luckyDone
+)
+
+// LuckyPrompt follows a d20 roll for characters with the Lucky feat. 'l'
+// spends a luck point to roll another d20; the player then picks which
+// die counts, '1' for the original and '2' for the luck die. 'n' or Esc
+// dismisses the offer.
+type LuckyPrompt struct {
+	points *rest.Resource
+	entry  RollEntry
+	luck   int
+	state  luckyState
+}
+
+// NewLuckyPrompt offers a luck point on entry, spending from points.
+func NewLuckyPrompt(points *rest.Resource, entry RollEntry) *LuckyPrompt {
+	return &LuckyPrompt{points: points, entry: entry}
+}
+
+// Offered reports whether the prompt should be shown: the roll had a d20
+// and a luck point is left, or a luck die is waiting for a choice.
+func (p *LuckyPrompt) Offered() bool {
+	switch p.state {
+	case luckyOffer:
+		return p.entry.Natural > 0 && p.points.Remaining > 0
+	case luckyChoose:
+		return true
+	}
+	return false
+}
+
+// Done reports whether the prompt has been answered or dismissed.
+func (p *LuckyPrompt) Done() bool {
+	return p.state == luckyDone || !p.Offered()
+}
+
+// Entry returns the roll entry, updated with both dice once the player
+// chose. The caller replaces the history's last entry with it.
+func (p *LuckyPrompt) Entry() RollEntry {
+	return p.entry
+}
+
+// HandleKey reports whether the key was used and whether a luck point
+// should be spent with Roll.
+func (p *LuckyPrompt) HandleKey(key string) (handled, roll bool) {
+	if !p.Offered() {
+		return false, false
+	}
+	k := keys.Normalize(key)
+	if p.state == luckyOffer {
+		switch k {
+		case "l", "y":
+			return true, true
+		case "n", "esc":
+			p.state = luckyDone
+			return true, false
+		}
+		return false, false
+	}
+	switch k {
+	case "1":
+		p.choose(false)
+	case "2", "enter":
+		p.choose(true)
+	default:
+		return false, false
+	}
+	return true, false
+}
+
+// Roll spends a luck point and rolls the luck die.
+func (p *LuckyPrompt) Roll(r *dice.Roller) error {
+	if p.state != luckyOffer || p.points.Remaining < 1 {
+		return fmt.Errorf("no luck points left")
+	}
+	p.points.Remaining--
+	p.luck = r.Roll(dice.D20(dice.Normal, 0)).Total
+	p.state = luckyChoose
+	return nil
+}
+
+// choose records both dice on the entry and, when the luck die is used,
+// swaps it in for the original.
+func (p *LuckyPrompt) choose(useLuck bool) {
+	original, used := p.entry.Natural, p.entry.Natural
+	if useLuck {
+		used = p.luck
+		p.entry.Total += p.luck - original
+		p.entry.Natural = p.luck
+	}
+	p.entry.AddNote(fmt.Sprintf("Lucky: %d and %d, used %d", original, p.luck, used))
+	p.state = luckyDone
+}
+
+// View renders the offer or the choice.
+func (p *LuckyPrompt) View() string {
+	switch p.state {
+	case luckyOffer:
+		return fmt.Sprintf("Rolled %d. Spend a luck point to reroll? (%d left)  l: reroll  n: keep", p.entry.Natural, p.points.Remaining)
+	case luckyChoose:
+		return fmt.Sprintf("Original %d, luck die %d.  1: use %d  2: use %d", p.entry.Natural, p.luck, p.entry.Natural, p.luck)
+	}
+	return ""
+}
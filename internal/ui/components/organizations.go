@@ -0,0 +1,180 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/models"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// orgInput is what the organization editor is typing.
+type orgInput int
+
+const (
+	orgBrowse orgInput = iota
+	orgName
+	orgRank
+)
+
+// OrganizationEditor is the character info view's organizations editor,
+// opened with 'O'. It edits the character's organizations in place:
+//
+//	up down   move
+//	a         add an organization
+//	r         set the highlighted one's rank
+//	+ -       raise or lower its renown
+//	d         remove it
+//	esc       close (or stop typing)
+type OrganizationEditor struct {
+	orgs   *[]models.Organization
+	cursor int
+	input  orgInput
+	buffer string
+	status string
+	dirty  bool
+	done   bool
+}
+
+// NewOrganizationEditor returns an editor over orgs.
+func NewOrganizationEditor(orgs *[]models.Organization) *OrganizationEditor {
+	return &OrganizationEditor{orgs: orgs}
+}
+
+// Done reports whether the editor should close.
+func (e *OrganizationEditor) Done() bool {
+	return e.done
+}
+
+// Typing reports whether a name or rank is being typed; the parent view
+// should then pass every key through.
+func (e *OrganizationEditor) Typing() bool {
+	return e.input != orgBrowse
+}
+
+// Dirty reports whether there are edits that haven't been saved.
+func (e *OrganizationEditor) Dirty() bool {
+	return e.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the character.
+func (e *OrganizationEditor) MarkSaved() {
+	e.dirty = false
+}
+
+// HandleKey reports whether the key was used.
+func (e *OrganizationEditor) HandleKey(key string) bool {
+	k := keys.Normalize(key)
+	if e.input != orgBrowse {
+		switch k {
+		case "enter":
+			e.commit()
+		case "esc":
+			e.input, e.buffer = orgBrowse, ""
+		default:
+			return typeInto(&e.buffer, key)
+		}
+		return true
+	}
+	switch k {
+	case "up", "k":
+		e.cursor = max(e.cursor-1, 0)
+	case "down", "j":
+		e.cursor = min(e.cursor+1, max(len(*e.orgs)-1, 0))
+	case "a":
+		e.input, e.status = orgName, ""
+	case "r":
+		if len(*e.orgs) > 0 {
+			e.input, e.buffer, e.status = orgRank, (*e.orgs)[e.cursor].Rank, ""
+		}
+	case "+", "=":
+		e.addRenown(1)
+	case "-":
+		e.addRenown(-1)
+	case "d":
+		e.remove()
+	case "esc":
+		e.done = true
+	default:
+		return false
+	}
+	return true
+}
+
+func (e *OrganizationEditor) commit() {
+	text := strings.TrimSpace(e.buffer)
+	input := e.input
+	e.input, e.buffer = orgBrowse, ""
+	if input == orgRank {
+		if o := &(*e.orgs)[e.cursor]; o.Rank != text {
+			o.Rank = text
+			e.dirty = true
+		}
+		return
+	}
+	if text == "" {
+		return
+	}
+	if slices.ContainsFunc(*e.orgs, func(o models.Organization) bool { return strings.EqualFold(o.Name, text) }) {
+		e.status = "Already listed: " + text
+		return
+	}
+	*e.orgs = append(*e.orgs, models.Organization{Name: text})
+	e.cursor = len(*e.orgs) - 1
+	e.status = "Added " + text
+	e.dirty = true
+}
+
+// addRenown changes the highlighted organization's renown, which never
+// drops below zero.
+func (e *OrganizationEditor) addRenown(n int) {
+	if len(*e.orgs) == 0 {
+		return
+	}
+	o := &(*e.orgs)[e.cursor]
+	if renown := max(o.Renown+n, 0); renown != o.Renown {
+		o.Renown = renown
+		e.dirty = true
+	}
+}
+
+func (e *OrganizationEditor) remove() {
+	if len(*e.orgs) == 0 {
+		return
+	}
+	name := (*e.orgs)[e.cursor].Name
+	*e.orgs = slices.Delete(*e.orgs, e.cursor, e.cursor+1)
+	e.cursor = min(e.cursor, max(len(*e.orgs)-1, 0))
+	e.status = "Removed " + name
+	e.dirty = true
+}
+
+// View lists the organizations with their standing, and what is being
+// typed.
+func (e *OrganizationEditor) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString(title("Organizations") + "\n\n")
+	if len(*e.orgs) == 0 {
+		b.WriteString("  None\n")
+	}
+	for i, o := range *e.orgs {
+		marker := "  "
+		if i == e.cursor && e.input != orgName {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%s\n", marker, o.Label())
+	}
+	switch e.input {
+	case orgName:
+		fmt.Fprintf(&b, "\nAdd: %s%s\n", e.buffer, g.TextCursor)
+	case orgRank:
+		fmt.Fprintf(&b, "\nRank: %s%s\n", e.buffer, g.TextCursor)
+	}
+	if e.status != "" {
+		b.WriteString("\n" + e.status + "\n")
+	}
+	b.WriteString("\n" + hint("a: add  r: rank  +/-: renown  d: remove  esc: close"))
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package components
+
+import (
+	"slices"
+	"testing"
+
+	"sheet/internal/models"
+)
+
+func TestOrganizationEditor(t *testing.T) {
+	tests := []struct {
+		name  string
+		start []models.Organization
+		keys  func(e *OrganizationEditor)
+		want  []models.Organization
+	}{
+		{
+			name: "add with rank and renown",
+			keys: func(e *OrganizationEditor) {
+				e.HandleKey("a")
+				typeKeys(e, "Harpers")
+				e.HandleKey("enter")
+				e.HandleKey("r")
+				typeKeys(e, "Watcher")
+				e.HandleKey("enter")
+				e.HandleKey("+")
+				e.HandleKey("+")
+			},
+			want: []models.Organization{{Name: "Harpers", Rank: "Watcher", Renown: 2}},
+		},
+		{
+			name:  "renown stops at zero",
+			start: []models.Organization{{Name: "Zhentarim", Renown: 1}},
+			keys: func(e *OrganizationEditor) {
+				e.HandleKey("-")
+				e.HandleKey("-")
+			},
+			want: []models.Organization{{Name: "Zhentarim"}},
+		},
+		{
+			name:  "duplicate names are refused",
+			start: []models.Organization{{Name: "Harpers"}},
+			keys: func(e *OrganizationEditor) {
+				e.HandleKey("a")
+				typeKeys(e, "harpers")
+				e.HandleKey("enter")
+			},
+			want: []models.Organization{{Name: "Harpers"}},
+		},
+		{
+			name:  "remove",
+			start: []models.Organization{{Name: "Harpers"}, {Name: "Emerald Enclave"}},
+			keys: func(e *OrganizationEditor) {
+				e.HandleKey("down")
+				e.HandleKey("d")
+			},
+			want: []models.Organization{{Name: "Harpers"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgs := slices.Clone(tt.start)
+			e := NewOrganizationEditor(&orgs)
+			tt.keys(e)
+			if !slices.Equal(orgs, tt.want) {
+				t.Errorf("organizations = %+v, want %+v", orgs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/ui/keys"
+)
+
+// PartyPicker selects party members for a party utility action, such as
+// who hears an Inspiring Leader speech. Space toggles the member under
+// the cursor, Enter confirms and Esc cancels.
+type PartyPicker struct {
+	title     string
+	members   []string
+	selected  map[string]bool
+	limit     int
+	cursor    int
+	confirmed bool
+	cancelled bool
+}
+
+// NewPartyPicker returns a picker over members allowing at most limit
+// selections; zero means no limit.
+func NewPartyPicker(title string, members []string, limit int) *PartyPicker {
+	return &PartyPicker{title: title, members: members, selected: make(map[string]bool), limit: limit}
+}
+
+// Confirmed reports whether the player confirmed the selection.
+func (p *PartyPicker) Confirmed() bool {
+	return p.confirmed
+}
+
+// Cancelled reports whether the player backed out.
+func (p *PartyPicker) Cancelled() bool {
+	return p.cancelled
+}
+
+// Selected returns the chosen members in party order.
+func (p *PartyPicker) Selected() []string {
+	var out []string
+	for _, m := range p.members {
+		if p.selected[m] {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// HandleKey reports whether the key was used.
+func (p *PartyPicker) HandleKey(key string) bool {
+	switch keys.Normalize(key) {
+	case "up", "k":
+		p.cursor = max(p.cursor-1, 0)
+	case "down", "j":
+		p.cursor = min(p.cursor+1, max(len(p.members)-1, 0))
+	case keys.Space:
+		if len(p.members) == 0 {
+			return true
+		}
+		m := p.members[p.cursor]
+		if !p.selected[m] && p.limit > 0 && len(p.Selected()) >= p.limit {
+			return true
+		}
+		p.selected[m] = !p.selected[m]
+	case "enter":
+		p.confirmed = len(p.Selected()) > 0
+	case "esc":
+		p.cancelled = true
+	default:
+		return false
+	}
+	return true
+}
+
+// View renders the members with checkboxes.
+func (p *PartyPicker) View() string {
+	var b strings.Builder
+	heading := p.title
+	if p.limit > 0 {
+		heading += fmt.Sprintf(" (%d/%d)", len(p.Selected()), p.limit)
+	}
+	b.WriteString(title(heading) + "\n\n")
+	for i, m := range p.members {
+		marker := "  "
+		if i == p.cursor {
+			marker = "> "
+		}
+		box := "[ ]"
+		if p.selected[m] {
+			box = "[x]"
+		}
+		fmt.Fprintf(&b, "%s%s %s\n", marker, box, m)
+	}
+	b.WriteString("\n" + hint("space: select  enter: confirm  esc: cancel"))
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"sheet/internal/portent"
+)
+
+// UsePortent replaces the d20 of entry, the wizard's roll or a tracked
+// creature's, with the i-th Portent die. The swap is logged on p and noted
+// on the entry, which the caller puts back in the roll history.
+func UsePortent(p *portent.Portent, i int, entry RollEntry, now time.Time) (RollEntry, error) {
+	if entry.Natural == 0 {
+		return entry, fmt.Errorf("%s has no d20 to replace", entry.Label)
+	}
+	u, err := p.Substitute(i, entry.Label, entry.Natural, now)
+	if err != nil {
+		return entry, err
+	}
+	entry.Total += u.Value - u.Replaced
+	entry.Natural = u.Value
+	entry.AddNote(fmt.Sprintf("Portent: %d replaced with %d", u.Replaced, u.Value))
+	return entry, nil
+}
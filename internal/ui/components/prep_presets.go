@@ -0,0 +1,159 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"sheet/internal/spellbook"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// PrepPresets is the preset bar of the spellbook's preparation mode.
+// Number keys apply the matching preset in one keystroke; 'P' names a new
+// preset (or overwrites one) from the spells prepared now, and 'X' then a
+// number deletes one. After a long rest the bar is opened with
+// OfferAfterRest, since that is when a new loadout can be prepared.
+type PrepPresets struct {
+	book     *spellbook.Spellbook
+	max      int
+	naming   bool
+	deleting bool
+	buffer   string
+	status   string
+	dirty    bool
+}
+
+// NewPrepPresets returns the preset bar for book, where at most max spells
+// can be prepared.
+func NewPrepPresets(book *spellbook.Spellbook, max int) *PrepPresets {
+	return &PrepPresets{book: book, max: max}
+}
+
+// Naming reports whether a preset name is being typed; the parent view
+// should then pass every key through.
+func (p *PrepPresets) Naming() bool {
+	return p.naming
+}
+
+// Dirty reports whether the spellbook changed since the last save.
+func (p *PrepPresets) Dirty() bool {
+	return p.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the character.
+func (p *PrepPresets) MarkSaved() {
+	p.dirty = false
+}
+
+// OfferAfterRest prompts for the loadout to prepare after a long rest.
+func (p *PrepPresets) OfferAfterRest() {
+	if len(p.book.Presets) == 0 {
+		return
+	}
+	p.status = fmt.Sprintf("Long rest finished: press 1-%d to prepare a preset", min(len(p.book.Presets), 9))
+}
+
+// HandleKey applies, saves or deletes presets. It reports whether the key
+// was used.
+func (p *PrepPresets) HandleKey(key string) bool {
+	if p.naming {
+		return p.handleNameKey(key)
+	}
+	k := keys.Normalize(key)
+	switch {
+	case k == "P":
+		p.naming, p.deleting = true, false
+		p.buffer = ""
+	case k == "X":
+		p.deleting = !p.deleting
+		p.status = ""
+		if p.deleting {
+			p.status = "Delete which preset?"
+		}
+	case k == "esc" && p.deleting:
+		p.deleting, p.status = false, ""
+	case len(k) == 1 && k >= "1" && k <= "9":
+		i := int(k[0] - '1')
+		if i >= len(p.book.Presets) {
+			return false
+		}
+		name := p.book.Presets[i].Name
+		if p.deleting {
+			p.deleting = false
+			if err := p.book.DeletePreset(name); err != nil {
+				p.status = err.Error()
+				return true
+			}
+			p.dirty = true
+			p.status = "Deleted " + name
+			return true
+		}
+		if err := p.book.ApplyPreset(name, p.max); err != nil {
+			p.status = err.Error()
+			return true
+		}
+		p.dirty = true
+		p.status = "Prepared " + name
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *PrepPresets) handleNameKey(key string) bool {
+	switch keys.Normalize(key) {
+	case "enter":
+		if err := p.book.SavePreset(p.buffer); err != nil {
+			p.status = err.Error()
+			return true
+		}
+		p.naming = false
+		p.dirty = true
+		p.status = "Saved " + strings.TrimSpace(p.buffer)
+	case "esc":
+		p.naming = false
+	case "backspace":
+		if _, size := utf8.DecodeLastRuneInString(p.buffer); size > 0 {
+			p.buffer = p.buffer[:len(p.buffer)-size]
+		}
+	case keys.Space:
+		p.buffer += " "
+	default:
+		if utf8.RuneCountInString(key) != 1 {
+			return false
+		}
+		p.buffer += key
+	}
+	return true
+}
+
+// View renders the numbered presets, the prepared count and the result of
+// the last action. The preset matching the prepared spells is starred,
+// and presets that no longer fit are flagged.
+func (p *PrepPresets) View(g glyphs.Set) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Prepared %d/%d\n", len(p.book.Prepared), p.max)
+	active, _ := p.book.ActivePreset()
+	for i, pr := range p.book.Presets {
+		if i == 9 {
+			break
+		}
+		marker := " "
+		if pr.Name == active.Name {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, " %s%d %s (%d)", marker, i+1, pr.Name, len(pr.Spells))
+		if p.book.CheckPreset(pr.Name, p.max) != nil {
+			b.WriteString(" (can't be prepared)")
+		}
+		b.WriteString("\n")
+	}
+	if p.naming {
+		b.WriteString("Save preset as: " + p.buffer + g.TextCursor + "\n")
+	} else if p.status != "" {
+		b.WriteString(p.status + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,141 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/templates"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// quickStep is where the quick-create path is.
+type quickStep int
+
+const (
+	quickPick quickStep = iota
+	quickName
+	quickPlayer
+)
+
+// QuickCreate is the creation wizard's quick-create path: pick a
+// template, type a name and player, and the template supplies everything
+// else. Enter moves on, Esc steps back, and Esc on the template list
+// leaves the path.
+type QuickCreate struct {
+	templates []templates.Template
+	step      quickStep
+	cursor    int
+	name      string
+	player    string
+	status    string
+	done      bool
+	cancelled bool
+}
+
+// NewQuickCreate returns the path over the saved templates.
+func NewQuickCreate(ts []templates.Template) *QuickCreate {
+	return &QuickCreate{templates: ts}
+}
+
+// Done reports whether the player finished; Template and Identity are
+// then ready to apply.
+func (q *QuickCreate) Done() bool {
+	return q.done
+}
+
+// Cancelled reports whether the player left for the full wizard.
+func (q *QuickCreate) Cancelled() bool {
+	return q.cancelled
+}
+
+// Template returns the chosen template.
+func (q *QuickCreate) Template() templates.Template {
+	return q.templates[q.cursor]
+}
+
+// Identity returns the typed name and player.
+func (q *QuickCreate) Identity() (name, player string) {
+	return strings.TrimSpace(q.name), strings.TrimSpace(q.player)
+}
+
+// HandleKey reports whether the key was used.
+func (q *QuickCreate) HandleKey(key string) bool {
+	k := keys.Normalize(key)
+	switch q.step {
+	case quickPick:
+		switch k {
+		case "up", "k":
+			q.cursor = max(q.cursor-1, 0)
+		case "down", "j":
+			q.cursor = min(q.cursor+1, max(len(q.templates)-1, 0))
+		case "enter":
+			if len(q.templates) > 0 {
+				q.step = quickName
+			}
+		case "esc":
+			q.cancelled = true
+		default:
+			return false
+		}
+		return true
+	case quickName:
+		switch k {
+		case "enter":
+			if strings.TrimSpace(q.name) == "" {
+				q.status = "A character needs a name"
+				return true
+			}
+			q.step, q.status = quickPlayer, ""
+		case "esc":
+			q.step = quickPick
+		default:
+			return typeInto(&q.name, key)
+		}
+		return true
+	}
+	switch k {
+	case "enter":
+		q.done = true
+	case "esc":
+		q.step = quickName
+	default:
+		return typeInto(&q.player, key)
+	}
+	return true
+}
+
+// View renders the current step.
+func (q *QuickCreate) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString(title("Quick create") + "\n\n")
+	if len(q.templates) == 0 {
+		b.WriteString("No templates saved yet. Save a character as a template first.\n")
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+	if q.step == quickPick {
+		for i, t := range q.templates {
+			marker := "  "
+			if i == q.cursor {
+				marker = "> "
+			}
+			fmt.Fprintf(&b, "%s%s  %s\n", marker, t.Name, strings.TrimSpace(data.RaceLabel(t.Race, t.Subrace)+" "+t.Class))
+		}
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+	t := q.Template()
+	fmt.Fprintf(&b, "%s: %s\n\n", t.Name, strings.TrimSpace(data.RaceLabel(t.Race, t.Subrace)+" "+t.Class))
+	cursor := func(s quickStep) string {
+		if q.step == s {
+			return g.TextCursor
+		}
+		return ""
+	}
+	fmt.Fprintf(&b, "Name    %s%s\n", q.name, cursor(quickName))
+	fmt.Fprintf(&b, "Player  %s%s\n", q.player, cursor(quickPlayer))
+	if q.status != "" {
+		b.WriteString(q.status + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
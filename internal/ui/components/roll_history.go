@@ -0,0 +1,222 @@
+// Package components holds UI pieces shared between views.
+package components
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"sheet/internal/dice"
+	"sheet/internal/storage"
+	"sheet/internal/ui/glyphs"
+)
+
+// DefaultRollHistorySize is how many rolls are kept and persisted.
+const DefaultRollHistorySize = 100
+
+// ToggleRollHistoryMsg asks the active view to show or hide the roll
+// history panel.
+type ToggleRollHistoryMsg struct{}
+
+// RollKind categorizes a roll history entry.
+type RollKind string
+
+const (
+	RollAttack RollKind = "attack"
+	RollSave   RollKind = "save"
+	RollCheck  RollKind = "check"
+	RollDamage RollKind = "damage"
+	RollOther  RollKind = "other"
+)
+
+// RollEntry is one roll in the history.
+type RollEntry struct {
+	Time      time.Time `json:"time"`
+	Kind      RollKind  `json:"kind"`
+	Label     string    `json:"label"`
+	Notation  string    `json:"notation"`
+	Breakdown string    `json:"breakdown"`
+	Total     int       `json:"total"`
+	Natural   int       `json:"natural,omitempty"`
+	Note      string    `json:"note,omitempty"`
+}
+
+// NewRollEntry builds an entry from a dice result.
+func NewRollEntry(kind RollKind, label string, res dice.Result) RollEntry {
+	return RollEntry{
+		Time:      time.Now(),
+		Kind:      kind,
+		Label:     label,
+		Notation:  res.Notation,
+		Breakdown: res.Breakdown(),
+		Total:     res.Total,
+		Natural:   res.Natural(),
+	}
+}
+
+// AddNote appends to the entry's note, separating it from any note
+// already there.
+func (e *RollEntry) AddNote(note string) {
+	if e.Note != "" {
+		note = e.Note + "; " + note
+	}
+	e.Note = note
+}
+
+// String formats the entry as a single history line.
+func (e RollEntry) String() string {
+	s := fmt.Sprintf("%s %-6s %s: %d", e.Time.Format("15:04"), e.Kind, e.Label, e.Total)
+	if e.Breakdown != "" {
+		s += "  " + e.Breakdown
+	}
+	if e.Note != "" {
+		s += "  (" + e.Note + ")"
+	}
+	return s
+}
+
+// RollHistory is a bounded, scrollable log of rolls. Entries are stored
+// oldest first and shown newest first.
+type RollHistory struct {
+	entries []RollEntry
+	limit   int
+	visible bool
+	offset  int
+}
+
+// NewRollHistory returns an empty history that keeps at most limit entries.
+func NewRollHistory(limit int) *RollHistory {
+	if limit < 1 {
+		limit = DefaultRollHistorySize
+	}
+	return &RollHistory{limit: limit}
+}
+
+// Add records a roll, discarding the oldest entry when full.
+func (h *RollHistory) Add(e RollEntry) {
+	h.entries = append(h.entries, e)
+	if len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+	// New rolls appear at the top, so jump back there.
+	h.offset = 0
+}
+
+// Entries returns the recorded rolls, oldest first.
+func (h *RollHistory) Entries() []RollEntry {
+	out := make([]RollEntry, len(h.entries))
+	copy(out, h.entries)
+	return out
+}
+
+// Last returns the most recent entry.
+func (h *RollHistory) Last() (RollEntry, bool) {
+	if len(h.entries) == 0 {
+		return RollEntry{}, false
+	}
+	return h.entries[len(h.entries)-1], true
+}
+
+// UpdateLast replaces the most recent entry, e.g. after a reroll.
+func (h *RollHistory) UpdateLast(e RollEntry) {
+	if len(h.entries) == 0 {
+		h.Add(e)
+		return
+	}
+	h.entries[len(h.entries)-1] = e
+}
+
+// Toggle shows or hides the panel.
+func (h *RollHistory) Toggle() {
+	h.visible = !h.visible
+	h.offset = 0
+}
+
+// Visible reports whether the panel is shown.
+func (h *RollHistory) Visible() bool {
+	return h.visible
+}
+
+// ScrollUp moves toward newer entries.
+func (h *RollHistory) ScrollUp() {
+	if h.offset > 0 {
+		h.offset--
+	}
+}
+
+// ScrollDown moves toward older entries.
+func (h *RollHistory) ScrollDown() {
+	if h.offset < len(h.entries)-1 {
+		h.offset++
+	}
+}
+
+// View renders up to height lines of history, newest first, each truncated
+// to width runes.
+func (h *RollHistory) View(width, height int, g glyphs.Set) string {
+	if len(h.entries) == 0 {
+		return "No rolls yet."
+	}
+	var lines []string
+	for i := len(h.entries) - 1 - h.offset; i >= 0 && len(lines) < height; i-- {
+		lines = append(lines, truncate(h.entries[i].String(), width, g.Ellipsis))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncate shortens s to width runes, ending it with ellipsis.
+func truncate(s string, width int, ellipsis string) string {
+	r := []rune(s)
+	if width <= 0 || len(r) <= width {
+		return s
+	}
+	e := []rune(ellipsis)
+	if width <= len(e) {
+		return string(e[:width])
+	}
+	return string(r[:width-len(e)]) + ellipsis
+}
+
+// RollHistoryPath returns the history file stored next to a character file,
+// e.g. "aragorn.json" → "aragorn.rolls.json".
+func RollHistoryPath(characterPath string) string {
+	return storage.SidecarPath(characterPath, storage.RollsSidecar)
+}
+
+// Save writes the history to path.
+func (h *RollHistory) Save(path string) error {
+	data, err := json.MarshalIndent(h.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal roll history: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write roll history: %w", err)
+	}
+	return nil
+}
+
+// LoadRollHistory reads a history saved by Save. A missing file yields an
+// empty history.
+func LoadRollHistory(path string, limit int) (*RollHistory, error) {
+	h := NewRollHistory(limit)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roll history: %w", err)
+	}
+
+	var entries []RollEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse roll history: %w", err)
+	}
+	for _, e := range entries {
+		h.Add(e)
+	}
+	return h, nil
+}
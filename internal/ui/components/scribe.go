@@ -0,0 +1,145 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sheet/internal/currency"
+	"sheet/internal/data"
+	"sheet/internal/spellbook"
+	"sheet/internal/ui/keys"
+)
+
+// ScribeStep is the wizard level-up's spellbook step. The first spells
+// picked are the level's free ones; after that each pick is scribed for
+// 50 gp and 2 hours per spell level, paid from the wallet.
+//
+//	up down   move
+//	enter     add the highlighted spell
+//	esc       finish the step
+type ScribeStep struct {
+	book     *spellbook.Spellbook
+	wallet   *currency.Wallet
+	spells   []data.Spell
+	maxLevel int
+	free     int
+	cursor   int
+	spent    time.Duration
+	status   string
+	done     bool
+}
+
+// NewScribeStep returns the step over the wizard spells that can be
+// added: spells of 1st level up to maxLevel that aren't known yet.
+func NewScribeStep(book *spellbook.Spellbook, wallet *currency.Wallet, spells []data.Spell, maxLevel int) *ScribeStep {
+	s := &ScribeStep{book: book, wallet: wallet, maxLevel: maxLevel, free: spellbook.FreeSpellsPerLevel}
+	for _, sp := range data.FilterSpells(spells, data.SpellFilter{Class: "Wizard", LevelSet: true, MinLevel: 1, MaxLevel: maxLevel}) {
+		if !book.Knows(sp.Name) {
+			s.spells = append(s.spells, sp)
+		}
+	}
+	return s
+}
+
+// Done reports whether the step is finished.
+func (s *ScribeStep) Done() bool {
+	return s.done
+}
+
+// FreeLeft returns how many free spells are still to be picked.
+func (s *ScribeStep) FreeLeft() int {
+	return s.free
+}
+
+// TimeSpent returns the total copying time of the spells scribed, for a
+// journal note.
+func (s *ScribeStep) TimeSpent() time.Duration {
+	return s.spent
+}
+
+// HandleKey reports whether the key was used.
+func (s *ScribeStep) HandleKey(key string) bool {
+	switch keys.Normalize(key) {
+	case "up", "k":
+		s.cursor = max(s.cursor-1, 0)
+	case "down", "j":
+		s.cursor = min(s.cursor+1, max(len(s.spells)-1, 0))
+	case "enter":
+		if len(s.spells) > 0 {
+			s.add(s.spells[s.cursor])
+		}
+	case "esc":
+		if s.free > 0 && len(s.spells) > 0 {
+			s.status = fmt.Sprintf("Pick %d more free spells first", s.free)
+			return true
+		}
+		s.done = true
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *ScribeStep) add(sp data.Spell) {
+	if s.free > 0 {
+		if err := s.book.LearnFree(sp, s.maxLevel); err != nil {
+			s.status = err.Error()
+			return
+		}
+		s.free--
+		s.status = "Added " + sp.Name
+	} else {
+		d, err := s.book.Scribe(s.wallet, sp, s.maxLevel)
+		if err != nil {
+			s.status = err.Error()
+			return
+		}
+		s.spent += d
+		s.status = fmt.Sprintf("Scribed %s: %s, %s", sp.Name, spellbook.ScribeCost(sp.Level), formatHours(d))
+	}
+	s.spells = append(s.spells[:s.cursor:s.cursor], s.spells[s.cursor+1:]...)
+	s.cursor = min(s.cursor, max(len(s.spells)-1, 0))
+}
+
+// formatHours formats a whole number of hours, e.g. "4 hours".
+func formatHours(d time.Duration) string {
+	h := int(d.Hours())
+	if h == 1 {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d hours", h)
+}
+
+// View lists the spells with what adding each costs.
+func (s *ScribeStep) View() string {
+	var b strings.Builder
+	if s.free > 0 {
+		fmt.Fprintf(&b, "Spellbook: %d free spells to add\n", s.free)
+	} else {
+		fmt.Fprintf(&b, "Spellbook: scribe more for 50 gp and 2 hours per level (%s on hand)\n", s.wallet.Coins)
+	}
+	b.WriteString("\n")
+	if len(s.spells) == 0 {
+		b.WriteString("  No more wizard spells to add\n")
+	}
+	for i, sp := range s.spells {
+		marker := "  "
+		if i == s.cursor {
+			marker = "> "
+		}
+		cost := "free"
+		if s.free == 0 {
+			cost = fmt.Sprintf("%s, %s", spellbook.ScribeCost(sp.Level), formatHours(spellbook.ScribeTime(sp.Level)))
+		}
+		fmt.Fprintf(&b, "%s%d  %s  (%s)\n", marker, sp.Level, sp.Name, cost)
+	}
+	if s.spent > 0 {
+		fmt.Fprintf(&b, "\nCopying time: %s\n", formatHours(s.spent))
+	}
+	if s.status != "" {
+		b.WriteString("\n" + s.status + "\n")
+	}
+	b.WriteString("\n" + hint("enter: add  esc: done"))
+	return b.String()
+}
@@ -0,0 +1,195 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/config"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+	"sheet/internal/ui/theme"
+)
+
+// settingRows are the settings the view can edit, in display order.
+var settingRows = []string{"Theme", "Vim keys", "Autosave", "Dice animation"}
+
+// autosaveStep is how far left/right moves the autosave interval.
+const autosaveStep = 15
+
+// SettingsEditor is the settings view. It edits either the global settings
+// or, when a character is given, that character's overrides; 'x' clears an
+// override so the row falls back to the global value.
+type SettingsEditor struct {
+	cfg       *config.Config
+	character string
+	cursor    int
+	dirty     bool
+}
+
+// NewSettingsEditor edits cfg in place. character is empty for the
+// global settings. The Theme row cycles through the registered themes.
+func NewSettingsEditor(cfg *config.Config, character string) *SettingsEditor {
+	return &SettingsEditor{cfg: cfg, character: character}
+}
+
+// Dirty reports whether the config has unsaved changes.
+func (e *SettingsEditor) Dirty() bool {
+	return e.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the config.
+func (e *SettingsEditor) MarkSaved() {
+	e.dirty = false
+}
+
+// HandleKey moves between rows; Enter, Space, left and right change the
+// selected setting. It reports whether the key was used.
+func (e *SettingsEditor) HandleKey(key string) bool {
+	key = keys.Normalize(key)
+	switch key {
+	case "up", "k":
+		e.cursor = max(e.cursor-1, 0)
+		return true
+	case "down", "j":
+		e.cursor = min(e.cursor+1, len(settingRows)-1)
+		return true
+	case "x":
+		if e.character == "" {
+			return false
+		}
+		e.clearOverride()
+		return true
+	case "enter", keys.Space, "right", "l":
+		e.change(1)
+		return true
+	case "left", "h":
+		e.change(-1)
+		return true
+	}
+	return false
+}
+
+func (e *SettingsEditor) change(dir int) {
+	cur := e.cfg.For(e.character)
+	o := e.cfg.Characters[e.character]
+	switch settingRows[e.cursor] {
+	case "Theme":
+		themes := theme.Names()
+		i := slices.Index(themes, cur.Theme)
+		next := themes[((i+dir)%len(themes)+len(themes))%len(themes)]
+		setSetting(e, &e.cfg.Theme, &o.Theme, next)
+	case "Vim keys":
+		setSetting(e, &e.cfg.VimKeys, &o.VimKeys, !cur.VimKeys)
+	case "Autosave":
+		setSetting(e, &e.cfg.AutosaveSeconds, &o.AutosaveSeconds, max(cur.AutosaveSeconds+dir*autosaveStep, 0))
+	case "Dice animation":
+		setSetting(e, &e.cfg.DiceAnimation, &o.DiceAnimation, !cur.DiceAnimation)
+	}
+	if e.character != "" {
+		e.cfg.SetOverrides(e.character, o)
+	}
+	e.applyTheme()
+	e.dirty = true
+}
+
+// setSetting writes v to the global field or, when editing a character,
+// to its override.
+func setSetting[T any](e *SettingsEditor, global *T, override **T, v T) {
+	if e.character == "" {
+		*global = v
+		return
+	}
+	*override = &v
+}
+
+func (e *SettingsEditor) clearOverride() {
+	o := e.cfg.Characters[e.character]
+	switch settingRows[e.cursor] {
+	case "Theme":
+		o.Theme = nil
+	case "Vim keys":
+		o.VimKeys = nil
+	case "Autosave":
+		o.AutosaveSeconds = nil
+	case "Dice animation":
+		o.DiceAnimation = nil
+	}
+	e.cfg.SetOverrides(e.character, o)
+	e.applyTheme()
+	e.dirty = true
+}
+
+// applyTheme switches to the theme now in effect so the change shows on
+// the next render. A name that isn't registered, e.g. one left in the
+// config by a removed custom theme, keeps the current theme.
+func (e *SettingsEditor) applyTheme() {
+	_ = theme.Set(e.cfg.For(e.character).Theme)
+}
+
+func (e *SettingsEditor) overridden(row string) bool {
+	o, ok := e.cfg.Characters[e.character]
+	if !ok {
+		return false
+	}
+	switch row {
+	case "Theme":
+		return o.Theme != nil
+	case "Vim keys":
+		return o.VimKeys != nil
+	case "Autosave":
+		return o.AutosaveSeconds != nil
+	case "Dice animation":
+		return o.DiceAnimation != nil
+	}
+	return false
+}
+
+// View renders the settings with their current values.
+func (e *SettingsEditor) View(g glyphs.Set) string {
+	s := e.cfg.For(e.character)
+	var b strings.Builder
+	if e.character == "" {
+		b.WriteString(title("Settings") + "\n\n")
+	} else {
+		b.WriteString(title("Settings for "+e.character) + "\n\n")
+	}
+	for i, row := range settingRows {
+		var value string
+		switch row {
+		case "Theme":
+			value = s.Theme
+		case "Vim keys":
+			value = onOff(s.VimKeys)
+		case "Autosave":
+			value = "off"
+			if s.AutosaveSeconds > 0 {
+				value = s.AutosaveInterval().String()
+			}
+		case "Dice animation":
+			value = onOff(s.DiceAnimation)
+		}
+		marker := "  "
+		if i == e.cursor {
+			marker = "> "
+		}
+		line := fmt.Sprintf("%s%-16s %s", marker, row, value)
+		if e.character != "" && e.overridden(row) {
+			line += " (character)"
+		}
+		b.WriteString(line + "\n")
+	}
+	help := "enter/" + g.ArrowLeft + "/" + g.ArrowRight + ": change"
+	if e.character != "" {
+		help += "  x: use global"
+	}
+	b.WriteString("\n" + hint(help))
+	return b.String()
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,40 @@
+package components
+
+import (
+	"testing"
+
+	"sheet/internal/config"
+	"sheet/internal/ui/theme"
+)
+
+func TestSettingsEditorTheme(t *testing.T) {
+	t.Cleanup(func() { theme.Set(theme.Dark.Name) })
+	tests := []struct {
+		name      string
+		character string
+		keys      []string
+		want      string
+	}{
+		{"next", "", []string{"enter"}, "high-contrast"},
+		{"previous", "", []string{"left"}, "colorblind"},
+		{"wraps around", "", []string{"right", "right", "right"}, "colorblind"},
+		{"character override", "Ash", []string{"right"}, "high-contrast"},
+		{"cleared override", "Ash", []string{"right", "x"}, "dark"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme.Set(theme.Dark.Name)
+			cfg := config.Default()
+			e := NewSettingsEditor(&cfg, tt.character)
+			for _, k := range tt.keys {
+				e.HandleKey(k)
+			}
+			if got := cfg.For(tt.character).Theme; got != tt.want {
+				t.Errorf("theme setting = %s, want %s", got, tt.want)
+			}
+			if got := theme.Current().Name; got != tt.want {
+				t.Errorf("current theme = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// SlotEditor is the spellcasting panel's slot editor, opened with 'S', for
+// slots the class table doesn't account for: Rod of the Pact Keeper, a
+// boon, or DM fiat. Warlocks get a Pact row below the nine levels for
+// their Pact Magic slots.
+//
+//	up down   move between slot levels
+//	+ -       regain or spend a slot of the highlighted level
+//	a         adjust the level's total: type "+1 Boon of Spell Recall"
+//	d         remove the level's most recent adjustment
+//	esc       close (or stop adjusting)
+type SlotEditor struct {
+	state     *rules.SpellSlotState
+	base      rules.SlotBase
+	cursor    int
+	adjusting bool
+	buffer    string
+	status    string
+	dirty     bool
+	done      bool
+}
+
+// NewSlotEditor returns an editor over state for a character whose classes
+// give base slots.
+func NewSlotEditor(state *rules.SpellSlotState, base rules.SlotBase) *SlotEditor {
+	return &SlotEditor{state: state, base: base}
+}
+
+// Done reports whether the editor should close.
+func (e *SlotEditor) Done() bool {
+	return e.done
+}
+
+// Adjusting reports whether an adjustment is being typed; the parent view
+// should then pass every key through.
+func (e *SlotEditor) Adjusting() bool {
+	return e.adjusting
+}
+
+// Dirty reports whether there are edits that haven't been saved.
+func (e *SlotEditor) Dirty() bool {
+	return e.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the character.
+func (e *SlotEditor) MarkSaved() {
+	e.dirty = false
+}
+
+// pactRow is the cursor position of the Pact row.
+const pactRow = 9
+
+// hasPact reports whether the Pact row is shown: the character has Pact
+// Magic slots or adjustments to them.
+func (e *SlotEditor) hasPact() bool {
+	return e.base.Pact > 0 || len(e.state.AdjustmentsAt(0, true)) > 0
+}
+
+// onPact reports whether the cursor is on the Pact row.
+func (e *SlotEditor) onPact() bool {
+	return e.cursor == pactRow
+}
+
+// level is the slot level under the cursor, on the nine level rows.
+func (e *SlotEditor) level() int {
+	return e.cursor + 1
+}
+
+// rowName names the row under the cursor in messages, e.g. "Level 2" or
+// "Pact".
+func (e *SlotEditor) rowName() string {
+	if e.onPact() {
+		return "Pact"
+	}
+	return fmt.Sprintf("Level %d", e.level())
+}
+
+// HandleKey reports whether the key was used.
+func (e *SlotEditor) HandleKey(key string) bool {
+	k := keys.Normalize(key)
+	if e.adjusting {
+		switch k {
+		case "enter":
+			e.submit()
+		case "esc":
+			e.adjusting, e.buffer = false, ""
+		default:
+			return typeInto(&e.buffer, key)
+		}
+		return true
+	}
+	switch k {
+	case "up", "k":
+		e.cursor = max(e.cursor-1, 0)
+	case "down", "j":
+		last := pactRow - 1
+		if e.hasPact() {
+			last = pactRow
+		}
+		e.cursor = min(e.cursor+1, last)
+	case "+", "-":
+		step := 1
+		if k == "-" {
+			step = -1
+		}
+		var err error
+		if e.onPact() {
+			err = e.state.SetPactRemaining(e.base, e.state.PactRemaining+step)
+		} else {
+			err = e.state.SetRemaining(e.base, e.level(), e.state.Remaining[e.cursor]+step)
+		}
+		if err != nil {
+			e.status = err.Error()
+			return true
+		}
+		e.status, e.dirty = "", true
+	case "a":
+		e.adjusting, e.status = true, ""
+	case "d":
+		e.removeLast()
+	case "esc":
+		e.done = true
+	default:
+		return false
+	}
+	return true
+}
+
+// submit parses the typed adjustment, a signed number then the source.
+func (e *SlotEditor) submit() {
+	amount, source, _ := strings.Cut(strings.TrimSpace(e.buffer), " ")
+	delta, err := strconv.Atoi(amount)
+	if err != nil || delta == 0 {
+		e.status = `Type a change and its source, e.g. "+1 Rod of the Pact Keeper"`
+		return
+	}
+	if e.onPact() {
+		err = e.state.AdjustPact(e.base, delta, source)
+	} else {
+		err = e.state.Adjust(e.base, e.level(), delta, source)
+	}
+	if err != nil {
+		e.status = err.Error()
+		return
+	}
+	e.status = fmt.Sprintf("%s slots %+d (%s)", e.rowName(), delta, strings.TrimSpace(source))
+	e.adjusting, e.buffer, e.dirty = false, "", true
+}
+
+func (e *SlotEditor) removeLast() {
+	for i := len(e.state.Adjustments) - 1; i >= 0; i-- {
+		a := e.state.Adjustments[i]
+		if a.Pact == e.onPact() && (a.Pact || a.Level == e.level()) {
+			e.state.RemoveAdjustment(e.base, i)
+			e.status = fmt.Sprintf("Removed %s from %s slots", a, strings.ToLower(e.rowName()))
+			e.dirty = true
+			return
+		}
+	}
+	e.status = fmt.Sprintf("No adjustments to %s slots", strings.ToLower(e.rowName()))
+}
+
+// View lists each slot level as left/total, with the class table's total
+// and the adjustments when they differ, e.g.
+// "> 1st  3/5  (4 +1 (Boon of Spell Recall))". The Pact row also shows
+// the slots' level.
+func (e *SlotEditor) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString(title("Spell Slots") + "\n")
+	row := func(i int, name string, left, total, base int, adj []rules.SlotAdjustment) {
+		marker := "  "
+		if i == e.cursor {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%-4s %d/%d", marker, name, left, total)
+		if len(adj) > 0 {
+			parts := make([]string, len(adj))
+			for j, a := range adj {
+				parts[j] = a.String()
+			}
+			fmt.Fprintf(&b, "  (%d %s)", base, strings.Join(parts, " "))
+		}
+		b.WriteString("\n")
+	}
+	for i, total := range e.state.Totals(e.base) {
+		row(i, ordinal(i+1), e.state.Remaining[i], total, e.base.Slots[i], e.state.AdjustmentsAt(i+1, false))
+	}
+	if e.hasPact() {
+		name := "Pact"
+		if e.base.PactLevel > 0 {
+			name = fmt.Sprintf("Pact (%s)", ordinal(e.base.PactLevel))
+		}
+		row(pactRow, name, e.state.PactRemaining, e.state.PactTotal(e.base), e.base.Pact, e.state.AdjustmentsAt(0, true))
+	}
+	if e.adjusting {
+		fmt.Fprintf(&b, "Adjust %s slots: %s%s\n", strings.ToLower(e.rowName()), e.buffer, g.TextCursor)
+	}
+	if e.status != "" {
+		b.WriteString(e.status + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// ordinal returns "1st", "2nd", "3rd", "4th" and so on, for spell levels.
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	}
+	return fmt.Sprintf("%dth", n)
+}
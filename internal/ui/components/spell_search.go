@@ -0,0 +1,158 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"sheet/internal/data"
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// castingTimes are the casting time filters, cycled with ctrl+t.
+var castingTimes = []string{"", data.CastAction, data.CastBonusAction, data.CastReaction}
+
+// SpellSearch is the filter state of the Add Spell overlay. Typing edits
+// the query; filters are toggled with keys that can't appear in a spell
+// name:
+//
+//	[ ]      lower/raise the maximum level
+//	{ }      lower/raise the minimum level
+//	ctrl+s   cycle school
+//	ctrl+r   ritual only
+//	ctrl+k   concentration: any, yes, no
+//	ctrl+d   cycle damage type
+//	ctrl+t   cycle casting time
+//	ctrl+f   search descriptions too
+type SpellSearch struct {
+	filter data.SpellFilter
+	// levelCap is the highest level the maximum can be raised to.
+	levelCap int
+}
+
+// NewSpellSearch returns a search limited to class's spell list.
+func NewSpellSearch(class string) *SpellSearch {
+	return &SpellSearch{filter: data.SpellFilter{Class: class, MaxLevel: 9}, levelCap: 9}
+}
+
+// CapLevel limits the search to spells of level n and below, the highest
+// the class can learn (Budget.MaxLevel), so the overlay doesn't offer
+// spells the character can't learn yet.
+func (s *SpellSearch) CapLevel(n int) {
+	s.levelCap = min(max(n, 0), 9)
+	f := &s.filter
+	f.LevelSet = true
+	f.MaxLevel = min(f.MaxLevel, s.levelCap)
+	f.MinLevel = min(f.MinLevel, f.MaxLevel)
+}
+
+// Filter returns the current filter.
+func (s *SpellSearch) Filter() data.SpellFilter {
+	return s.filter
+}
+
+// Results filters and sorts spells.
+func (s *SpellSearch) Results(spells []data.Spell) []data.Spell {
+	return data.FilterSpells(spells, s.filter)
+}
+
+// HandleKey edits the query or toggles a filter. It reports whether the
+// key was used.
+func (s *SpellSearch) HandleKey(key string) bool {
+	f := &s.filter
+	switch keys.Normalize(key) {
+	case "[":
+		f.LevelSet = true
+		f.MaxLevel = max(f.MaxLevel-1, f.MinLevel)
+	case "]":
+		f.LevelSet = true
+		f.MaxLevel = min(f.MaxLevel+1, s.levelCap)
+	case "{":
+		f.LevelSet = true
+		f.MinLevel = max(f.MinLevel-1, 0)
+	case "}":
+		f.LevelSet = true
+		f.MinLevel = min(f.MinLevel+1, f.MaxLevel)
+	case "ctrl+s":
+		f.School = cycle(append([]string{""}, data.SpellSchools...), f.School)
+	case "ctrl+r":
+		f.RitualOnly = !f.RitualOnly
+	case "ctrl+k":
+		switch {
+		case f.Concentration == nil:
+			yes := true
+			f.Concentration = &yes
+		case *f.Concentration:
+			no := false
+			f.Concentration = &no
+		default:
+			f.Concentration = nil
+		}
+	case "ctrl+d":
+		types := []rules.DamageType{""}
+		types = append(types, rules.DamageTypes...)
+		f.DamageType = cycle(types, f.DamageType)
+	case "ctrl+t":
+		f.CastingTime = cycle(castingTimes, f.CastingTime)
+	case "ctrl+f":
+		f.FullText = !f.FullText
+	case "backspace":
+		if _, size := utf8.DecodeLastRuneInString(f.Query); size > 0 {
+			f.Query = f.Query[:len(f.Query)-size]
+		}
+	case keys.Space:
+		f.Query += " "
+	default:
+		if utf8.RuneCountInString(key) != 1 {
+			return false
+		}
+		f.Query += key
+	}
+	return true
+}
+
+// cycle returns the element after cur in opts, wrapping around.
+func cycle[T comparable](opts []T, cur T) T {
+	i := slices.Index(opts, cur)
+	return opts[(i+1)%len(opts)]
+}
+
+// Chips describes the active filters for the overlay header, e.g.
+// "lvl 1-2 · evocation · ritual".
+func (s *SpellSearch) Chips(g glyphs.Set) string {
+	f := s.filter
+	var chips []string
+	if f.LevelSet {
+		if f.MinLevel == f.MaxLevel {
+			chips = append(chips, fmt.Sprintf("lvl %d", f.MinLevel))
+		} else {
+			chips = append(chips, fmt.Sprintf("lvl %d-%d", f.MinLevel, f.MaxLevel))
+		}
+	}
+	if f.School != "" {
+		chips = append(chips, f.School)
+	}
+	if f.RitualOnly {
+		chips = append(chips, "ritual")
+	}
+	if f.Concentration != nil {
+		if *f.Concentration {
+			chips = append(chips, "concentration")
+		} else {
+			chips = append(chips, "no concentration")
+		}
+	}
+	if f.DamageType != "" {
+		chips = append(chips, string(f.DamageType))
+	}
+	if f.CastingTime != "" {
+		chips = append(chips, f.CastingTime)
+	}
+	if f.FullText {
+		chips = append(chips, "full text")
+	}
+	return strings.Join(chips, " "+g.Dot+" ")
+}
@@ -0,0 +1,13 @@
+package components
+
+import "sheet/internal/ui/theme"
+
+// title renders a view's heading in the current theme.
+func title(s string) string {
+	return theme.Current().Title.Render(s)
+}
+
+// hint renders a view's key help line in the current theme.
+func hint(s string) string {
+	return theme.Current().Muted.Render(s)
+}
@@ -0,0 +1,112 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// SubracePicker is the creation wizard's subrace step, shown after the
+// race step for races with subraces. Enter picks the highlighted subrace;
+// Esc goes back to the race step.
+type SubracePicker struct {
+	race   data.Race
+	cursor int
+	done   bool
+	back   bool
+}
+
+// NewSubracePicker returns the step for race, with current highlighted
+// when the player is revisiting it.
+func NewSubracePicker(race data.Race, current string) *SubracePicker {
+	p := &SubracePicker{race: race}
+	for i, s := range race.Subraces {
+		if strings.EqualFold(s.Name, current) {
+			p.cursor = i
+		}
+	}
+	return p
+}
+
+// Done reports whether a subrace was picked.
+func (p *SubracePicker) Done() bool {
+	return p.done
+}
+
+// Back reports whether the player went back to the race step.
+func (p *SubracePicker) Back() bool {
+	return p.back
+}
+
+// Subrace returns the highlighted subrace.
+func (p *SubracePicker) Subrace() data.Subrace {
+	return p.race.Subraces[p.cursor]
+}
+
+// HandleKey reports whether the key was used.
+func (p *SubracePicker) HandleKey(key string) bool {
+	switch keys.Normalize(key) {
+	case "up", "k":
+		p.cursor = max(p.cursor-1, 0)
+	case "down", "j":
+		p.cursor = min(p.cursor+1, max(len(p.race.Subraces)-1, 0))
+	case "enter":
+		p.done = len(p.race.Subraces) > 0
+	case "esc":
+		p.back = true
+	default:
+		return false
+	}
+	return true
+}
+
+// View lists the subraces with what each adds to the race.
+func (p *SubracePicker) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString(title(p.race.Name+" subrace") + "\n\n")
+	for i, s := range p.race.Subraces {
+		marker := "  "
+		if i == p.cursor {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%s", marker, s.Name)
+		if summary := subraceSummary(p.race, s, g); summary != "" {
+			b.WriteString("  " + summary)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// subraceSummary describes what a subrace adds, e.g. "WIS +1 · speed 35".
+func subraceSummary(race data.Race, s data.Subrace, g glyphs.Set) string {
+	var parts []string
+	for _, a := range rules.Abilities {
+		if n := s.AbilityBonuses[a]; n != 0 {
+			parts = append(parts, fmt.Sprintf("%s %+d", a, n))
+		}
+	}
+	if s.Speed > 0 && s.Speed != race.Speed {
+		parts = append(parts, fmt.Sprintf("speed %d", s.Speed))
+	}
+	if s.Darkvision > race.Darkvision {
+		parts = append(parts, fmt.Sprintf("darkvision %d ft", s.Darkvision))
+	}
+	for _, dt := range s.Resistances {
+		parts = append(parts, string(dt)+" resistance")
+	}
+	for _, sp := range s.Spells {
+		parts = append(parts, sp.Name)
+	}
+	if s.SpellChoice != nil {
+		parts = append(parts, "a cantrip")
+	}
+	if s.LanguageChoice > 0 {
+		parts = append(parts, "a language")
+	}
+	return strings.Join(parts, " "+g.Dot+" ")
+}
@@ -0,0 +1,122 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// TempHPEntry is the combat panel's temporary hit points overlay, opened
+// with 'T'. The player types the amount and its source, e.g. "8 False
+// Life"; Tab toggles whether they end at a short rest rather than a long
+// one. Temporary hit points don't stack, so a larger amount than the
+// character has asks before replacing them ('y' or 'n'), and a smaller
+// one is refused.
+type TempHPEntry struct {
+	temp    *rules.TempHP
+	buffer  string
+	expiry  rules.TempHPExpiry
+	offered *rules.TempHP
+	status  string
+	dirty   bool
+	done    bool
+}
+
+// NewTempHPEntry returns the overlay over temp.
+func NewTempHPEntry(temp *rules.TempHP) *TempHPEntry {
+	return &TempHPEntry{temp: temp}
+}
+
+// NewTempHPOffer returns the overlay asking whether offered, granted by
+// someone else, should replace temp.
+func NewTempHPOffer(temp *rules.TempHP, offered rules.TempHP) *TempHPEntry {
+	return &TempHPEntry{temp: temp, offered: &offered}
+}
+
+// Done reports whether the overlay should close.
+func (e *TempHPEntry) Done() bool {
+	return e.done
+}
+
+// Dirty reports whether there are edits that haven't been saved.
+func (e *TempHPEntry) Dirty() bool {
+	return e.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the character.
+func (e *TempHPEntry) MarkSaved() {
+	e.dirty = false
+}
+
+// HandleKey reports whether the key was used.
+func (e *TempHPEntry) HandleKey(key string) bool {
+	k := keys.Normalize(key)
+	if e.offered != nil {
+		switch k {
+		case "y", "enter":
+			*e.temp, e.dirty = *e.offered, true
+		case "n", "esc":
+		default:
+			return false
+		}
+		e.offered, e.done = nil, true
+		return true
+	}
+	switch k {
+	case "enter":
+		e.submit()
+	case "esc":
+		e.done = true
+	case "tab":
+		if e.expiry == rules.TempHPShortRest {
+			e.expiry = rules.TempHPLongRest
+		} else {
+			e.expiry = rules.TempHPShortRest
+		}
+	default:
+		return typeInto(&e.buffer, key)
+	}
+	return true
+}
+
+func (e *TempHPEntry) submit() {
+	amount, source, _ := strings.Cut(strings.TrimSpace(e.buffer), " ")
+	n, err := strconv.Atoi(amount)
+	if err != nil || n <= 0 {
+		e.status = `Type an amount and its source, e.g. "8 False Life"`
+		return
+	}
+	g := rules.TempHP{Amount: n, Source: strings.TrimSpace(source), Expiry: e.expiry}
+	switch apply, ask := e.temp.Offer(g); {
+	case apply:
+		*e.temp, e.dirty, e.done = g, true, true
+	case ask:
+		e.offered = &g
+	default:
+		e.status = fmt.Sprintf("Temporary hit points don't stack; keeping %s", *e.temp)
+	}
+}
+
+// View renders the prompt, or the replacement question.
+func (e *TempHPEntry) View(g glyphs.Set) string {
+	if e.offered != nil {
+		return fmt.Sprintf("Replace %s with %s? (y/n)", *e.temp, *e.offered)
+	}
+	var b strings.Builder
+	if e.temp.Amount > 0 {
+		b.WriteString("Current: " + e.temp.String() + "\n")
+	}
+	if e.status != "" {
+		b.WriteString(e.status + "\n")
+	}
+	until := "long rest"
+	if e.expiry == rules.TempHPShortRest {
+		until = "short rest"
+	}
+	fmt.Fprintf(&b, "Temp HP (until %s, tab to change): %s%s", until, e.buffer, g.TextCursor)
+	return b.String()
+}
@@ -0,0 +1,189 @@
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/dice"
+	"sheet/internal/effects"
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// ToolRoller is the tools section of the Skills panel: a cursor over the
+// character's tool proficiencies, with the keys of the other rolling
+// panels plus
+//
+//	u            cycle the tool's typical uses
+//	left right   change the ability for this check
+//
+// Picking a use sets the ability it calls for; the player can still
+// change it, since the DM decides which ability a check uses.
+type ToolRoller struct {
+	tools   []data.Tool
+	cursor  int
+	use     int
+	ability rules.Ability
+	mode    dice.Mode
+	last    *RollEntry
+	optOut
+}
+
+// NewToolRoller returns a roller over the named tools, described from db.
+// Tools not in db, such as homebrew ones, default to Intelligence.
+func NewToolRoller(names []string, db []data.Tool) *ToolRoller {
+	t := &ToolRoller{use: -1}
+	for _, n := range names {
+		tool, ok := data.FindTool(db, n)
+		if !ok {
+			tool = data.Tool{Name: n, Ability: rules.Intelligence}
+		}
+		t.tools = append(t.tools, tool)
+	}
+	t.reset()
+	return t
+}
+
+// Selected returns the tool under the cursor.
+func (t *ToolRoller) Selected() (data.Tool, bool) {
+	if len(t.tools) == 0 {
+		return data.Tool{}, false
+	}
+	return t.tools[t.cursor], true
+}
+
+// Use returns the selected use of the tool, if one is picked.
+func (t *ToolRoller) Use() (data.ToolUse, bool) {
+	tool, ok := t.Selected()
+	if !ok || t.use < 0 {
+		return data.ToolUse{}, false
+	}
+	return tool.Uses[t.use], true
+}
+
+// Ability returns the ability the next check uses.
+func (t *ToolRoller) Ability() rules.Ability {
+	return t.ability
+}
+
+// Mode returns the advantage state for the next roll.
+func (t *ToolRoller) Mode() dice.Mode {
+	return t.mode
+}
+
+// Last returns the most recent tool check.
+func (t *ToolRoller) Last() *RollEntry {
+	return t.last
+}
+
+// reset clears the use and goes back to the selected tool's ability.
+func (t *ToolRoller) reset() {
+	t.use = -1
+	if tool, ok := t.Selected(); ok {
+		t.ability = tool.Ability
+	}
+}
+
+// HandleKey reports whether the key was used and whether a roll was asked
+// for.
+func (t *ToolRoller) HandleKey(key string) (handled, roll bool) {
+	if len(t.tools) == 0 {
+		return false, false
+	}
+	switch keys.Normalize(key) {
+	case "u":
+		tool := t.tools[t.cursor]
+		if len(tool.Uses) == 0 {
+			return true, false
+		}
+		if t.use++; t.use >= len(tool.Uses) {
+			t.reset()
+		} else {
+			t.ability = tool.Uses[t.use].Ability
+		}
+		return true, false
+	case "left":
+		n := len(rules.Abilities)
+		t.ability = rules.Abilities[(slices.Index(rules.Abilities, t.ability)+n-1)%n]
+		return true, false
+	case "right":
+		t.ability = cycle(rules.Abilities, t.ability)
+		return true, false
+	}
+	prev := t.cursor
+	handled, roll = handleRollerKey(key, &t.cursor, len(t.tools), &t.mode)
+	if t.cursor != prev {
+		t.reset()
+	}
+	return handled, roll
+}
+
+// Roll rolls a check with the selected tool and ability. profs maps tool
+// names to proficiency, so expertise with a tool doubles the bonus. The
+// advantage toggle resets after each roll; the use and ability stay for a
+// retry.
+func (t *ToolRoller) Roll(r *dice.Roller, scores rules.Scores, profs map[string]rules.Proficiency, profBonus int, active []effects.Effect) (RollEntry, []effects.Effect, error) {
+	tool, ok := t.Selected()
+	if !ok {
+		return RollEntry{}, nil, fmt.Errorf("no tool proficiencies")
+	}
+	mod := rules.CheckModifier(scores[t.ability], profs[tool.Name], profBonus)
+	roll := effects.Roll{Type: effects.CheckRoll, Ability: t.ability}
+	active = t.optOut.apply(active)
+
+	expr, applied, err := effects.D20Roll(roll, mod, t.mode, active)
+	if err != nil {
+		return RollEntry{}, nil, err
+	}
+
+	label := fmt.Sprintf("%s (%s) check", tool.Name, t.ability)
+	if use, ok := t.Use(); ok {
+		label = fmt.Sprintf("%s: %s (%s)", tool.Name, use.Name, t.ability)
+	}
+	entry := NewRollEntry(RollCheck, label, r.Roll(expr))
+	var notes []string
+	if t.mode != dice.Normal {
+		notes = append(notes, t.mode.String())
+	}
+	if use, ok := t.Use(); ok && use.DC > 0 {
+		notes = append(notes, fmt.Sprintf("typical DC %d", use.DC))
+	}
+	entry.Note = strings.Join(notes, "; ")
+	t.last = &entry
+	t.mode = dice.Normal
+	return entry, applied, nil
+}
+
+// View lists the tools with the selected one's description, use and
+// ability.
+func (t *ToolRoller) View(g glyphs.Set) string {
+	var b strings.Builder
+	b.WriteString(title("Tools") + "\n")
+	if len(t.tools) == 0 {
+		b.WriteString("  No tool proficiencies")
+		return b.String()
+	}
+	for i, tool := range t.tools {
+		marker := "  "
+		if i == t.cursor {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%s\n", marker, tool.Name)
+	}
+	tool := t.tools[t.cursor]
+	if tool.Description != "" {
+		b.WriteString("\n" + tool.Description + "\n")
+	}
+	use := "general check"
+	if u, ok := t.Use(); ok {
+		use = u.Name
+	}
+	fmt.Fprintf(&b, "\nUse: %s (u)  Ability: %s (%s/%s)", use, t.ability, g.ArrowLeft, g.ArrowRight)
+	if t.mode != dice.Normal {
+		fmt.Fprintf(&b, "  %s", t.mode)
+	}
+	return b.String()
+}
@@ -0,0 +1,163 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sheet/internal/ui/keys"
+)
+
+// TourStep is one callout in the guided tour. Panel names the main sheet
+// panel the view should highlight, if any.
+type TourStep struct {
+	Title string
+	Body  string
+	Panel string
+	Keys  []string
+}
+
+// DefaultTour walks a new user through creating a character and the main
+// sheet's panels.
+var DefaultTour = []TourStep{
+	{
+		Title: "Welcome",
+		Body:  "This tour creates a sample character and shows you around the sheet. Press Enter to continue or Esc to skip; you can run it again from the settings.",
+	},
+	{
+		Title: "Create a character",
+		Body:  "Pick a race, class, background and ability scores. The sample character is saved like any other and can be deleted afterwards.",
+		Keys:  []string{"n"},
+	},
+	{
+		Title: "Abilities & Saving Throws",
+		Body:  "Select an ability and press Enter to roll a save. 'a' and 'd' toggle advantage and disadvantage.",
+		Panel: "abilities",
+		Keys:  []string{"enter", "a", "d"},
+	},
+	{
+		Title: "Skills",
+		Body:  "Roll any skill check from here. Active effects like Guidance are added automatically.",
+		Panel: "skills",
+		Keys:  []string{"enter"},
+	},
+	{
+		Title: "Combat",
+		Body:  "Track hit points, conditions and death saves.",
+		Panel: "combat",
+	},
+	{
+		Title: "Actions",
+		Body:  "Attacks, spells and features you can use on your turn.",
+		Panel: "actions",
+	},
+	{
+		Title: "Roll history",
+		Body:  "Every roll is kept with its breakdown. Toggle the history panel at any time.",
+		Keys:  []string{"h"},
+	},
+	{
+		Title: "You're ready",
+		Body:  "Press ? on any screen to see its hotkeys.",
+		Keys:  []string{"?"},
+	},
+}
+
+// Tour steps through callouts one at a time.
+type Tour struct {
+	steps   []TourStep
+	current int
+	done    bool
+}
+
+// NewTour returns a tour over steps, starting at the first.
+func NewTour(steps []TourStep) *Tour {
+	return &Tour{steps: steps, done: len(steps) == 0}
+}
+
+// Step returns the current callout.
+func (t *Tour) Step() TourStep {
+	return t.steps[t.current]
+}
+
+// Done reports whether the tour was finished or skipped.
+func (t *Tour) Done() bool {
+	return t.done
+}
+
+// HandleKey advances on Enter or Space, goes back on Backspace and skips
+// the rest of the tour on Esc.
+func (t *Tour) HandleKey(key string) bool {
+	if t.done {
+		return false
+	}
+	switch keys.Normalize(key) {
+	case "enter", keys.Space, "right":
+		if t.current == len(t.steps)-1 {
+			t.done = true
+		} else {
+			t.current++
+		}
+	case "backspace", "left":
+		if t.current > 0 {
+			t.current--
+		}
+	case "esc":
+		t.done = true
+	default:
+		return false
+	}
+	return true
+}
+
+// View renders the current callout to be drawn over the highlighted panel.
+func (t *Tour) View(width int) string {
+	if t.done {
+		return ""
+	}
+	step := t.Step()
+	var b strings.Builder
+	b.WriteString(title(fmt.Sprintf("%s (%d/%d)", step.Title, t.current+1, len(t.steps))) + "\n\n")
+	for _, line := range wrap(step.Body, width) {
+		b.WriteString(line + "\n")
+	}
+	if len(step.Keys) > 0 {
+		fmt.Fprintf(&b, "\nKeys: %s\n", strings.Join(step.Keys, ", "))
+	}
+	b.WriteString("\n" + hint("enter: next  backspace: back  esc: skip"))
+	return b.String()
+}
+
+// tourMarker is created in the config directory once the tour is finished
+// or skipped.
+const tourMarker = "tour-complete"
+
+// TourSeen reports whether the tour has been completed before, so it only
+// starts on first launch.
+func TourSeen(configDir string) bool {
+	_, err := os.Stat(filepath.Join(configDir, tourMarker))
+	return err == nil
+}
+
+// MarkTourSeen records that the tour was completed or skipped.
+func MarkTourSeen(configDir string) error {
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, tourMarker), nil, 0o644); err != nil {
+		return fmt.Errorf("failed to record tour: %w", err)
+	}
+	return nil
+}
+
+// ResetTour forgets that the tour was seen so it runs on the next launch.
+func ResetTour(configDir string) error {
+	err := os.Remove(filepath.Join(configDir, tourMarker))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to reset tour: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/ui/keys"
+	"sheet/internal/ui/theme"
+	"sheet/internal/wildshape"
+)
+
+// WildShapeToggle is the main sheet's Wild Shape key. 'W' reverts when
+// transformed, or opens a picker over the druid's saved forms; Enter
+// transforms into the selected beast.
+type WildShapeToggle struct {
+	ws         *wildshape.WildShape
+	beasts     []data.Monster
+	druidLevel int
+	moon       bool
+
+	picking bool
+	cursor  int
+	status  string
+	dirty   bool
+}
+
+// NewWildShapeToggle returns the toggle for ws. beasts are the stat blocks
+// the saved forms are looked up in.
+func NewWildShapeToggle(ws *wildshape.WildShape, beasts []data.Monster, druidLevel int, moon bool) *WildShapeToggle {
+	return &WildShapeToggle{ws: ws, beasts: beasts, druidLevel: druidLevel, moon: moon}
+}
+
+// Picking reports whether the form picker is open; the parent view should
+// then pass every key through.
+func (w *WildShapeToggle) Picking() bool {
+	return w.picking
+}
+
+// Dirty reports whether the feature changed since the last save.
+func (w *WildShapeToggle) Dirty() bool {
+	return w.dirty
+}
+
+// MarkSaved clears the dirty flag after the caller saves the character.
+func (w *WildShapeToggle) MarkSaved() {
+	w.dirty = false
+}
+
+// HandleKey reports whether the key was used.
+func (w *WildShapeToggle) HandleKey(key string) bool {
+	k := keys.Normalize(key)
+	if !w.picking {
+		if k != "W" {
+			return false
+		}
+		if w.ws.Active != nil {
+			w.status = "Reverted from " + w.ws.Active.Beast
+			w.ws.Exit()
+			w.dirty = true
+			return true
+		}
+		w.picking, w.cursor, w.status = true, 0, ""
+		return true
+	}
+	switch k {
+	case "up", "k":
+		w.cursor = max(w.cursor-1, 0)
+	case "down", "j":
+		w.cursor = min(w.cursor+1, max(len(w.ws.Forms)-1, 0))
+	case "enter":
+		if len(w.ws.Forms) == 0 {
+			return true
+		}
+		name := w.ws.Forms[w.cursor]
+		m, ok := data.FindMonster(w.beasts, name)
+		if !ok {
+			w.status = fmt.Sprintf("No stat block named %q", name)
+			return true
+		}
+		if err := w.ws.Enter(m, w.druidLevel, w.moon); err != nil {
+			w.status = err.Error()
+			return true
+		}
+		w.picking = false
+		w.dirty = true
+		w.status = "Transformed into " + m.Name
+	case "esc", "W":
+		w.picking = false
+	default:
+		return false
+	}
+	return true
+}
+
+// View renders the active form's stats, or the picker while it is open.
+func (w *WildShapeToggle) View() string {
+	var b strings.Builder
+	switch {
+	case w.picking:
+		b.WriteString(title(fmt.Sprintf("Wild Shape (%d/%d)", w.ws.Uses.Remaining, w.ws.Uses.Max)) + "\n")
+		if len(w.ws.Forms) == 0 {
+			b.WriteString("  No saved forms\n")
+		}
+		for i, f := range w.ws.Forms {
+			marker := "  "
+			if i == w.cursor {
+				marker = "> "
+			}
+			b.WriteString(marker + f + "\n")
+		}
+	case w.ws.Active != nil:
+		f := w.ws.Active
+		hp := theme.Current().HP(f.HP, f.MaxHP).Render(fmt.Sprintf("HP %d/%d", f.HP, f.MaxHP))
+		fmt.Fprintf(&b, "Wild Shape: %s  %s  AC %d  Speed %s\n", f.Beast, hp, f.ArmorClass, f.Speed)
+	}
+	if w.status != "" {
+		b.WriteString(w.status + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,137 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sheet/internal/rules"
+	"sheet/internal/ui/glyphs"
+	"sheet/internal/ui/keys"
+)
+
+// XPEntry is the main sheet's XP overlay, opened with 'x' for characters
+// using XP progression. Digits type an amount (a leading '-' subtracts)
+// and Enter adds it. When the new total crosses level thresholds, the
+// levels earned are queued and offered one at a time: 'y' starts the
+// level-up for the next one, 'n' leaves the rest for later.
+type XPEntry struct {
+	xp      *int
+	level   int
+	table   rules.XPTable
+	buffer  string
+	pending []int
+	status  string
+	done    bool
+}
+
+// NewXPEntry returns the overlay over xp for a character at level, using
+// table (nil for the standard one).
+func NewXPEntry(xp *int, level int, table rules.XPTable) *XPEntry {
+	return &XPEntry{xp: xp, level: level, table: table}
+}
+
+// Done reports whether the overlay should close.
+func (x *XPEntry) Done() bool {
+	return x.done
+}
+
+// Offering returns the level whose level-up is being offered, or 0.
+func (x *XPEntry) Offering() int {
+	if len(x.pending) == 0 {
+		return 0
+	}
+	return x.pending[0]
+}
+
+// HandleKey reports whether the key was used and, when 'y' accepts an
+// offer, the level to run the level-up for. Call LevelTaken once it
+// finishes to offer the next level.
+func (x *XPEntry) HandleKey(key string) (handled bool, levelUp int) {
+	k := keys.Normalize(key)
+	if len(x.pending) > 0 {
+		switch k {
+		case "y", "enter":
+			return true, x.pending[0]
+		case "n", "esc":
+			x.pending = nil
+			x.done = true
+		default:
+			return false, 0
+		}
+		return true, 0
+	}
+	switch {
+	case k == "enter":
+		x.submit()
+	case k == "esc":
+		x.done = true
+	case k == "backspace":
+		if x.buffer != "" {
+			x.buffer = x.buffer[:len(x.buffer)-1]
+		}
+	case k == "-" && x.buffer == "":
+		x.buffer = "-"
+	case len(k) == 1 && k[0] >= '0' && k[0] <= '9':
+		x.buffer += k
+	default:
+		return false, 0
+	}
+	return true, 0
+}
+
+func (x *XPEntry) submit() {
+	amount, err := strconv.Atoi(x.buffer)
+	if err != nil {
+		x.status = "Enter an amount of XP"
+		return
+	}
+	gain, err := x.table.AddXP(*x.xp, amount, x.level)
+	if err != nil {
+		x.status = err.Error()
+		return
+	}
+	*x.xp = gain.To
+	x.buffer = ""
+	x.status = fmt.Sprintf("%+d XP: %d total", amount, gain.To)
+	x.pending = gain.Levels
+	if len(x.pending) == 0 {
+		x.done = true
+	}
+}
+
+// LevelTaken records that the offered level-up finished and moves on to
+// the next level, if any.
+func (x *XPEntry) LevelTaken() {
+	if len(x.pending) == 0 {
+		return
+	}
+	x.level = x.pending[0]
+	x.pending = x.pending[1:]
+	if len(x.pending) == 0 {
+		x.done = true
+	}
+}
+
+// View renders the prompt, or the level-up offer.
+func (x *XPEntry) View(g glyphs.Set) string {
+	var b strings.Builder
+	next := x.table.ForLevel(x.level + 1)
+	fmt.Fprintf(&b, "XP %d", *x.xp)
+	if x.level < x.table.MaxLevel() {
+		fmt.Fprintf(&b, " (level %d at %d)", x.level+1, next)
+	}
+	b.WriteString("\n")
+	if x.status != "" {
+		b.WriteString(x.status + "\n")
+	}
+	if lvl := x.Offering(); lvl > 0 {
+		fmt.Fprintf(&b, "Level %d reached! Level up now? (y/n)", lvl)
+		if len(x.pending) > 1 {
+			fmt.Fprintf(&b, " (%d more after this)", len(x.pending)-1)
+		}
+		return b.String()
+	}
+	b.WriteString("Add XP: " + x.buffer + g.TextCursor)
+	return b.String()
+}
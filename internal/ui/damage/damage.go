@@ -0,0 +1,64 @@
+// Package damage is the registry of how damage types are shown: one colour
+// and symbol per type, used by the Actions panel, cast modal, roll history
+// and resistance lists so mixed-damage output reads the same everywhere.
+package damage
+
+import (
+	"fmt"
+
+	"sheet/internal/rules"
+)
+
+// Style is the presentation of one damage type. Color is an ANSI 256 colour
+// code in the same form the views pass to lipgloss.Color.
+type Style struct {
+	Color  string
+	Symbol string
+	ASCII  string
+}
+
+var registry = map[rules.DamageType]Style{
+	rules.Acid:        {Color: "112", Symbol: "🧪", ASCII: "ac"},
+	rules.Bludgeoning: {Color: "250", Symbol: "🔨", ASCII: "bl"},
+	rules.Cold:        {Color: "117", Symbol: "❄", ASCII: "co"},
+	rules.Fire:        {Color: "202", Symbol: "🔥", ASCII: "fi"},
+	rules.Force:       {Color: "135", Symbol: "✦", ASCII: "fo"},
+	rules.Lightning:   {Color: "226", Symbol: "⚡", ASCII: "li"},
+	rules.Necrotic:    {Color: "96", Symbol: "☠", ASCII: "ne"},
+	rules.Piercing:    {Color: "252", Symbol: "➶", ASCII: "pi"},
+	rules.Poison:      {Color: "34", Symbol: "☣", ASCII: "po"},
+	rules.Psychic:     {Color: "213", Symbol: "✺", ASCII: "ps"},
+	rules.Radiant:     {Color: "229", Symbol: "☀", ASCII: "ra"},
+	rules.Slashing:    {Color: "245", Symbol: "⚔", ASCII: "sl"},
+	rules.Thunder:     {Color: "69", Symbol: "≋", ASCII: "th"},
+}
+
+// unknown is used for types not in the registry, such as homebrew ones.
+var unknown = Style{Color: "244", Symbol: "?", ASCII: "??"}
+
+// StyleOf returns the style for a damage type.
+func StyleOf(dt rules.DamageType) Style {
+	if s, ok := registry[dt]; ok {
+		return s
+	}
+	return unknown
+}
+
+// Register adds or replaces the style for a damage type.
+func Register(dt rules.DamageType, s Style) {
+	registry[dt] = s
+}
+
+// Badge returns the symbol for a damage type, or its ASCII fallback.
+func Badge(dt rules.DamageType, ascii bool) string {
+	s := StyleOf(dt)
+	if ascii {
+		return s.ASCII
+	}
+	return s.Symbol
+}
+
+// Label formats an amount of damage with its badge, e.g. "7 🔥 fire".
+func Label(amount int, dt rules.DamageType, ascii bool) string {
+	return fmt.Sprintf("%d %s %s", amount, Badge(dt, ascii), dt)
+}
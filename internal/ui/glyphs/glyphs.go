@@ -0,0 +1,106 @@
+// Package glyphs holds the symbols the TUI draws with, in a Unicode set and
+// an ASCII fallback for terminals (older Windows consoles, some ConPTY
+// fonts) that render box drawing or emoji badly.
+package glyphs
+
+import (
+	"os"
+	"runtime"
+)
+
+// Set is a collection of symbols used by the views.
+type Set struct {
+	Bullet      string
+	Cursor      string
+	Check       string
+	Cross       string
+	Filled      string // filled pip: used slots, failed death saves
+	Empty       string // empty pip: available slots
+	Concentrate string
+	Ritual      string
+	Warning     string
+	Heart       string
+	Shield      string
+	ArrowUp     string
+	ArrowDown   string
+	ArrowLeft   string
+	ArrowRight  string
+	Separator   string
+	Dot         string // joins short items: "Cantrips 3/4 · Spells 6/7"
+	TextCursor  string // end of text being typed
+	None        string // stands in for an empty value
+	Times       string // quantities: "Torch ×5"
+	Ellipsis    string
+}
+
+// Unicode is the default symbol set.
+var Unicode = Set{
+	Bullet:      "•",
+	Cursor:      "▶",
+	Check:       "✓",
+	Cross:       "✗",
+	Filled:      "●",
+	Empty:       "○",
+	Concentrate: "◆",
+	Ritual:      "®",
+	Warning:     "⚠",
+	Heart:       "♥",
+	Shield:      "⛨",
+	ArrowUp:     "↑",
+	ArrowDown:   "↓",
+	ArrowLeft:   "←",
+	ArrowRight:  "→",
+	Separator:   "│",
+	Dot:         "·",
+	TextCursor:  "▏",
+	None:        "—",
+	Times:       "×",
+	Ellipsis:    "…",
+}
+
+// ASCII is the fallback symbol set.
+var ASCII = Set{
+	Bullet:      "*",
+	Cursor:      ">",
+	Check:       "+",
+	Cross:       "x",
+	Filled:      "#",
+	Empty:       "o",
+	Concentrate: "C",
+	Ritual:      "R",
+	Warning:     "!",
+	Heart:       "HP",
+	Shield:      "AC",
+	ArrowUp:     "^",
+	ArrowDown:   "v",
+	ArrowLeft:   "<",
+	ArrowRight:  ">",
+	Separator:   "|",
+	Dot:         "-",
+	TextCursor:  "_",
+	None:        "-",
+	Times:       "x",
+	Ellipsis:    "...",
+}
+
+// For returns the ASCII set when ascii is true and the Unicode set otherwise.
+func For(ascii bool) Set {
+	if ascii {
+		return ASCII
+	}
+	return Unicode
+}
+
+// PreferASCII reports whether the current terminal is likely to mangle
+// Unicode symbols. It is a default only; the config flag always wins.
+// Windows Terminal sets WT_SESSION and handles Unicode fine, while the
+// legacy console host does not.
+func PreferASCII() bool {
+	if os.Getenv("SHEET_ASCII") != "" {
+		return true
+	}
+	if runtime.GOOS == "windows" {
+		return os.Getenv("WT_SESSION") == ""
+	}
+	return os.Getenv("TERM") == "linux"
+}
@@ -0,0 +1,36 @@
+// Package keys normalizes key names so views match keys the same way on
+// every platform.
+package keys
+
+// Space is the canonical name for the space bar.
+const Space = "space"
+
+// Normalize maps the different spellings terminals and input layers use for
+// the same key onto one canonical name. The space bar arrives as " " on most
+// Unix terminals and as "space" through some Windows console paths; both
+// normalize to Space.
+func Normalize(key string) string {
+	switch key {
+	case " ", "space", "spacebar":
+		return Space
+	case "return", "ctrl+m":
+		return "enter"
+	case "escape", "ctrl+[":
+		return "esc"
+	case "backspace2", "ctrl+h":
+		return "backspace"
+	}
+	return key
+}
+
+// Matches reports whether key is one of the given bindings after
+// normalization.
+func Matches(key string, bindings ...string) bool {
+	key = Normalize(key)
+	for _, b := range bindings {
+		if Normalize(b) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+// Package theme holds the named styles views draw with, so colors are
+// chosen by meaning (title, error, low HP) rather than hard-coded, and the
+// whole UI can switch theme at runtime.
+package theme
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Style is a text style. Colors are ANSI 256 color codes in the same form
+// the views pass to lipgloss.Color; empty means the terminal default.
+type Style struct {
+	Foreground string
+	Background string
+	Bold       bool
+	Underline  bool
+	Reverse    bool
+}
+
+// Render wraps s in the escape codes for the style.
+func (st Style) Render(s string) string {
+	var codes []string
+	if st.Bold {
+		codes = append(codes, "1")
+	}
+	if st.Underline {
+		codes = append(codes, "4")
+	}
+	if st.Reverse {
+		codes = append(codes, "7")
+	}
+	if st.Foreground != "" {
+		codes = append(codes, "38;5;"+st.Foreground)
+	}
+	if st.Background != "" {
+		codes = append(codes, "48;5;"+st.Background)
+	}
+	if len(codes) == 0 {
+		return s
+	}
+	return fmt.Sprintf("\x1b[%sm%s\x1b[0m", strings.Join(codes, ";"), s)
+}
+
+// Theme is a set of semantic styles.
+type Theme struct {
+	Name string
+
+	Title     Style
+	Subtitle  Style
+	Text      Style
+	Muted     Style
+	Focused   Style
+	Border    Style
+	Selected  Style
+	Highlight Style
+
+	Error   Style
+	Warning Style
+	Success Style
+
+	HPGood Style
+	HPWarn Style
+	HPLow  Style
+}
+
+// HP returns the style for a hit point readout: good above half, warning
+// above a quarter, low otherwise.
+func (t Theme) HP(hp, maxHP int) Style {
+	switch {
+	case maxHP <= 0 || hp*2 > maxHP:
+		return t.HPGood
+	case hp*4 > maxHP:
+		return t.HPWarn
+	}
+	return t.HPLow
+}
+
+// Dark is the default theme, for dark terminal backgrounds.
+var Dark = Theme{
+	Name:      "dark",
+	Title:     Style{Foreground: "205", Bold: true},
+	Subtitle:  Style{Foreground: "141"},
+	Text:      Style{Foreground: "252"},
+	Muted:     Style{Foreground: "241"},
+	Focused:   Style{Foreground: "205"},
+	Border:    Style{Foreground: "62"},
+	Selected:  Style{Foreground: "229", Background: "57", Bold: true},
+	Highlight: Style{Foreground: "86"},
+	Error:     Style{Foreground: "196", Bold: true},
+	Warning:   Style{Foreground: "214"},
+	Success:   Style{Foreground: "42"},
+	HPGood:    Style{Foreground: "42"},
+	HPWarn:    Style{Foreground: "214"},
+	HPLow:     Style{Foreground: "196", Bold: true},
+}
+
+// Light is for light terminal backgrounds.
+var Light = Theme{
+	Name:      "light",
+	Title:     Style{Foreground: "125", Bold: true},
+	Subtitle:  Style{Foreground: "55"},
+	Text:      Style{Foreground: "235"},
+	Muted:     Style{Foreground: "245"},
+	Focused:   Style{Foreground: "125"},
+	Border:    Style{Foreground: "25"},
+	Selected:  Style{Foreground: "231", Background: "25", Bold: true},
+	Highlight: Style{Foreground: "30"},
+	Error:     Style{Foreground: "160", Bold: true},
+	Warning:   Style{Foreground: "130"},
+	Success:   Style{Foreground: "28"},
+	HPGood:    Style{Foreground: "28"},
+	HPWarn:    Style{Foreground: "130"},
+	HPLow:     Style{Foreground: "160", Bold: true},
+}
+
+// HighContrast uses only black, white and bright primaries, with
+// underlines and reverse video so focus doesn't rely on color.
+var HighContrast = Theme{
+	Name:      "high-contrast",
+	Title:     Style{Foreground: "15", Bold: true, Underline: true},
+	Subtitle:  Style{Foreground: "15", Bold: true},
+	Text:      Style{Foreground: "15"},
+	Muted:     Style{Foreground: "250"},
+	Focused:   Style{Foreground: "11", Bold: true},
+	Border:    Style{Foreground: "15"},
+	Selected:  Style{Reverse: true, Bold: true},
+	Highlight: Style{Foreground: "14", Bold: true},
+	Error:     Style{Foreground: "9", Bold: true, Underline: true},
+	Warning:   Style{Foreground: "11", Bold: true},
+	Success:   Style{Foreground: "10", Bold: true},
+	HPGood:    Style{Foreground: "10", Bold: true},
+	HPWarn:    Style{Foreground: "11", Bold: true},
+	HPLow:     Style{Foreground: "9", Bold: true, Reverse: true},
+}
+
+// Colorblind avoids red/green pairs, using blue and orange for good and
+// bad states.
+var Colorblind = Theme{
+	Name:      "colorblind",
+	Title:     Style{Foreground: "39", Bold: true},
+	Subtitle:  Style{Foreground: "111"},
+	Text:      Style{Foreground: "252"},
+	Muted:     Style{Foreground: "243"},
+	Focused:   Style{Foreground: "39"},
+	Border:    Style{Foreground: "67"},
+	Selected:  Style{Foreground: "16", Background: "39", Bold: true},
+	Highlight: Style{Foreground: "228"},
+	Error:     Style{Foreground: "208", Bold: true},
+	Warning:   Style{Foreground: "228"},
+	Success:   Style{Foreground: "33"},
+	HPGood:    Style{Foreground: "33"},
+	HPWarn:    Style{Foreground: "228"},
+	HPLow:     Style{Foreground: "208", Bold: true, Underline: true},
+}
+
+var (
+	mu      sync.RWMutex
+	themes  = map[string]Theme{}
+	current = Dark
+)
+
+func init() {
+	for _, t := range []Theme{Dark, Light, HighContrast, Colorblind} {
+		themes[t.Name] = t
+	}
+}
+
+// Register adds or replaces a theme, e.g. one loaded from the user's
+// config. Replacing the active theme takes effect on the next render.
+func Register(t Theme) {
+	mu.Lock()
+	defer mu.Unlock()
+	themes[t.Name] = t
+	if current.Name == t.Name {
+		current = t
+	}
+}
+
+// Names returns the registered theme names, sorted, for the settings view.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(themes))
+	for n := range themes {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Get returns the named theme.
+func Get(name string) (Theme, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	t, ok := themes[name]
+	return t, ok
+}
+
+// Current returns the active theme.
+func Current() Theme {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Set switches the active theme. Views pick it up on their next render.
+func Set(name string) error {
+	t, ok := Get(name)
+	if !ok {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = t
+	return nil
+}
@@ -0,0 +1,85 @@
+package theme
+
+import "testing"
+
+func TestHP(t *testing.T) {
+	tests := []struct {
+		hp, max int
+		want    Style
+	}{
+		{10, 10, Dark.HPGood},
+		{6, 10, Dark.HPGood},
+		{5, 10, Dark.HPWarn},
+		{3, 10, Dark.HPWarn},
+		{2, 10, Dark.HPLow},
+		{0, 10, Dark.HPLow},
+		{0, 0, Dark.HPGood},
+	}
+	for _, tt := range tests {
+		if got := Dark.HP(tt.hp, tt.max); got != tt.want {
+			t.Errorf("HP(%d, %d) = %+v, want %+v", tt.hp, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		style Style
+		want  string
+	}{
+		{Style{}, "hi"},
+		{Style{Bold: true}, "\x1b[1mhi\x1b[0m"},
+		{Style{Foreground: "42"}, "\x1b[38;5;42mhi\x1b[0m"},
+		{Style{Reverse: true, Foreground: "9", Background: "15"}, "\x1b[7;38;5;9;48;5;15mhi\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := tt.style.Render("hi"); got != tt.want {
+			t.Errorf("%+v.Render = %q, want %q", tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	t.Cleanup(func() { Set(Dark.Name) })
+	if err := Set("light"); err != nil {
+		t.Fatal(err)
+	}
+	if Current().Name != "light" {
+		t.Errorf("Current() = %s, want light", Current().Name)
+	}
+	if err := Set("neon"); err == nil {
+		t.Error("Set(neon) succeeded")
+	}
+	if Current().Name != "light" {
+		t.Errorf("after a failed Set, Current() = %s, want light", Current().Name)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() {
+		mu.Lock()
+		delete(themes, "neon")
+		mu.Unlock()
+		Register(Light)
+		Set(Dark.Name)
+	})
+	if err := Set(Light.Name); err != nil {
+		t.Fatal(err)
+	}
+	custom := Light
+	custom.Title = Style{Foreground: "201", Bold: true}
+	tests := []struct {
+		name string
+		reg  Theme
+		want Style
+	}{
+		{"other theme", Theme{Name: "neon", Title: Style{Foreground: "46"}}, Light.Title},
+		{"active theme", custom, custom.Title},
+	}
+	for _, tt := range tests {
+		Register(tt.reg)
+		if got := Current().Title; got != tt.want {
+			t.Errorf("%s: Current().Title = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package wildshape lets a druid take the form of a beast: which beasts
+// are allowed, the stats shown while transformed, and the form's own hit
+// points on top of the character's.
+package wildshape
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sheet/internal/data"
+	"sheet/internal/rest"
+	"sheet/internal/rules"
+)
+
+// UsesPerRest is how many times Wild Shape can be used between short
+// rests.
+const UsesPerRest = 2
+
+// Limits are the restrictions on beast forms at a druid level.
+type Limits struct {
+	MaxCR float64
+	Swim  bool
+	Fly   bool
+	// Hours is how long a form lasts.
+	Hours int
+}
+
+// LimitsFor returns the Beast Shapes limits for a druid level. Circle of
+// the Moon druids use Combat Wild Shape's higher challenge ratings.
+func LimitsFor(druidLevel int, moon bool) Limits {
+	l := Limits{Hours: max(druidLevel/2, 1)}
+	switch {
+	case druidLevel >= 8:
+		l.MaxCR, l.Swim, l.Fly = 1, true, true
+	case druidLevel >= 4:
+		l.MaxCR, l.Swim = 0.5, true
+	default:
+		l.MaxCR = 0.25
+	}
+	if moon {
+		l.MaxCR = max(l.MaxCR, 1, float64(druidLevel/3))
+	}
+	return l
+}
+
+// Eligible reports why a stat block can't be used as a form at a druid
+// level, or nil if it can.
+func Eligible(m data.Monster, druidLevel int, moon bool) error {
+	if druidLevel < 2 {
+		return fmt.Errorf("wild shape needs druid level 2")
+	}
+	if !strings.HasPrefix(strings.ToLower(m.Type), "beast") {
+		return fmt.Errorf("%s is not a beast", m.Name)
+	}
+	l := LimitsFor(druidLevel, moon)
+	cr, err := rules.ChallengeValue(m.CR)
+	if err != nil {
+		return err
+	}
+	if cr > l.MaxCR {
+		return fmt.Errorf("%s is CR %s, above the limit at druid level %d", m.Name, m.CR, druidLevel)
+	}
+	speed := strings.ToLower(m.Speed)
+	if !l.Swim && strings.Contains(speed, "swim") {
+		return fmt.Errorf("%s has a swimming speed, allowed from druid level 4", m.Name)
+	}
+	if !l.Fly && strings.Contains(speed, "fly") {
+		return fmt.Errorf("%s has a flying speed, allowed from druid level 8", m.Name)
+	}
+	return nil
+}
+
+// Form is the beast form the druid is in.
+type Form struct {
+	// Beast is the name of the stat block.
+	Beast      string       `json:"beast"`
+	HP         int          `json:"hp"`
+	MaxHP      int          `json:"max_hp"`
+	ArmorClass int          `json:"armor_class"`
+	Speed      string       `json:"speed"`
+	Abilities  rules.Scores `json:"abilities"`
+}
+
+// WildShape is a druid's Wild Shape feature: the beasts they have saved as
+// favourite forms, the remaining uses, and the active form if any.
+type WildShape struct {
+	// Forms are the stat blocks the player has saved for quick access.
+	Forms  []string      `json:"forms,omitempty"`
+	Uses   rest.Resource `json:"uses"`
+	Active *Form         `json:"active,omitempty"`
+}
+
+// New returns the feature with full uses.
+func New() *WildShape {
+	return &WildShape{Uses: rest.Resource{
+		Name:      "Wild Shape",
+		Remaining: UsesPerRest,
+		Max:       UsesPerRest,
+		Recovery:  rest.OnShortRest,
+	}}
+}
+
+// SaveForm adds a stat block to the saved forms.
+func (w *WildShape) SaveForm(beast string) {
+	if !slices.ContainsFunc(w.Forms, func(f string) bool { return strings.EqualFold(f, beast) }) {
+		w.Forms = append(w.Forms, beast)
+	}
+}
+
+// Enter spends a use and takes the form of m.
+func (w *WildShape) Enter(m data.Monster, druidLevel int, moon bool) error {
+	if w.Active != nil {
+		return fmt.Errorf("already in the form of %s", w.Active.Beast)
+	}
+	if err := Eligible(m, druidLevel, moon); err != nil {
+		return err
+	}
+	if w.Uses.Remaining < 1 {
+		return fmt.Errorf("no uses of Wild Shape left")
+	}
+	w.Uses.Remaining--
+	w.Active = &Form{
+		Beast:      m.Name,
+		HP:         m.HP,
+		MaxHP:      m.HP,
+		ArmorClass: m.ArmorClass,
+		Speed:      m.Speed,
+		Abilities:  m.Abilities,
+	}
+	return nil
+}
+
+// Exit reverts to the druid's normal form.
+func (w *WildShape) Exit() {
+	w.Active = nil
+}
+
+// Damage applies damage to the form. When the form drops to 0 HP the
+// druid reverts, and the damage left over carries to their normal form; it
+// is returned so the caller can apply it.
+func (w *WildShape) Damage(amount int) (overflow int) {
+	if w.Active == nil {
+		return amount
+	}
+	w.Active.HP -= amount
+	if w.Active.HP > 0 {
+		return 0
+	}
+	overflow = -w.Active.HP
+	w.Exit()
+	return overflow
+}
+
+// Heal restores the form's hit points, up to its maximum.
+func (w *WildShape) Heal(amount int) {
+	if w.Active != nil {
+		w.Active.HP = min(w.Active.HP+amount, w.Active.MaxHP)
+	}
+}
+
+// Scores returns the ability scores to display: the beast's STR, DEX and
+// CON with the druid's own INT, WIS and CHA while transformed, or the
+// druid's scores otherwise.
+func (w *WildShape) Scores(own rules.Scores) rules.Scores {
+	if w.Active == nil {
+		return own
+	}
+	out := make(rules.Scores, len(rules.Abilities))
+	for _, a := range rules.Abilities {
+		out[a] = own[a]
+	}
+	for _, a := range []rules.Ability{rules.Strength, rules.Dexterity, rules.Constitution} {
+		out[a] = w.Active.Abilities[a]
+	}
+	return out
+}
+
+// Rest restores the uses; both short and long rests refill them.
+func (w *WildShape) Rest() {
+	w.Uses.Remaining = w.Uses.Max
+}